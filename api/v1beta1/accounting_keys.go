@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
@@ -61,14 +62,73 @@ func (o *Accounting) AuthStorageRef() corev1.SecretKeySelector {
 }
 
 func (o *Accounting) AuthSlurmKey() types.NamespacedName {
+	ref := o.AuthSlurmRef()
 	return types.NamespacedName{
-		Name:      o.Spec.SlurmKeyRef.Name,
+		Name:      ref.Name,
 		Namespace: o.Namespace,
 	}
 }
 
+// AuthSlurmRef returns the Secret key of the `auth/slurm` key, or of the
+// `slurm.jwks` when the keys are rotated. When the spec omits the key, it is
+// the generated key of the status.
 func (o *Accounting) AuthSlurmRef() corev1.SecretKeySelector {
-	return o.Spec.SlurmKeyRef
+	switch {
+	case o.Spec.SlurmKeyRotation != nil:
+		return corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: o.AuthSlurmJwksKey().Name,
+			},
+			Key: AuthSlurmJwksFile,
+		}
+	case !o.IsAuthSlurmRefOmitted():
+		return o.Spec.SlurmKeyRef
+	case o.Status.SlurmKeyRef != nil:
+		return *o.Status.SlurmKeyRef
+	default:
+		return o.AuthSlurmGeneratedRef()
+	}
+}
+
+// IsAuthSlurmRefOmitted reports if the spec gives no `auth/slurm` key.
+func (o *Accounting) IsAuthSlurmRefOmitted() bool {
+	return o.Spec.SlurmKeyRotation == nil && o.Spec.SlurmKeyRef.Name == ""
+}
+
+// AuthSlurmGeneratedRef returns the Secret key of the generated `auth/slurm`
+// key.
+func (o *Accounting) AuthSlurmGeneratedRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-slurm-key", o.Key().Name),
+		},
+		Key: AuthSlurmKeyFile,
+	}
+}
+
+// AuthSlurmJwksKey returns the key of the Secret which contains the
+// `slurm.jwks` of the rotated `auth/slurm` keys.
+func (o *Accounting) AuthSlurmJwksKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-slurm-jwks", o.Key().Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// AuthRotationKeys returns the keys of the Secrets of the rotated `auth/slurm`
+// keys.
+func (o *Accounting) AuthRotationKeys() []types.NamespacedName {
+	keys := []types.NamespacedName{}
+	if o.Spec.SlurmKeyRotation == nil {
+		return keys
+	}
+	for _, key := range o.Spec.SlurmKeyRotation.Keys {
+		keys = append(keys, types.NamespacedName{
+			Name:      key.SecretRef.Name,
+			Namespace: o.Namespace,
+		})
+	}
+	return keys
 }
 
 // Deprecated: use AuthJwtKey() instead.
@@ -89,16 +149,34 @@ func (o *Accounting) AuthJwtKey() types.NamespacedName {
 	}
 }
 
-// NOTE: Return non-nil because this field is effectively required.
+// AuthJwtRef returns the Secret key of the `auth/jwt` key which signs. When
+// the spec omits the key, it is the generated key of the status.
 func (o *Accounting) AuthJwtRef() corev1.SecretKeySelector {
-	var refPtr *corev1.SecretKeySelector
 	switch {
 	case o.Spec.JwtKeyRef != nil:
-		refPtr = o.Spec.JwtKeyRef
+		return *o.Spec.JwtKeyRef
 	case o.Spec.JwtHs256KeyRef != nil:
-		refPtr = o.Spec.JwtHs256KeyRef
+		return *o.Spec.JwtHs256KeyRef
+	case o.Status.JwtKeyRef != nil:
+		return *o.Status.JwtKeyRef
+	default:
+		return o.AuthJwtGeneratedRef()
+	}
+}
+
+// IsAuthJwtRefOmitted reports if the spec gives no `auth/jwt` key.
+func (o *Accounting) IsAuthJwtRefOmitted() bool {
+	return o.Spec.JwtKeyRef == nil && o.Spec.JwtHs256KeyRef == nil
+}
+
+// AuthJwtGeneratedRef returns the Secret key of the generated `auth/jwt` key.
+func (o *Accounting) AuthJwtGeneratedRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-jwt-key", o.Key().Name),
+		},
+		Key: AuthJwtKeyFile,
 	}
-	return ptr.Deref(refPtr, corev1.SecretKeySelector{})
 }
 
 func (o *Accounting) AuthJwksKey() types.NamespacedName {
@@ -119,3 +197,11 @@ func (o *Accounting) ConfigKey() types.NamespacedName {
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *Accounting) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *Accounting) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -18,11 +18,11 @@ var (
 )
 
 // AccountingSpec defines the desired state of Accounting
-// +kubebuilder:validation:XValidation:rule="!self.external ? has(self.slurmKeyRef) : true", message="slurmKeyRef must be set when external is false"
-// +kubebuilder:validation:XValidation:rule="!self.external ? has(self.jwtKeyRef) || has(self.jwtHs256KeyRef) : true", message="jwtKeyRef or jwtHs256KeyRef must be set when external is false"
 // +kubebuilder:validation:XValidation:rule="self.external ? has(self.externalConfig) : true", message="externalConfig must be set when external is true"
 type AccountingSpec struct {
 	// Slurm `auth/slurm` key authentication.
+	// If omitted, the key is generated, and shared by the Controllers which
+	// reference the Accounting.
 	// +optional
 	SlurmKeyRef corev1.SecretKeySelector `json:"slurmKeyRef,omitzero"`
 
@@ -33,6 +33,8 @@ type AccountingSpec struct {
 	JwtHs256KeyRef *corev1.SecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
 
 	// Slurm `auth/jwt` JWT key authentication.
+	// If omitted, with JwtHs256KeyRef, the key is generated, and shared by the
+	// Controllers which reference the Accounting.
 	// +optional
 	JwtKeyRef *corev1.SecretKeySelector `json:"jwtKeyRef,omitzero"`
 
@@ -40,6 +42,11 @@ type AccountingSpec struct {
 	// +optional
 	JwksKeyRef *corev1.ConfigMapKeySelector `json:"jwksKeyRef,omitempty"`
 
+	// SlurmKeyRotation rotates the Slurm `auth/slurm` keys, which are
+	// rendered as `slurm.jwks`. If set, SlurmKeyRef is ignored.
+	// +optional
+	SlurmKeyRotation *AuthKeyRotation `json:"slurmKeyRotation,omitempty"`
+
 	// external indicates if this component is external to Kubernetes or not.
 	// If true, then externalConfig is used and other fields are ignored.
 	// +optional
@@ -122,11 +129,24 @@ type AccountingStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// SlurmKeyRotation is the observed state of the `auth/slurm` key rotation.
+	// +optional
+	SlurmKeyRotation *AuthKeyRotationStatus `json:"slurmKeyRotation,omitempty"`
+
+	// SlurmKeyRef is a reference to the generated `auth/slurm` key, when the spec omits it.
+	// +optional
+	SlurmKeyRef *corev1.SecretKeySelector `json:"slurmKeyRef,omitempty"`
+
+	// JwtKeyRef is a reference to the generated `auth/jwt` key, when the spec omits it.
+	// +optional
+	JwtKeyRef *corev1.SecretKeySelector `json:"jwtKeyRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmdbd
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the Accounting is ready."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Accounting is the Schema for the accountings API
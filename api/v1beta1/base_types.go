@@ -9,6 +9,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PodTemplate describes a template for creating copies of a predefined pod.
@@ -206,3 +207,84 @@ type ExternalConfig struct {
 	// +required
 	Port int `json:"port,omitzero"`
 }
+
+// AuthKeyRotation defines the keys of an authentication method, which are all
+// accepted, and of which the active key signs. A new active key only signs
+// once every key has been accepted for the grace period, so all components are
+// rolled to accept it beforehand.
+type AuthKeyRotation struct {
+	// Keys are the keys which are accepted.
+	// +required
+	// +listType=map
+	// +listMapKey=id
+	// +kubebuilder:validation:MinItems=1
+	Keys []AuthKey `json:"keys"`
+
+	// ActiveKeyID is the ID of the key which signs.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ActiveKeyID string `json:"activeKeyId"`
+
+	// GracePeriod is how long every key is accepted before the active key
+	// signs. It must exceed the time to roll all components.
+	// +optional
+	// +default:="10m"
+	GracePeriod metav1.Duration `json:"gracePeriod,omitzero"`
+}
+
+// AuthKey defines a key of an AuthKeyRotation.
+type AuthKey struct {
+	// ID identifies the key (e.g. "2026-10"). The key of an ID must not be
+	// changed, rather added under a new ID.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	ID string `json:"id"`
+
+	// SecretRef is a reference to the Secret key which contains the key.
+	// +required
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+}
+
+// AuthKeyRotationStatus defines the observed state of an AuthKeyRotation.
+type AuthKeyRotationStatus struct {
+	// ActiveKeyID is the ID of the key which signs.
+	// +optional
+	ActiveKeyID string `json:"activeKeyId,omitempty"`
+
+	// KeyIDs are the IDs of the keys which are accepted.
+	// +optional
+	KeyIDs []string `json:"keyIds,omitempty"`
+
+	// LastTransitionTime is the last time the accepted keys or the active key changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitzero"`
+}
+
+// Key returns the key of the ID, or nil if there is none.
+func (o *AuthKeyRotation) Key(id string) *AuthKey {
+	for i := range o.Keys {
+		if o.Keys[i].ID == id {
+			return &o.Keys[i]
+		}
+	}
+	return nil
+}
+
+// SigningKeyID returns the ID of the key which signs, according to the status,
+// otherwise the spec.
+func (o *AuthKeyRotation) SigningKeyID(status *AuthKeyRotationStatus) string {
+	if status != nil && o.Key(status.ActiveKeyID) != nil {
+		return status.ActiveKeyID
+	}
+	return o.ActiveKeyID
+}
+
+// ActiveKeyRef returns the Secret key of the key which signs, according to
+// the status, otherwise the spec.
+func (o *AuthKeyRotation) ActiveKeyRef(status *AuthKeyRotationStatus) corev1.SecretKeySelector {
+	if key := o.Key(o.SigningKeyID(status)); key != nil {
+		return key.SecretRef
+	}
+	return corev1.SecretKeySelector{}
+}
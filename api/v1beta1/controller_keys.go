@@ -5,8 +5,10 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
@@ -41,6 +43,24 @@ func (o *Controller) PrimaryFQDN() string {
 	return fmt.Sprintf("%s.%s", key, svc)
 }
 
+// BackupName returns the name of the backup slurmctld pod, when
+// HighAvailability is enabled.
+func (o *Controller) BackupName() string {
+	key := o.Key()
+	return fmt.Sprintf("%s-1", key.Name)
+}
+
+func (o *Controller) BackupFQDN() string {
+	key := o.BackupName()
+	svc := o.ServiceFQDNShort()
+	return fmt.Sprintf("%s.%s", key, svc)
+}
+
+// IsHighAvailability reports if a primary and backup slurmctld are run.
+func (o *Controller) IsHighAvailability() bool {
+	return !o.Spec.External && o.Spec.HighAvailability.Enabled
+}
+
 func (o *Controller) ServiceKey() types.NamespacedName {
 	key := o.Key()
 	return types.NamespacedName{
@@ -60,14 +80,73 @@ func (o *Controller) ServiceFQDNShort() string {
 }
 
 func (o *Controller) AuthSlurmKey() types.NamespacedName {
+	ref := o.AuthSlurmRef()
 	return types.NamespacedName{
-		Name:      o.Spec.SlurmKeyRef.Name,
+		Name:      ref.Name,
 		Namespace: o.Namespace,
 	}
 }
 
+// AuthSlurmRef returns the Secret key of the `auth/slurm` key, or of the
+// `slurm.jwks` when the keys are rotated. When the spec omits the key, it is
+// the shared or generated key of the status.
 func (o *Controller) AuthSlurmRef() corev1.SecretKeySelector {
-	return o.Spec.SlurmKeyRef
+	switch {
+	case o.Spec.SlurmKeyRotation != nil:
+		return corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: o.AuthSlurmJwksKey().Name,
+			},
+			Key: AuthSlurmJwksFile,
+		}
+	case !o.IsAuthSlurmRefOmitted():
+		return o.Spec.SlurmKeyRef
+	case o.Status.SlurmKeyRef != nil:
+		return *o.Status.SlurmKeyRef
+	default:
+		return o.AuthSlurmGeneratedRef()
+	}
+}
+
+// IsAuthSlurmRefOmitted reports if the spec gives no `auth/slurm` key.
+func (o *Controller) IsAuthSlurmRefOmitted() bool {
+	return o.Spec.SlurmKeyRotation == nil && o.Spec.SlurmKeyRef.Name == ""
+}
+
+// AuthSlurmGeneratedRef returns the Secret key of the generated `auth/slurm`
+// key.
+func (o *Controller) AuthSlurmGeneratedRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-slurm-key", o.Name),
+		},
+		Key: AuthSlurmKeyFile,
+	}
+}
+
+// AuthSlurmJwksKey returns the key of the Secret which contains the
+// `slurm.jwks` of the rotated `auth/slurm` keys.
+func (o *Controller) AuthSlurmJwksKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-slurm-jwks", o.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// AuthRotationKeys returns the keys of the Secrets of the rotated `auth/slurm`
+// keys.
+func (o *Controller) AuthRotationKeys() []types.NamespacedName {
+	keys := []types.NamespacedName{}
+	if o.Spec.SlurmKeyRotation == nil {
+		return keys
+	}
+	for _, key := range o.Spec.SlurmKeyRotation.Keys {
+		keys = append(keys, types.NamespacedName{
+			Name:      key.SecretRef.Name,
+			Namespace: o.Namespace,
+		})
+	}
+	return keys
 }
 
 // Deprecated: use AuthJwtKey() instead.
@@ -88,16 +167,43 @@ func (o *Controller) AuthJwtKey() types.NamespacedName {
 	}
 }
 
-// NOTE: Return non-nil because this field is effectively required.
+// AuthJwtRef returns the Secret key of the `auth/jwt` key which signs. When
+// the spec omits the key, it is the shared or generated key of the status.
 func (o *Controller) AuthJwtRef() corev1.SecretKeySelector {
-	var refPtr *corev1.SecretKeySelector
 	switch {
 	case o.Spec.JwtKeyRef != nil:
-		refPtr = o.Spec.JwtKeyRef
+		return *o.Spec.JwtKeyRef
 	case o.Spec.JwtHs256KeyRef != nil:
-		refPtr = o.Spec.JwtHs256KeyRef
+		return *o.Spec.JwtHs256KeyRef
+	case o.Status.JwtKeyRef != nil:
+		return *o.Status.JwtKeyRef
+	default:
+		return o.AuthJwtGeneratedRef()
+	}
+}
+
+// IsAuthJwtRefOmitted reports if the spec gives no `auth/jwt` key.
+func (o *Controller) IsAuthJwtRefOmitted() bool {
+	return o.Spec.JwtKeyRef == nil && o.Spec.JwtHs256KeyRef == nil
+}
+
+// AuthJwtGeneratedRef returns the Secret key of the generated `auth/jwt` key.
+func (o *Controller) AuthJwtGeneratedRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-jwt-key", o.Name),
+		},
+		Key: AuthJwtKeyFile,
+	}
+}
+
+// AuthJwtSigningRef returns the Secret key which signs the JWTs of the
+// operator, which is the `auth/jwt` key unless a JWKS key is given.
+func (o *Controller) AuthJwtSigningRef() corev1.SecretKeySelector {
+	if o.Spec.JwtSigningKeyRef != nil {
+		return *o.Spec.JwtSigningKeyRef
 	}
-	return ptr.Deref(refPtr, corev1.SecretKeySelector{})
+	return o.AuthJwtRef()
 }
 
 func (o *Controller) AuthJwksKey() types.NamespacedName {
@@ -112,9 +218,32 @@ func (o *Controller) AuthJwksRef() *corev1.ConfigMapKeySelector {
 	return o.Spec.JwksKeyRef
 }
 
+// IsTokenUserAllowed returns true if ServiceAccounts and pods may request JWTs
+// as the Slurm username. The privileged users must be listed explicitly.
+func (o *Controller) IsTokenUserAllowed(username string) bool {
+	if username == "" {
+		return false
+	}
+	if slices.Contains(o.Spec.TokenUsers, username) {
+		return true
+	}
+	if slices.Contains(PrivilegedTokenUsers, username) {
+		return false
+	}
+	return slices.Contains(o.Spec.TokenUsers, TokenUsersAll)
+}
+
 func (o *Controller) ConfigKey() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      fmt.Sprintf("%s-config", o.Name),
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *Controller) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *Controller) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -17,10 +17,21 @@ var (
 	ControllerAPIVersion = GroupVersion.String()
 )
 
+const (
+	// TokenUsersAll allows any Slurm username in TokenUsers, except the
+	// PrivilegedTokenUsers.
+	TokenUsersAll = "*"
+)
+
+var (
+	// PrivilegedTokenUsers are the Slurm usernames which must be listed in
+	// TokenUsers explicitly: `root` and the SlurmUser.
+	PrivilegedTokenUsers = []string{"root", "slurm"}
+)
+
 // ControllerSpec defines the desired state of Controller
-// +kubebuilder:validation:XValidation:rule="!self.external ? has(self.slurmKeyRef) : true", message="slurmKeyRef must be set when external is false"
-// +kubebuilder:validation:XValidation:rule="!self.external ? has(self.jwtKeyRef) || has(self.jwtHs256KeyRef) : true", message="jwtKeyRef or jwtHs256KeyRef must be set when external is false"
 // +kubebuilder:validation:XValidation:rule="self.external ? has(self.externalConfig) : true", message="externalConfig must be set when external is true"
+// +kubebuilder:validation:XValidation:rule="!has(self.jwtSigningKeyRef) || has(self.jwksKeyRef)", message="jwksKeyRef must be set when jwtSigningKeyRef is set"
 type ControllerSpec struct {
 	// The Slurm ClusterName, which uniquely identifies the Slurm Cluster to
 	// itself and accounting.
@@ -29,6 +40,8 @@ type ControllerSpec struct {
 	ClusterName string `json:"clusterName,omitzero"`
 
 	// Slurm `auth/slurm` key authentication.
+	// If omitted, the key of the referenced Accounting is used, otherwise it is
+	// generated.
 	// +optional
 	SlurmKeyRef corev1.SecretKeySelector `json:"slurmKeyRef,omitzero"`
 
@@ -39,6 +52,8 @@ type ControllerSpec struct {
 	JwtHs256KeyRef *corev1.SecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
 
 	// Slurm `auth/jwt` JWT key authentication.
+	// If omitted, with JwtHs256KeyRef, the key of the referenced Accounting is
+	// used, otherwise it is generated.
 	// +optional
 	JwtKeyRef *corev1.SecretKeySelector `json:"jwtKeyRef,omitzero"`
 
@@ -46,6 +61,27 @@ type ControllerSpec struct {
 	// +optional
 	JwksKeyRef *corev1.ConfigMapKeySelector `json:"jwksKeyRef,omitempty"`
 
+	// JwtSigningKeyRef is a PEM RSA (RS256) or ECDSA P-256 (ES256) private key,
+	// which signs the JWTs of the operator instead of the `auth/jwt` key. Its
+	// public key must be in the JWKS, by which Slurm verifies them, so the
+	// private key is not given to Slurm.
+	// +optional
+	JwtSigningKeyRef *corev1.SecretKeySelector `json:"jwtSigningKeyRef,omitempty"`
+
+	// SlurmKeyRotation rotates the Slurm `auth/slurm` keys, which are
+	// rendered as `slurm.jwks`. If set, SlurmKeyRef is ignored.
+	// +optional
+	SlurmKeyRotation *AuthKeyRotation `json:"slurmKeyRotation,omitempty"`
+
+	// TokenUsers are the Slurm usernames which ServiceAccounts, by the token
+	// exchange, and pods, by the pod token webhook, may request JWTs as. The
+	// entry "*" allows any username, except the privileged users (`root` and
+	// the SlurmUser `slurm`), which must be listed explicitly. If empty, none
+	// may be requested. Tokens themselves are not restricted.
+	// +optional
+	// +listType=set
+	TokenUsers []string `json:"tokenUsers,omitempty"`
+
 	// accountingRef is a reference to the Accounting CR to which this has membership.
 	// +optional
 	AccountingRef *corev1.LocalObjectReference `json:"accountingRef,omitempty"`
@@ -90,7 +126,14 @@ type ControllerSpec struct {
 	// +optional
 	Template PodTemplate `json:"template,omitempty"`
 
+	// SlurmConf defines common `slurm.conf` parameters as typed fields.
+	// A parameter set here must not also be set in ExtraConf.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html
+	// +optional
+	SlurmConf SlurmConf `json:"slurmConf,omitzero"`
+
 	// ExtraConf is appended onto the end of the `slurm.conf` file.
+	// It is for parameters without a field in SlurmConf.
 	// Ref: https://slurm.schedmd.com/slurm.conf.html
 	// +optional
 	ExtraConf string `json:"extraConf,omitempty"`
@@ -130,6 +173,12 @@ type ControllerSpec struct {
 	// +optional
 	Persistence ControllerPersistence `json:"persistence,omitzero"`
 
+	// HighAvailability runs a backup slurmctld, which takes over scheduling
+	// when the primary slurmctld is unavailable.
+	// Ref: https://slurm.schedmd.com/quickstart_admin.html#HA
+	// +optional
+	HighAvailability ControllerHighAvailability `json:"highAvailability,omitzero"`
+
 	// Service defines a template for a Kubernetes Service object.
 	// +optional
 	Service ServiceSpec `json:"service,omitzero"`
@@ -137,6 +186,25 @@ type ControllerSpec struct {
 	// Metrics defines the metric collection configuration.
 	// +optional
 	Metrics Metrics `json:"metrics,omitzero"`
+
+	// Topology generates the Slurm `topology.yaml` from Kubernetes node labels.
+	// The topology spec of each NodeSet pod is derived from the labels of its
+	// Kubernetes node, unless the node has the `topology.slinky.slurm.net/spec`
+	// annotation. It cannot be used with a `topology.yaml` or `topology.conf`
+	// in configFileRefs.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Topology []ControllerTopology `json:"topology,omitempty"`
+
+	// Gres derives the generic resources (GRES) of NodeSet pods from their
+	// extended resource limits (e.g. "nvidia.com/gpu"), and generates the
+	// matching `GresTypes` and `gres.conf`. A `gres.conf` in configFileRefs
+	// takes precedence over the generated one.
+	// Ref: https://slurm.schedmd.com/gres.html
+	// +optional
+	Gres ControllerGres `json:"gres,omitzero"`
 }
 
 type ControllerPersistence struct {
@@ -153,6 +221,300 @@ type ControllerPersistence struct {
 	corev1.PersistentVolumeClaimSpec `json:",inline"`
 }
 
+type ControllerHighAvailability struct {
+	// Enabled runs the primary and backup slurmctld as two replicas, which
+	// share the StateSaveLocation. The `persistence.existingClaim` must be a
+	// ReadWriteMany (or otherwise replicated) volume, and the service must be
+	// of type ClusterIP, as it is made headless. It cannot be changed after
+	// creation.
+	// +optional
+	// +default:=false
+	Enabled bool `json:"enabled,omitzero"`
+}
+
+// ControllerTopology defines a Slurm topology, whose switches or blocks are
+// derived from the label values of the Kubernetes nodes.
+// Exactly one of tree or block must be set.
+type ControllerTopology struct {
+	// Name is the name of the topology (e.g. "topo-switch").
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ClusterDefault uses this topology when a partition does not select one.
+	// At most one topology may be the cluster default.
+	// +optional
+	ClusterDefault bool `json:"clusterDefault,omitzero"`
+
+	// Tree defines a tree topology, of switches derived from node labels.
+	// +optional
+	Tree *ControllerTopologyTree `json:"tree,omitempty"`
+
+	// Block defines a block topology, of blocks derived from a node label.
+	// +optional
+	Block *ControllerTopologyBlock `json:"block,omitempty"`
+}
+
+// ControllerTopologyTree defines a tree topology.
+type ControllerTopologyTree struct {
+	// LabelKeys are the keys of Kubernetes node labels, ordered from the
+	// top-level switch to the leaf switch (e.g. "topology.kubernetes.io/zone",
+	// then a rack label). Each distinct label value is a switch, under the
+	// switch of the preceding label, and all top-level switches are under a
+	// "root" switch. Nodes without all labels are not placed in the topology.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	LabelKeys []string `json:"labelKeys"`
+}
+
+// ControllerTopologyBlock defines a block topology.
+type ControllerTopologyBlock struct {
+	// LabelKey is the key of a Kubernetes node label (e.g.
+	// "topology.kubernetes.io/zone"). Each distinct label value is a block.
+	// Nodes without the label are not placed in the topology.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	LabelKey string `json:"labelKey"`
+
+	// BlockSizes are the planning base block sizes.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html#OPT_block_sizes
+	// +optional
+	// +listType=atomic
+	BlockSizes []int32 `json:"blockSizes,omitempty"`
+}
+
+// ControllerGres defines the GRES of NodeSet pods.
+type ControllerGres struct {
+	// Resources maps the extended resources of NodeSet pods to GRES. The
+	// limit of each resource on the slurmd container is the GRES count of the
+	// Slurm node.
+	// +optional
+	// +listType=map
+	// +listMapKey=resourceName
+	Resources []ControllerGresResource `json:"resources,omitempty"`
+
+	// AutoDetect is the mechanism used by slurmd to detect the devices of the
+	// GRES (e.g. "nvidia", "nvml"). Each slurmd only detects the devices
+	// allocated to its pod. If empty, the GRES only track counts.
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_AutoDetect
+	// +optional
+	AutoDetect string `json:"autoDetect,omitempty"`
+}
+
+// ControllerGresResource maps an extended resource to a GRES.
+type ControllerGresResource struct {
+	// ResourceName is the name of the extended resource (e.g. "nvidia.com/gpu").
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Name is the name of the GRES (e.g. "gpu").
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the type of the GRES (e.g. "h100").
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// SlurmConf defines typed `slurm.conf` parameters.
+// Unset fields are omitted, so the Slurm default is used.
+type SlurmConf struct {
+	// Scheduling defines the scheduler parameters.
+	// +optional
+	Scheduling SlurmConfScheduling `json:"scheduling,omitzero"`
+
+	// Priority defines the job priority parameters.
+	// Ref: https://slurm.schedmd.com/priority_multifactor.html
+	// +optional
+	Priority SlurmConfPriority `json:"priority,omitzero"`
+
+	// Select defines the resource selection parameters.
+	// Ref: https://slurm.schedmd.com/cons_tres.html
+	// +optional
+	Select SlurmConfSelect `json:"select,omitzero"`
+
+	// Timeouts defines the timer parameters. They are rounded down to whole
+	// seconds.
+	// +optional
+	Timeouts SlurmConfTimeouts `json:"timeouts,omitzero"`
+}
+
+type SlurmConfScheduling struct {
+	// The scheduler plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerType
+	// +optional
+	// +kubebuilder:validation:Enum=sched/backfill;sched/builtin
+	SchedulerType string `json:"schedulerType,omitzero"`
+
+	// The scheduler plugin options (e.g. `bf_continue`, `bf_window=4320`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerParameters
+	// +optional
+	// +listType=set
+	SchedulerParameters []string `json:"schedulerParameters,omitempty"`
+
+	// The preemption plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptType
+	// +optional
+	// +kubebuilder:validation:Enum=preempt/none;preempt/partition_prio;preempt/qos
+	PreemptType string `json:"preemptType,omitzero"`
+
+	// The preemption mechanisms (e.g. `REQUEUE`, `SUSPEND,GANG`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptMode
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=OFF;CANCEL;GANG;REQUEUE;SUSPEND;PRIORITY;WITHIN
+	PreemptMode []string `json:"preemptMode,omitempty"`
+
+	// The maximum number of jobs slurmctld keeps in memory.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxJobCount
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxJobCount *int32 `json:"maxJobCount,omitempty"`
+
+	// The maximum job array task index, plus one.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxArraySize
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4000001
+	MaxArraySize *int32 `json:"maxArraySize,omitempty"`
+
+	// How a DOWN node is returned to service.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ReturnToService
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2
+	ReturnToService *int32 `json:"returnToService,omitempty"`
+}
+
+type SlurmConfPriority struct {
+	// The priority plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityType
+	// +optional
+	// +kubebuilder:validation:Enum=priority/basic;priority/multifactor
+	PriorityType string `json:"priorityType,omitzero"`
+
+	// The half-life of historical usage. It is rounded down to whole minutes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityDecayHalfLife
+	// +optional
+	DecayHalfLife *metav1.Duration `json:"decayHalfLife,omitempty"`
+
+	// The job age at which the age factor reaches its maximum. It is rounded
+	// down to whole minutes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityMaxAge
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAge
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightAge *int32 `json:"weightAge,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAssoc
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightAssoc *int32 `json:"weightAssoc,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightFairshare
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightFairshare *int32 `json:"weightFairshare,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightJobSize
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightJobSize *int32 `json:"weightJobSize,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightPartition
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightPartition *int32 `json:"weightPartition,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightQOS
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	WeightQOS *int32 `json:"weightQOS,omitempty"`
+
+	// The weight of each TRES (e.g. `cpu`, `mem`, `gres/gpu`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightTRES
+	// +optional
+	WeightTRES map[string]int32 `json:"weightTRES,omitempty"`
+}
+
+type SlurmConfSelect struct {
+	// The resource selection plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectType
+	// +optional
+	// +kubebuilder:validation:Enum=select/cons_tres;select/linear
+	SelectType string `json:"selectType,omitzero"`
+
+	// The resource selection plugin options (e.g. `CR_Core_Memory`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectTypeParameters
+	// +optional
+	// +listType=set
+	SelectTypeParameters []string `json:"selectTypeParameters,omitempty"`
+
+	// The default memory per allocated CPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerCPU
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DefMemPerCPU *int64 `json:"defMemPerCPU,omitempty"`
+
+	// The maximum memory per allocated CPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxMemPerCPU
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxMemPerCPU *int64 `json:"maxMemPerCPU,omitempty"`
+
+	// The default memory per allocated GPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerGPU
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DefMemPerGPU *int64 `json:"defMemPerGPU,omitempty"`
+
+	// The default number of CPUs per allocated GPU.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefCpuPerGPU
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DefCpuPerGPU *int32 `json:"defCpuPerGPU,omitempty"`
+}
+
+type SlurmConfTimeouts struct {
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmctldTimeout
+	// +optional
+	SlurmctldTimeout *metav1.Duration `json:"slurmctldTimeout,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmdTimeout
+	// +optional
+	SlurmdTimeout *metav1.Duration `json:"slurmdTimeout,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MessageTimeout
+	// +optional
+	MessageTimeout *metav1.Duration `json:"messageTimeout,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_InactiveLimit
+	// +optional
+	InactiveLimit *metav1.Duration `json:"inactiveLimit,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_KillWait
+	// +optional
+	KillWait *metav1.Duration `json:"killWait,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MinJobAge
+	// +optional
+	MinJobAge *metav1.Duration `json:"minJobAge,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_WaitTime
+	// +optional
+	WaitTime *metav1.Duration `json:"waitTime,omitempty"`
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_UnkillableStepTimeout
+	// +optional
+	UnkillableStepTimeout *metav1.Duration `json:"unkillableStepTimeout,omitempty"`
+}
+
 // ControllerStatus defines the observed state of Controller
 type ControllerStatus struct {
 	// Represents the latest available observations of a Controller's current state.
@@ -162,11 +524,41 @@ type ControllerStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Primary is the name of the slurmctld pod which is currently the primary.
+	// +optional
+	Primary string `json:"primary,omitempty"`
+
+	// SlurmVersion is the Slurm version reported by slurmctld for its nodes.
+	// While the cluster is being upgraded, it is a comma-separated list.
+	// +optional
+	SlurmVersion string `json:"slurmVersion,omitempty"`
+
+	// ConfigHash is the checksum of the Slurm configuration in effect.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// SlurmKeyRotation is the observed state of the `auth/slurm` key rotation.
+	// +optional
+	SlurmKeyRotation *AuthKeyRotationStatus `json:"slurmKeyRotation,omitempty"`
+
+	// SlurmKeyRef is a reference to the `auth/slurm` key, when the spec omits it. It
+	// is shared by the referenced Accounting, otherwise generated.
+	// +optional
+	SlurmKeyRef *corev1.SecretKeySelector `json:"slurmKeyRef,omitempty"`
+
+	// JwtKeyRef is a reference to the `auth/jwt` key, when the spec omits it. It is
+	// shared by the referenced Accounting, otherwise generated.
+	// +optional
+	JwtKeyRef *corev1.SecretKeySelector `json:"jwtKeyRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmctld
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the Controller is ready."
+// +kubebuilder:printcolumn:name="PRIMARY",type="string",JSONPath=".status.primary",description="The current primary slurmctld pod."
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.slurmVersion",priority=1,description="The Slurm version."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Controller is the Schema for the controllers API
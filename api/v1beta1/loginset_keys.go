@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
@@ -68,3 +69,11 @@ func (o *LoginSet) SshHostKeys() types.NamespacedName {
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *LoginSet) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *LoginSet) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -48,3 +49,11 @@ func (o *NodeSet) SshConfigKey() types.NamespacedName {
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *NodeSet) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *NodeSet) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -142,6 +142,196 @@ type NodeSetSpec struct {
 	// +optional
 	// +default:=false
 	OversubscribeNode bool `json:"oversubscribeNode,omitempty"`
+
+	// ScaleInStrategy controls how NodeSet pods are selected for deletion when scaling in.
+	// "JobAware" prefers pods whose Slurm node is idle, then runs fewer jobs, then
+	// whose jobs complete soonest; "Default" only considers Kubernetes pod state.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	// +kubebuilder:validation:Enum=Default;JobAware
+	// +kubebuilder:default:=JobAware
+	ScaleInStrategy NodeSetScaleInStrategyType `json:"scaleInStrategy,omitempty"`
+
+	// Autoscaling configures the native queue-driven autoscaler.
+	// When enabled, the operator manages `replicas` from the pending Slurm jobs
+	// which target the NodeSet partition, hence `partition.enabled` must be true.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	Autoscaling NodeSetAutoscaling `json:"autoscaling,omitzero"`
+
+	// PowerSave configures scale-from-zero with Slurm power saving.
+	// When enabled, the operator pre-registers Slurm nodes in the CLOUD state
+	// and only runs NodeSet pods for the nodes which slurmctld resumes;
+	// `replicas` is ignored.
+	// Used only when `scalingMode=StatefulSet`.
+	// Ref: https://slurm.schedmd.com/power_save.html
+	// +optional
+	PowerSave NodeSetPowerSave `json:"powerSave,omitzero"`
+
+	// Remediation configures the automatic remediation of NodeSet pods whose
+	// Slurm node is unhealthy. Unhealthy pods are drained in Slurm, then
+	// deleted once their jobs complete, to be recreated.
+	// +optional
+	Remediation NodeSetRemediation `json:"remediation,omitzero"`
+
+	// NodeFeatures publishes labels of the Kubernetes node of each pod as
+	// features of its Slurm node, so jobs can request them with `--constraint`.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Features
+	// +optional
+	NodeFeatures NodeSetNodeFeatures `json:"nodeFeatures,omitzero"`
+}
+
+// NodeSetScaleInStrategyType is a string enumeration of how a NodeSet selects
+// pods for deletion when scaling in.
+// +enum
+type NodeSetScaleInStrategyType string
+
+const (
+	// ScaleInStrategyDefault selects pods for deletion by their Kubernetes
+	// state (e.g. readiness, pod-deletion-cost, workload deadline, ordinal).
+	ScaleInStrategyDefault NodeSetScaleInStrategyType = "Default"
+
+	// ScaleInStrategyJobAware selects pods for deletion by the Slurm allocation
+	// state of their node (IDLE < MIXED < ALLOCATED), then by the number of
+	// running jobs, then by the remaining job time, before falling back to the
+	// Default ordering.
+	// This is the default.
+	ScaleInStrategyJobAware NodeSetScaleInStrategyType = "JobAware"
+)
+
+// NodeSetAutoscaling defines the queue-driven autoscaling configuration for the NodeSet.
+type NodeSetAutoscaling struct {
+	// Enabled will have the operator manage the NodeSet replicas.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower limit for the number of replicas.
+	// +optional
+	// +default:=0
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetPendingJobsPerNode is the number of pending Slurm jobs which
+	// warrant one additional replica.
+	// +optional
+	// +default:=1
+	// +kubebuilder:validation:Minimum=1
+	TargetPendingJobsPerNode int32 `json:"targetPendingJobsPerNode,omitempty"`
+
+	// ScaleUpCooldown is the minimum time between the last scale event and
+	// the next scale up.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="30s"
+	ScaleUpCooldown metav1.Duration `json:"scaleUpCooldown,omitzero"`
+
+	// ScaleDownCooldown is the minimum time between the last scale event and
+	// the next scale down.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="5m"
+	ScaleDownCooldown metav1.Duration `json:"scaleDownCooldown,omitzero"`
+}
+
+// NodeSetPowerSave defines the Slurm power saving configuration for the NodeSet.
+type NodeSetPowerSave struct {
+	// Enabled will have the operator create NodeSet pods on demand of slurmctld.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// MaxNodes is the number of Slurm nodes to pre-register, which is the
+	// upper limit for the number of NodeSet pods.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxNodes int32 `json:"maxNodes,omitempty"`
+
+	// NodeConf is added to the node line of pre-registered Slurm nodes, so
+	// slurmctld can schedule jobs to them before their pods exist
+	// (e.g. "CPUs=8 RealMemory=30000").
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^\\n]+$"
+	NodeConf string `json:"nodeConf,omitzero"`
+
+	// SuspendTime is how long a Slurm node must be idle before slurmctld
+	// suspends it, deleting its pod.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendTime_1
+	// +optional
+	// +kubebuilder:default:="10m"
+	SuspendTime metav1.Duration `json:"suspendTime,omitzero"`
+
+	// ResumeTimeout is how long slurmctld waits for a resumed Slurm node to
+	// register, after its pod is requested, before marking it DOWN.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ResumeTimeout_1
+	// +optional
+	// +kubebuilder:default:="10m"
+	ResumeTimeout metav1.Duration `json:"resumeTimeout,omitzero"`
+}
+
+// NodeSetRemediation defines the health remediation policy for the NodeSet.
+type NodeSetRemediation struct {
+	// Enabled will have the operator remediate pods with an unhealthy Slurm node.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// Conditions are the Slurm node state pod conditions (e.g.
+	// "SlurmNodeStateNotResponding") which mark a pod as unhealthy.
+	// +optional
+	// +listType=set
+	// +kubebuilder:default:={"SlurmNodeStateNotResponding","SlurmNodeStateFail","SlurmNodeStateInvalidReg"}
+	Conditions []corev1.PodConditionType `json:"conditions,omitempty"`
+
+	// UnhealthyDuration is how long one of the conditions must be true before
+	// the pod is remediated.
+	// +optional
+	// +kubebuilder:default:="5m"
+	UnhealthyDuration metav1.Duration `json:"unhealthyDuration,omitzero"`
+
+	// RecreateOnDifferentNode will have a remediated pod recreated on a
+	// different Kubernetes node than the one it was remediated from.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	// +default:=false
+	RecreateOnDifferentNode bool `json:"recreateOnDifferentNode,omitempty"`
+
+	// MaxUnhealthy is the maximum number of unhealthy pods for which remediation
+	// is allowed. When more pods are unhealthy (e.g. during a cluster-wide
+	// outage), remediation is paused.
+	// Value can be an absolute number (ex: 5) or a percentage of pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// +optional
+	// +kubebuilder:default:="50%"
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// MaxRemediations is the maximum number of pods which may begin remediation
+	// within the window.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	MaxRemediations int32 `json:"maxRemediations,omitempty"`
+
+	// Window is the period over which MaxRemediations is counted.
+	// +optional
+	// +kubebuilder:default:="10m"
+	Window metav1.Duration `json:"window,omitzero"`
+}
+
+// NodeSetNodeFeatures defines the Kubernetes node labels which are published
+// as Slurm node features.
+type NodeSetNodeFeatures struct {
+	// LabelKeys are the keys of Kubernetes node labels (e.g.
+	// "node.kubernetes.io/instance-type"). The value of each label on the
+	// Kubernetes node of a pod is added to the active and available features of
+	// its Slurm node, and updated when the label changes. The Slurm node features
+	// are replaced, so features not in the NodeSet or its labels are removed.
+	// +optional
+	// +listType=set
+	LabelKeys []string `json:"labelKeys,omitempty"`
 }
 
 // ScalingModeType is a string enumeration of how a NodeSet scales its pods.
@@ -207,6 +397,34 @@ type NodeSetUpdateStrategy struct {
 	// to be updated.
 	// +optional
 	ScheduledUpdate ScheduledUpdateNodeSetStrategy `json:"scheduledUpdate,omitempty"`
+
+	// Rollback configures the automatic rollback of failed revisions.
+	// +optional
+	Rollback NodeSetRollback `json:"rollback,omitzero"`
+
+	// RollbackTo is a previous revision to revert the NodeSet to, by its
+	// "controller-revision-hash" (e.g. from `status.revisions`) or its
+	// ControllerRevision name. The operator clears it once the NodeSet has
+	// been reverted.
+	// +optional
+	RollbackTo string `json:"rollbackTo,omitempty"`
+}
+
+// NodeSetRollback defines the automatic rollback of failed NodeSet revisions.
+type NodeSetRollback struct {
+	// Enabled will have the operator revert the NodeSet to the stable
+	// revision (see `status.stableRevision`) when pods of the update revision
+	// fail in Slurm.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// ProgressDeadline is how long pods of the update revision have to
+	// register with slurmctld. Within it, a pod whose Slurm node is
+	// NOT_RESPONDING or INVALID_REG fails the revision; after it, so does a
+	// pod whose Slurm node has not registered.
+	// +optional
+	// +kubebuilder:default:="10m"
+	ProgressDeadline metav1.Duration `json:"progressDeadline,omitzero"`
 }
 
 // PersistentVolumeClaimRetentionPolicyType is a string enumeration of the policies that will determine
@@ -281,6 +499,41 @@ type RollingUpdateNodeSetStrategy struct {
 	// +optional
 	// +kubebuilder:default:="25%"
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Partition indicates the ordinal at which the NodeSet should be
+	// partitioned for updates. During a rolling update, pods with an ordinal
+	// greater than or equal to the partition are updated; pods with a lower
+	// ordinal are not replaced by the rollout.
+	// Used only when `scalingMode=StatefulSet`.
+	// Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+
+	// Canary configures a canary stage at the start of each rollout.
+	// +optional
+	Canary NodeSetCanary `json:"canary,omitzero"`
+}
+
+// NodeSetCanary defines the canary stage of a NodeSet rolling update.
+type NodeSetCanary struct {
+	// Enabled will have each rollout first update only the canary pods, then
+	// pause until they have soaked in Slurm.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// Replicas is the number of pods updated during the canary stage.
+	// +optional
+	// +default:=1
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// SoakTime is how long the canary pods must be IDLE in Slurm (or running
+	// jobs), without any node failure (e.g. DOWN, FAIL, NOT_RESPONDING), before
+	// the rollout continues. A node failure restarts the soak.
+	// +optional
+	// +kubebuilder:default:="10m"
+	SoakTime metav1.Duration `json:"soakTime,omitzero"`
 }
 
 // ScheduledUpdateNodeSetStrategy is used to communicate parameters for
@@ -308,6 +561,52 @@ type ScheduledUpdateNodeSetStrategy struct {
 	Flags []string `json:"flags,omitempty"`
 }
 
+// NodeSetRevisionStatus defines the observed state of a NodeSet revision.
+type NodeSetRevisionStatus struct {
+	// Revision is the "controller-revision-hash" of the pods.
+	Revision string `json:"revision"`
+
+	// Total number of non-terminated pods of this revision.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Total number of pods of this revision with a Ready Condition.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Total number of available pods (ready for at least minReadySeconds) of this revision.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+}
+
+// NodeSetCanaryStatus defines the observed state of a NodeSet canary stage.
+type NodeSetCanaryStatus struct {
+	// Revision is the "controller-revision-hash" under canary.
+	Revision string `json:"revision"`
+
+	// SoakStartTime is when all canary pods were last observed healthy in
+	// Slurm, after which the soak time is counted.
+	// +optional
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+
+	// Promoted indicates the canary pods have soaked and the rollout continues.
+	// +optional
+	Promoted bool `json:"promoted,omitempty"`
+}
+
+// NodeSetRemediationStatus defines the observed state of a NodeSet pod remediation.
+type NodeSetRemediationStatus struct {
+	// Pod is the name of the remediated pod.
+	Pod string `json:"pod"`
+
+	// Node is the Kubernetes node the pod was remediated from.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// StartTime is when the remediation began.
+	StartTime metav1.Time `json:"startTime"`
+}
+
 // NodeSetPruneNodeRecordType is a string enumeration of how a NodeSet has its
 // Slurm node records pruned.
 // +enum
@@ -378,6 +677,40 @@ type NodeSetStatus struct {
 	// +optional
 	SlurmDrain int32 `json:"slurmDrain,omitempty"`
 
+	// The number of pending Slurm jobs which target the NodeSet partition.
+	// Only reported when autoscaling is enabled.
+	// +optional
+	SlurmPending int32 `json:"slurmPending,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the number of replicas.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Revisions reports the rollout progress of each NodeSet revision which
+	// still has pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=revision
+	Revisions []NodeSetRevisionStatus `json:"revisions,omitempty"`
+
+	// StableRevision is the last "controller-revision-hash" which every pod
+	// was updated to and registered with slurmctld, which is the target of an
+	// automatic rollback.
+	// +optional
+	StableRevision string `json:"stableRevision,omitempty"`
+
+	// Canary reports the canary stage of the current rollout.
+	// Only reported when the rolling update canary is enabled.
+	// +optional
+	Canary *NodeSetCanaryStatus `json:"canary,omitempty"`
+
+	// Remediations reports the NodeSet pods which were remediated within the
+	// remediation window, or are still being remediated.
+	// +optional
+	// +listType=map
+	// +listMapKey=pod
+	Remediations []NodeSetRemediationStatus `json:"remediations,omitempty"`
+
 	// observedGeneration is the most recent generation observed for this NodeSet. It corresponds to the
 	// NodeSet's generation, which is updated on mutation by the API Server.
 	// +optional
@@ -422,6 +755,7 @@ type NodeSetStatus struct {
 // +kubebuilder:printcolumn:name="ALLOCATED",type="integer",JSONPath=".status.slurmAllocated",priority=1,description="The number of ALLOCATED/MIXED slurm nodes."
 // +kubebuilder:printcolumn:name="DOWN",type="integer",JSONPath=".status.slurmDown",priority=1,description="The number of DOWN slurm nodes."
 // +kubebuilder:printcolumn:name="DRAIN",type="integer",JSONPath=".status.slurmDrain",priority=1,description="The number of DRAIN slurm nodes."
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.slurmPending",priority=1,description="The number of pending slurm jobs (autoscaling)."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // NodeSet is the Schema for the nodesets API
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *Partition) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Partition) ControllerKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Spec.ControllerRef.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// PartitionName returns the Slurm partition name.
+func (o *Partition) PartitionName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PartitionKind = "Partition"
+)
+
+var (
+	PartitionGVK        = GroupVersion.WithKind(PartitionKind)
+	PartitionAPIVersion = GroupVersion.String()
+)
+
+// PartitionSpec defines the desired state of Partition.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultTime) || !has(self.maxTime) || duration(self.defaultTime) <= duration(self.maxTime)", message="defaultTime must not exceed maxTime"
+type PartitionSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// The Slurm partition name.
+	// If empty, the object name is used.
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^\\s=,#]+$"
+	Name string `json:"name,omitzero"`
+
+	// nodeSetSelector selects the NodeSets, of the same Controller, whose nodes
+	// are in the partition.
+	// If unset, the partition has no nodes. An empty selector selects all
+	// NodeSets of the Controller.
+	// +optional
+	NodeSetSelector *metav1.LabelSelector `json:"nodeSetSelector,omitempty"`
+
+	// Default makes this the default partition, used by jobs which do not
+	// request one.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Default
+	// +optional
+	Default bool `json:"default,omitzero"`
+
+	// The maximum run time limit of jobs. It is rounded down to whole minutes.
+	// If unset, there is no limit.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxTime
+	// +optional
+	MaxTime *metav1.Duration `json:"maxTime,omitempty"`
+
+	// The run time limit of jobs which do not request one. It is rounded down
+	// to whole minutes.
+	// If unset, maxTime is used.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefaultTime
+	// +optional
+	DefaultTime *metav1.Duration `json:"defaultTime,omitempty"`
+
+	// The priority tier of the partition. Jobs in a higher tier are scheduled,
+	// and may preempt jobs, before those in a lower tier.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityTier
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65533
+	PriorityTier *int32 `json:"priorityTier,omitempty"`
+
+	// The accounts which may use the partition.
+	// If empty, all accounts may use it.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_AllowAccounts
+	// +optional
+	// +listType=set
+	AllowAccounts []string `json:"allowAccounts,omitempty"`
+
+	// The QOS whose limits are applied to jobs in the partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_QOS
+	// +optional
+	QOS string `json:"qos,omitzero"`
+
+	// OverSubscribe controls whether jobs may share the nodes of the partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_OverSubscribe
+	// +optional
+	// +kubebuilder:validation:Enum=No;Yes;Exclusive;Force
+	OverSubscribe PartitionOverSubscribe `json:"overSubscribe,omitzero"`
+
+	// Config is added to the partition line, for options without a field.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^\\n]+$"
+	Config string `json:"config,omitzero"`
+}
+
+// PartitionOverSubscribe is a string enumeration of the Slurm partition
+// OverSubscribe options.
+// +enum
+type PartitionOverSubscribe string
+
+const (
+	// PartitionOverSubscribeNo allocates cores, but not nodes, to one job at a time.
+	PartitionOverSubscribeNo PartitionOverSubscribe = "No"
+	// PartitionOverSubscribeYes allows jobs which request it to share resources.
+	PartitionOverSubscribeYes PartitionOverSubscribe = "Yes"
+	// PartitionOverSubscribeExclusive allocates whole nodes to each job.
+	PartitionOverSubscribeExclusive PartitionOverSubscribe = "Exclusive"
+	// PartitionOverSubscribeForce makes all jobs share resources.
+	PartitionOverSubscribeForce PartitionOverSubscribe = "Force"
+)
+
+// PartitionStatus defines the observed state of Partition
+type PartitionStatus struct{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=part
+// +kubebuilder:printcolumn:name="DEFAULT",type="boolean",JSONPath=".spec.default",description="If this is the default partition."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Partition is the Schema for the partitions API
+type Partition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PartitionSpec   `json:"spec,omitempty"`
+	Status PartitionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PartitionList contains a list of Partition
+type PartitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Partition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Partition{}, &PartitionList{})
+}
@@ -6,6 +6,7 @@ package v1beta1
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
@@ -35,3 +36,11 @@ func (o *RestApi) ServiceFQDNShort() string {
 	s := o.ServiceKey()
 	return domainname.FqdnShort(s.Name, s.Namespace)
 }
+
+func (o *RestApi) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *RestApi) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
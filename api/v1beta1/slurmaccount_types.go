@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmAccountKind = "SlurmAccount"
+)
+
+var (
+	SlurmAccountGVK        = GroupVersion.WithKind(SlurmAccountKind)
+	SlurmAccountAPIVersion = GroupVersion.String()
+)
+
+// SlurmAccountSpec defines the desired state of SlurmAccount.
+// Fields which are unset are not managed, and are left as they are in slurmdbd.
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultQOS) || (has(self.qos) && self.defaultQOS in self.qos)", message="defaultQOS must be in qos"
+type SlurmAccountSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting configured.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// The Slurm account name.
+	// If empty, the object name is used.
+	// +optional
+	Name string `json:"name,omitzero"`
+
+	// Description of the account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Description
+	// +optional
+	Description string `json:"description,omitzero"`
+
+	// Organization to which the account belongs.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Organization
+	// +optional
+	Organization string `json:"organization,omitzero"`
+
+	// The parent account of this account in the association tree.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Parent
+	// +optional
+	ParentAccount string `json:"parentAccount,omitzero"`
+
+	// The number of fairshare shares allocated to the account association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Fairshare
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// The QOS which the account association may use.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// The QOS used by jobs which do not request one.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQOS,omitzero"`
+
+	// What happens to the Slurm account when this object is deleted.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +default:="Delete"
+	DeletionPolicy SlurmdbDeletionPolicy `json:"deletionPolicy,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slurmaccounts
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="If the account is synced with slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmAccount is the Schema for the slurmaccounts API
+type SlurmAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmAccountSpec `json:"spec,omitempty"`
+	Status SlurmdbStatus    `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmAccountList contains a list of SlurmAccount
+type SlurmAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmAccount{}, &SlurmAccountList{})
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SlurmdbDeletionPolicy is a string enumeration of what happens to the Slurm
+// accounting entity when its Kubernetes object is deleted.
+// +enum
+type SlurmdbDeletionPolicy string
+
+const (
+	// SlurmdbDeletionPolicyDelete indicates that the entity is deleted from
+	// slurmdbd when the object is deleted.
+	// This is the default.
+	SlurmdbDeletionPolicyDelete SlurmdbDeletionPolicy = "Delete"
+
+	// SlurmdbDeletionPolicyRetain indicates that the entity is left in slurmdbd
+	// when the object is deleted.
+	SlurmdbDeletionPolicyRetain SlurmdbDeletionPolicy = "Retain"
+)
+
+// SlurmdbStatus defines the observed state of a Slurm accounting entity
+// (e.g. SlurmAccount, SlurmUser, SlurmQOS).
+type SlurmdbStatus struct {
+	// The generation observed by the controller at the last sync.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is when the entity was last reconciled with slurmdbd.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Drift is the list of spec fields which differed in slurmdbd at the last
+	// sync (e.g. changed by sacctmgr), and were reverted to the spec.
+	// +optional
+	// +listType=set
+	Drift []string `json:"drift,omitempty"`
+
+	// Represents the latest available observations of the entity's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
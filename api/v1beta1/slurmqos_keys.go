@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmQOS) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *SlurmQOS) ControllerKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Spec.ControllerRef.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// QOSName returns the Slurm QOS name.
+func (o *SlurmQOS) QOSName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
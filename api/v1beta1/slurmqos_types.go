@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmQOSKind = "SlurmQOS"
+)
+
+var (
+	SlurmQOSGVK        = GroupVersion.WithKind(SlurmQOSKind)
+	SlurmQOSAPIVersion = GroupVersion.String()
+)
+
+// SlurmQOSSpec defines the desired state of SlurmQOS.
+// Fields which are unset are not managed, and are left as they are in slurmdbd.
+type SlurmQOSSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting configured.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// The Slurm QOS name.
+	// If empty, the object name is used.
+	// +optional
+	Name string `json:"name,omitzero"`
+
+	// Description of the QOS.
+	// +optional
+	Description string `json:"description,omitzero"`
+
+	// The priority of jobs which use the QOS.
+	// Ref: https://slurm.schedmd.com/qos.html#priority
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// The flags of the QOS, as named by slurmrestd (e.g. `DENY_LIMIT`, `NO_RESERVE`).
+	// Ref: https://slurm.schedmd.com/qos.html
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// The maximum wall clock time of each job which uses the QOS.
+	// It is rounded down to whole minutes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxWallDurationPerJob
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+
+	// The maximum TRES of each job which uses the QOS, by TRES name
+	// (e.g. `cpu`, `mem` in megabytes, `gres/gpu`).
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRESPerJob
+	// +optional
+	MaxTRESPerJob map[string]int64 `json:"maxTRESPerJob,omitempty"`
+
+	// The maximum TRES of each user with running jobs which use the QOS,
+	// by TRES name (e.g. `cpu`, `mem` in megabytes, `gres/gpu`).
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRESPerUser
+	// +optional
+	MaxTRESPerUser map[string]int64 `json:"maxTRESPerUser,omitempty"`
+
+	// What happens to the Slurm QOS when this object is deleted.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +default:="Delete"
+	DeletionPolicy SlurmdbDeletionPolicy `json:"deletionPolicy,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=slurmqoses,shortName=slurmqos
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="If the QOS is synced with slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmQOS is the Schema for the slurmqoses API
+type SlurmQOS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmQOSSpec  `json:"spec,omitempty"`
+	Status SlurmdbStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmQOSList contains a list of SlurmQOS
+type SlurmQOSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmQOS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmQOS{}, &SlurmQOSList{})
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmUser) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *SlurmUser) ControllerKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Spec.ControllerRef.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// UserName returns the Slurm user name.
+func (o *SlurmUser) UserName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+// DefaultAccount returns the default Slurm account of the user.
+func (o *SlurmUser) DefaultAccount() string {
+	if o.Spec.DefaultAccount != "" {
+		return o.Spec.DefaultAccount
+	}
+	if len(o.Spec.Accounts) > 0 {
+		return o.Spec.Accounts[0]
+	}
+	return ""
+}
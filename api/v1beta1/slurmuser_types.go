@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmUserKind = "SlurmUser"
+)
+
+var (
+	SlurmUserGVK        = GroupVersion.WithKind(SlurmUserKind)
+	SlurmUserAPIVersion = GroupVersion.String()
+)
+
+// SlurmUserSpec defines the desired state of SlurmUser.
+// Fields which are unset are not managed, and are left as they are in slurmdbd.
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultAccount) || self.defaultAccount in self.accounts", message="defaultAccount must be in accounts"
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultQOS) || (has(self.qos) && self.defaultQOS in self.qos)", message="defaultQOS must be in qos"
+type SlurmUserSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting configured.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// The Slurm user name, which must match the Linux user name.
+	// If empty, the object name is used.
+	// +optional
+	Name string `json:"name,omitzero"`
+
+	// The accounts of which the user is a member.
+	// An association is created for each account, and associations with other
+	// accounts are removed.
+	// +required
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Accounts []string `json:"accounts"`
+
+	// The account used by jobs which do not request one.
+	// If empty, the first account is used.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultAccount
+	// +optional
+	DefaultAccount string `json:"defaultAccount,omitzero"`
+
+	// The Slurm administrator level of the user.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_AdminLevel
+	// +optional
+	// +kubebuilder:validation:Enum=None;Operator;Administrator
+	AdminLevel SlurmUserAdminLevel `json:"adminLevel,omitzero"`
+
+	// The number of fairshare shares allocated to each user association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Fairshare
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// The QOS which the user associations may use.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// The QOS used by jobs which do not request one.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQOS,omitzero"`
+
+	// What happens to the Slurm user when this object is deleted.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +default:="Delete"
+	DeletionPolicy SlurmdbDeletionPolicy `json:"deletionPolicy,omitzero"`
+}
+
+// SlurmUserAdminLevel is a string enumeration of the Slurm administrator levels.
+// +enum
+type SlurmUserAdminLevel string
+
+const (
+	SlurmUserAdminLevelNone          SlurmUserAdminLevel = "None"
+	SlurmUserAdminLevelOperator      SlurmUserAdminLevel = "Operator"
+	SlurmUserAdminLevelAdministrator SlurmUserAdminLevel = "Administrator"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slurmusers
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="If the user is synced with slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmUser is the Schema for the slurmusers API
+type SlurmUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmUserSpec `json:"spec,omitempty"`
+	Status SlurmdbStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmUserList contains a list of SlurmUser
+type SlurmUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmUser{}, &SlurmUserList{})
+}
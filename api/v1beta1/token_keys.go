@@ -8,6 +8,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 )
@@ -89,3 +90,11 @@ func (o *Token) SecretRef() corev1.SecretKeySelector {
 		Key: key,
 	}
 }
+
+func (o *Token) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+func (o *Token) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -18,7 +18,7 @@ var (
 )
 
 // TokenSpec defines the desired state of Token
-// +kubebuilder:validation:XValidation:rule="has(self.jwtKeyRef) || has(self.jwtHs256KeyRef)", message="jwtKeyRef or jwtHs256KeyRef must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.jwtKeyRef) || has(self.jwtHs256KeyRef) || has(self.controllerRef)", message="jwtKeyRef, jwtHs256KeyRef, or controllerRef must be set"
 type TokenSpec struct {
 	// Slurm `auth/jwt` JWT HS256 key authentication.
 	// +optional
@@ -30,6 +30,12 @@ type TokenSpec struct {
 	// +optional
 	JwtKeyRef *corev1.SecretKeySelector `json:"jwtKeyRef,omitempty"`
 
+	// ControllerRef is a reference to the Controller whose `auth/jwt` key
+	// signs the JWT, following its key rotation. It takes precedence over
+	// JwtKeyRef.
+	// +optional
+	ControllerRef *corev1.LocalObjectReference `json:"controllerRef,omitempty"`
+
 	// The username whom the token is created for.
 	// +required
 	Username string `json:"username,omitzero"`
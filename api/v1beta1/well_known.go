@@ -10,6 +10,8 @@ const (
 	NodeSetPrefix  = "nodeset." + SlinkyPrefix
 	LoginSetPrefix = "loginset." + SlinkyPrefix
 	TopologyPrefix = "topology." + SlinkyPrefix
+	SlurmdbPrefix  = "slurmdb." + SlinkyPrefix
+	TokenPrefix    = "token." + SlinkyPrefix
 )
 
 // Well Known Annotations
@@ -28,6 +30,16 @@ const (
 	// workload by. Pods with an earlier deadline are preferred to be deleted before pods with a later deadline.
 	// NOTE: this is honored on a best-effort basis, and does not offer guarantees on pod deletion order.
 	AnnotationPodDeadline = NodeSetPrefix + "pod-deadline"
+
+	// AnnotationPodRemediation stores a time.RFC3339 timestamp, indicating NodeSet Pods which are being remediated for
+	// an unhealthy Slurm node since that time. The Slurm node is drained, then the pod is deleted once its running
+	// workload completes.
+	AnnotationPodRemediation = NodeSetPrefix + "pod-remediation"
+
+	// AnnotationPodEviction stores a time.RFC3339 timestamp, indicating NodeSet Pods which the eviction webhook has
+	// cordoned, and whose Slurm node it has drained, for their last denied eviction. The NodeSet controller uncordons the
+	// pod and undrains its Slurm node when no eviction follows within a timeout (e.g. `kubectl drain` was aborted).
+	AnnotationPodEviction = NodeSetPrefix + "pod-eviction"
 )
 
 // Well Known Annotations for Objects of type corev1.Node
@@ -46,6 +58,26 @@ const (
 	AnnotationNodeHostnameOverride = NodeSetPrefix + "hostname-override"
 )
 
+// Well Known Annotations for Objects of type corev1.ServiceAccount and corev1.Pod
+const (
+	// AnnotationTokenUsername indicates the Slurm username of the ServiceAccount or Pod, as which the token exchange
+	// signs its JWTs, or its Token is created.
+	AnnotationTokenUsername = TokenPrefix + "username"
+
+	// AnnotationTokenController indicates the Controller, in the namespace of the ServiceAccount or Pod, whose key
+	// signs the JWTs of the token exchange, or of its Token.
+	AnnotationTokenController = TokenPrefix + "controller"
+)
+
+// Well Known Annotations for Objects of type corev1.Pod
+const (
+	// AnnotationPodToken indicates the Token whose Secret is projected into the pod, as the SLURM_JWT env var and
+	// file, if the pod has LabelPodTokenInject. With AnnotationTokenUsername and AnnotationTokenController, the Token
+	// is created if it does not exist, owned by the pods which request it, and its name is generated if omitted. The
+	// username must be allowed by the TokenUsers of the Controller.
+	AnnotationPodToken = TokenPrefix + "token"
+)
+
 // Well Known Labels
 const (
 	// LabelNodeSetPodName indicates the pod name.
@@ -67,6 +99,10 @@ const (
 	// LabelNodeSetScalingMode indicates the scaling mode (DaemonSet or StatefulSet).
 	// NOTE: Set by the NodeSet controller.
 	LabelNodeSetScalingMode = NodeSetPrefix + "scaling-mode"
+
+	// LabelPodTokenInject opts the pod into the pod token webhook, with the value "true". Only labeled pods are sent
+	// to the webhook, as annotations cannot be selected.
+	LabelPodTokenInject = TokenPrefix + "inject"
 )
 
 // Well Known Finalizers
@@ -75,4 +111,25 @@ const (
 	// FinalizerNodeSetReservation
 	// NOTE: Set by the NodeSet controller.
 	FinalizerNodeSetReservation = NodeSetPrefix + "reservation"
+
+	// FinalizerSlurmdbEntity indicates that the Slurm accounting entity must be deleted from slurmdbd, according to
+	// the deletion policy, before the object is removed.
+	// NOTE: Set by the SlurmAccount, SlurmUser, and SlurmQOS controllers.
+	FinalizerSlurmdbEntity = SlurmdbPrefix + "entity"
+)
+
+// Well Known Files
+
+const (
+	// AuthSlurmJwksFile is the file of the Slurm `auth/slurm` keys, when they are rotated.
+	// Ref: https://slurm.schedmd.com/authentication.html#slurm
+	AuthSlurmJwksFile = "slurm.jwks"
+
+	// AuthSlurmKeyFile is the file of the generated Slurm `auth/slurm` key.
+	// Ref: https://slurm.schedmd.com/authentication.html#slurm
+	AuthSlurmKeyFile = "slurm.key"
+
+	// AuthJwtKeyFile is the file of the generated Slurm `auth/jwt` key.
+	// Ref: https://slurm.schedmd.com/authentication.html#jwt
+	AuthJwtKeyFile = "jwt.key"
 )
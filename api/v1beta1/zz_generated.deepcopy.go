@@ -92,6 +92,11 @@ func (in *AccountingSpec) DeepCopyInto(out *AccountingSpec) {
 		*out = new(v1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SlurmKeyRotation != nil {
+		in, out := &in.SlurmKeyRotation, &out.SlurmKeyRotation
+		*out = new(AuthKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ExternalConfig = in.ExternalConfig
 	in.Slurmdbd.DeepCopyInto(&out.Slurmdbd)
 	in.Template.DeepCopyInto(&out.Template)
@@ -119,6 +124,21 @@ func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SlurmKeyRotation != nil {
+		in, out := &in.SlurmKeyRotation, &out.SlurmKeyRotation
+		*out = new(AuthKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlurmKeyRef != nil {
+		in, out := &in.SlurmKeyRef, &out.SlurmKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeyRef != nil {
+		in, out := &in.JwtKeyRef, &out.JwtKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingStatus.
@@ -131,6 +151,66 @@ func (in *AccountingStatus) DeepCopy() *AccountingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthKey) DeepCopyInto(out *AuthKey) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthKey.
+func (in *AuthKey) DeepCopy() *AuthKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthKeyRotation) DeepCopyInto(out *AuthKeyRotation) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]AuthKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthKeyRotation.
+func (in *AuthKeyRotation) DeepCopy() *AuthKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthKeyRotationStatus) DeepCopyInto(out *AuthKeyRotationStatus) {
+	*out = *in
+	if in.KeyIDs != nil {
+		in, out := &in.KeyIDs, &out.KeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthKeyRotationStatus.
+func (in *AuthKeyRotationStatus) DeepCopy() *AuthKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerWrapper) DeepCopyInto(out *ContainerWrapper) {
 	clone := in.DeepCopy()
@@ -164,6 +244,56 @@ func (in *Controller) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerGres) DeepCopyInto(out *ControllerGres) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ControllerGresResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerGres.
+func (in *ControllerGres) DeepCopy() *ControllerGres {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerGres)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerGresResource) DeepCopyInto(out *ControllerGresResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerGresResource.
+func (in *ControllerGresResource) DeepCopy() *ControllerGresResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerGresResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerHighAvailability) DeepCopyInto(out *ControllerHighAvailability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerHighAvailability.
+func (in *ControllerHighAvailability) DeepCopy() *ControllerHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerList) DeepCopyInto(out *ControllerList) {
 	*out = *in
@@ -236,6 +366,21 @@ func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 		*out = new(v1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JwtSigningKeyRef != nil {
+		in, out := &in.JwtSigningKeyRef, &out.JwtSigningKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlurmKeyRotation != nil {
+		in, out := &in.SlurmKeyRotation, &out.SlurmKeyRotation
+		*out = new(AuthKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenUsers != nil {
+		in, out := &in.TokenUsers, &out.TokenUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AccountingRef != nil {
 		in, out := &in.AccountingRef, &out.AccountingRef
 		*out = new(v1.LocalObjectReference)
@@ -246,6 +391,7 @@ func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 	in.Reconfigure.DeepCopyInto(&out.Reconfigure)
 	in.LogFile.DeepCopyInto(&out.LogFile)
 	in.Template.DeepCopyInto(&out.Template)
+	in.SlurmConf.DeepCopyInto(&out.SlurmConf)
 	if in.ConfigFileRefs != nil {
 		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -272,8 +418,17 @@ func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 		copy(*out, *in)
 	}
 	in.Persistence.DeepCopyInto(&out.Persistence)
+	out.HighAvailability = in.HighAvailability
 	in.Service.DeepCopyInto(&out.Service)
 	in.Metrics.DeepCopyInto(&out.Metrics)
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make([]ControllerTopology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Gres.DeepCopyInto(&out.Gres)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
@@ -296,6 +451,21 @@ func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SlurmKeyRotation != nil {
+		in, out := &in.SlurmKeyRotation, &out.SlurmKeyRotation
+		*out = new(AuthKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlurmKeyRef != nil {
+		in, out := &in.SlurmKeyRef, &out.SlurmKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeyRef != nil {
+		in, out := &in.JwtKeyRef, &out.JwtKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStatus.
@@ -308,6 +478,71 @@ func (in *ControllerStatus) DeepCopy() *ControllerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopology) DeepCopyInto(out *ControllerTopology) {
+	*out = *in
+	if in.Tree != nil {
+		in, out := &in.Tree, &out.Tree
+		*out = new(ControllerTopologyTree)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = new(ControllerTopologyBlock)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopology.
+func (in *ControllerTopology) DeepCopy() *ControllerTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopologyBlock) DeepCopyInto(out *ControllerTopologyBlock) {
+	*out = *in
+	if in.BlockSizes != nil {
+		in, out := &in.BlockSizes, &out.BlockSizes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopologyBlock.
+func (in *ControllerTopologyBlock) DeepCopy() *ControllerTopologyBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopologyBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopologyTree) DeepCopyInto(out *ControllerTopologyTree) {
+	*out = *in
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopologyTree.
+func (in *ControllerTopologyTree) DeepCopy() *ControllerTopologyTree {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopologyTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
 	*out = *in
@@ -518,6 +753,58 @@ func (in *NodeSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetAutoscaling) DeepCopyInto(out *NodeSetAutoscaling) {
+	*out = *in
+	out.ScaleUpCooldown = in.ScaleUpCooldown
+	out.ScaleDownCooldown = in.ScaleDownCooldown
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscaling.
+func (in *NodeSetAutoscaling) DeepCopy() *NodeSetAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetCanary) DeepCopyInto(out *NodeSetCanary) {
+	*out = *in
+	out.SoakTime = in.SoakTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetCanary.
+func (in *NodeSetCanary) DeepCopy() *NodeSetCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetCanaryStatus) DeepCopyInto(out *NodeSetCanaryStatus) {
+	*out = *in
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetCanaryStatus.
+func (in *NodeSetCanaryStatus) DeepCopy() *NodeSetCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
 	*out = *in
@@ -550,6 +837,26 @@ func (in *NodeSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeFeatures) DeepCopyInto(out *NodeSetNodeFeatures) {
+	*out = *in
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeFeatures.
+func (in *NodeSetNodeFeatures) DeepCopy() *NodeSetNodeFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
 	*out = *in
@@ -581,71 +888,187 @@ func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersis
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+func (in *NodeSetPowerSave) DeepCopyInto(out *NodeSetPowerSave) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	out.SuspendTime = in.SuspendTime
+	out.ResumeTimeout = in.ResumeTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerSave.
+func (in *NodeSetPowerSave) DeepCopy() *NodeSetPowerSave {
+	if in == nil {
+		return nil
 	}
-	in.Slurmd.DeepCopyInto(&out.Slurmd)
-	in.Ssh.DeepCopyInto(&out.Ssh)
-	in.LogFile.DeepCopyInto(&out.LogFile)
-	in.Template.DeepCopyInto(&out.Template)
-	out.Partition = in.Partition
-	if in.VolumeClaimTemplates != nil {
-		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(NodeSetPowerSave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRemediation) DeepCopyInto(out *NodeSetRemediation) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.PodConditionType, len(*in))
+		copy(*out, *in)
 	}
-	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
-	out.PersistentVolumeClaimRetentionPolicy = in.PersistentVolumeClaimRetentionPolicy
-	if in.WorkloadDisruptionProtection != nil {
-		in, out := &in.WorkloadDisruptionProtection, &out.WorkloadDisruptionProtection
-		*out = new(bool)
+	out.UnhealthyDuration = in.UnhealthyDuration
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	out.Window = in.Window
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
-func (in *NodeSetSpec) DeepCopy() *NodeSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediation.
+func (in *NodeSetRemediation) DeepCopy() *NodeSetRemediation {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSpec)
+	out := new(NodeSetRemediation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSsh) DeepCopyInto(out *NodeSetSsh) {
+func (in *NodeSetRemediationStatus) DeepCopyInto(out *NodeSetRemediationStatus) {
 	*out = *in
-	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
+	in.StartTime.DeepCopyInto(&out.StartTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSsh.
-func (in *NodeSetSsh) DeepCopy() *NodeSetSsh {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediationStatus.
+func (in *NodeSetRemediationStatus) DeepCopy() *NodeSetRemediationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSsh)
+	out := new(NodeSetRemediationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
+func (in *NodeSetRevisionStatus) DeepCopyInto(out *NodeSetRevisionStatus) {
 	*out = *in
-	if in.CollisionCount != nil {
-		in, out := &in.CollisionCount, &out.CollisionCount
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRevisionStatus.
+func (in *NodeSetRevisionStatus) DeepCopy() *NodeSetRevisionStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
+	out := new(NodeSetRevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRollback) DeepCopyInto(out *NodeSetRollback) {
+	*out = *in
+	out.ProgressDeadline = in.ProgressDeadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRollback.
+func (in *NodeSetRollback) DeepCopy() *NodeSetRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Slurmd.DeepCopyInto(&out.Slurmd)
+	in.Ssh.DeepCopyInto(&out.Ssh)
+	in.LogFile.DeepCopyInto(&out.LogFile)
+	in.Template.DeepCopyInto(&out.Template)
+	out.Partition = in.Partition
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	out.PersistentVolumeClaimRetentionPolicy = in.PersistentVolumeClaimRetentionPolicy
+	if in.WorkloadDisruptionProtection != nil {
+		in, out := &in.WorkloadDisruptionProtection, &out.WorkloadDisruptionProtection
+		*out = new(bool)
+		**out = **in
+	}
+	out.Autoscaling = in.Autoscaling
+	out.PowerSave = in.PowerSave
+	in.Remediation.DeepCopyInto(&out.Remediation)
+	in.NodeFeatures.DeepCopyInto(&out.NodeFeatures)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
+func (in *NodeSetSpec) DeepCopy() *NodeSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSsh) DeepCopyInto(out *NodeSetSsh) {
+	*out = *in
+	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSsh.
+func (in *NodeSetSsh) DeepCopy() *NodeSetSsh {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetSsh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]NodeSetRevisionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(NodeSetCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]NodeSetRemediationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CollisionCount != nil {
+		in, out := &in.CollisionCount, &out.CollisionCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
@@ -675,6 +1098,7 @@ func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
 	*out = *in
 	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
 	in.ScheduledUpdate.DeepCopyInto(&out.ScheduledUpdate)
+	out.Rollback = in.Rollback
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
@@ -687,6 +1111,121 @@ func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Partition) DeepCopyInto(out *Partition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Partition.
+func (in *Partition) DeepCopy() *Partition {
+	if in == nil {
+		return nil
+	}
+	out := new(Partition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Partition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionList) DeepCopyInto(out *PartitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Partition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionList.
+func (in *PartitionList) DeepCopy() *PartitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PartitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionSpec) DeepCopyInto(out *PartitionSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.NodeSetSelector != nil {
+		in, out := &in.NodeSetSelector, &out.NodeSetSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTime != nil {
+		in, out := &in.MaxTime, &out.MaxTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DefaultTime != nil {
+		in, out := &in.DefaultTime, &out.DefaultTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PriorityTier != nil {
+		in, out := &in.PriorityTier, &out.PriorityTier
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowAccounts != nil {
+		in, out := &in.AllowAccounts, &out.AllowAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionSpec.
+func (in *PartitionSpec) DeepCopy() *PartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionStatus) DeepCopyInto(out *PartitionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionStatus.
+func (in *PartitionStatus) DeepCopy() *PartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
 	clone := in.DeepCopy()
@@ -823,6 +1362,12 @@ func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetSt
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	out.Canary = in.Canary
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
@@ -902,23 +1447,7 @@ func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
-	*out = *in
-	in.PasswordKeyRef.DeepCopyInto(&out.PasswordKeyRef)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
-func (in *StorageConfig) DeepCopy() *StorageConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(StorageConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Token) DeepCopyInto(out *Token) {
+func (in *SlurmAccount) DeepCopyInto(out *SlurmAccount) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -926,18 +1455,18 @@ func (in *Token) DeepCopyInto(out *Token) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Token.
-func (in *Token) DeepCopy() *Token {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccount.
+func (in *SlurmAccount) DeepCopy() *SlurmAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(Token)
+	out := new(SlurmAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Token) DeepCopyObject() runtime.Object {
+func (in *SlurmAccount) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -945,31 +1474,31 @@ func (in *Token) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TokenList) DeepCopyInto(out *TokenList) {
+func (in *SlurmAccountList) DeepCopyInto(out *SlurmAccountList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Token, len(*in))
+		*out = make([]SlurmAccount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenList.
-func (in *TokenList) DeepCopy() *TokenList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountList.
+func (in *SlurmAccountList) DeepCopy() *SlurmAccountList {
 	if in == nil {
 		return nil
 	}
-	out := new(TokenList)
+	out := new(SlurmAccountList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TokenList) DeepCopyObject() runtime.Object {
+func (in *SlurmAccountList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -977,17 +1506,564 @@ func (in *TokenList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
+func (in *SlurmAccountSpec) DeepCopyInto(out *SlurmAccountSpec) {
 	*out = *in
-	if in.JwtHs256KeyRef != nil {
-		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	out.ControllerRef = in.ControllerRef
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
 	}
-	if in.JwtKeyRef != nil {
-		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountSpec.
+func (in *SlurmAccountSpec) DeepCopy() *SlurmAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConf) DeepCopyInto(out *SlurmConf) {
+	*out = *in
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.Priority.DeepCopyInto(&out.Priority)
+	in.Select.DeepCopyInto(&out.Select)
+	in.Timeouts.DeepCopyInto(&out.Timeouts)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConf.
+func (in *SlurmConf) DeepCopy() *SlurmConf {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConf)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConfPriority) DeepCopyInto(out *SlurmConfPriority) {
+	*out = *in
+	if in.DecayHalfLife != nil {
+		in, out := &in.DecayHalfLife, &out.DecayHalfLife
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WeightAge != nil {
+		in, out := &in.WeightAge, &out.WeightAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightAssoc != nil {
+		in, out := &in.WeightAssoc, &out.WeightAssoc
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightFairshare != nil {
+		in, out := &in.WeightFairshare, &out.WeightFairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightJobSize != nil {
+		in, out := &in.WeightJobSize, &out.WeightJobSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightPartition != nil {
+		in, out := &in.WeightPartition, &out.WeightPartition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightQOS != nil {
+		in, out := &in.WeightQOS, &out.WeightQOS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightTRES != nil {
+		in, out := &in.WeightTRES, &out.WeightTRES
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfPriority.
+func (in *SlurmConfPriority) DeepCopy() *SlurmConfPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConfScheduling) DeepCopyInto(out *SlurmConfScheduling) {
+	*out = *in
+	if in.SchedulerParameters != nil {
+		in, out := &in.SchedulerParameters, &out.SchedulerParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreemptMode != nil {
+		in, out := &in.PreemptMode, &out.PreemptMode
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxJobCount != nil {
+		in, out := &in.MaxJobCount, &out.MaxJobCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxArraySize != nil {
+		in, out := &in.MaxArraySize, &out.MaxArraySize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReturnToService != nil {
+		in, out := &in.ReturnToService, &out.ReturnToService
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfScheduling.
+func (in *SlurmConfScheduling) DeepCopy() *SlurmConfScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConfSelect) DeepCopyInto(out *SlurmConfSelect) {
+	*out = *in
+	if in.SelectTypeParameters != nil {
+		in, out := &in.SelectTypeParameters, &out.SelectTypeParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefMemPerCPU != nil {
+		in, out := &in.DefMemPerCPU, &out.DefMemPerCPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMemPerCPU != nil {
+		in, out := &in.MaxMemPerCPU, &out.MaxMemPerCPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefMemPerGPU != nil {
+		in, out := &in.DefMemPerGPU, &out.DefMemPerGPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefCpuPerGPU != nil {
+		in, out := &in.DefCpuPerGPU, &out.DefCpuPerGPU
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfSelect.
+func (in *SlurmConfSelect) DeepCopy() *SlurmConfSelect {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfSelect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConfTimeouts) DeepCopyInto(out *SlurmConfTimeouts) {
+	*out = *in
+	if in.SlurmctldTimeout != nil {
+		in, out := &in.SlurmctldTimeout, &out.SlurmctldTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SlurmdTimeout != nil {
+		in, out := &in.SlurmdTimeout, &out.SlurmdTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MessageTimeout != nil {
+		in, out := &in.MessageTimeout, &out.MessageTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InactiveLimit != nil {
+		in, out := &in.InactiveLimit, &out.InactiveLimit
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KillWait != nil {
+		in, out := &in.KillWait, &out.KillWait
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinJobAge != nil {
+		in, out := &in.MinJobAge, &out.MinJobAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WaitTime != nil {
+		in, out := &in.WaitTime, &out.WaitTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnkillableStepTimeout != nil {
+		in, out := &in.UnkillableStepTimeout, &out.UnkillableStepTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfTimeouts.
+func (in *SlurmConfTimeouts) DeepCopy() *SlurmConfTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOS) DeepCopyInto(out *SlurmQOS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOS.
+func (in *SlurmQOS) DeepCopy() *SlurmQOS {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSList) DeepCopyInto(out *SlurmQOSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmQOS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSList.
+func (in *SlurmQOSList) DeepCopy() *SlurmQOSList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSSpec) DeepCopyInto(out *SlurmQOSSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxTRESPerJob != nil {
+		in, out := &in.MaxTRESPerJob, &out.MaxTRESPerJob
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxTRESPerUser != nil {
+		in, out := &in.MaxTRESPerUser, &out.MaxTRESPerUser
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSSpec.
+func (in *SlurmQOSSpec) DeepCopy() *SlurmQOSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUser) DeepCopyInto(out *SlurmUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUser.
+func (in *SlurmUser) DeepCopy() *SlurmUser {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserList) DeepCopyInto(out *SlurmUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserList.
+func (in *SlurmUserList) DeepCopy() *SlurmUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserSpec) DeepCopyInto(out *SlurmUserSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserSpec.
+func (in *SlurmUserSpec) DeepCopy() *SlurmUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmdbStatus) DeepCopyInto(out *SlurmdbStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmdbStatus.
+func (in *SlurmdbStatus) DeepCopy() *SlurmdbStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmdbStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+	*out = *in
+	in.PasswordKeyRef.DeepCopyInto(&out.PasswordKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
+func (in *StorageConfig) DeepCopy() *StorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Token) DeepCopyInto(out *Token) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Token.
+func (in *Token) DeepCopy() *Token {
+	if in == nil {
+		return nil
+	}
+	out := new(Token)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Token) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenList) DeepCopyInto(out *TokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Token, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenList.
+func (in *TokenList) DeepCopy() *TokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
+	*out = *in
+	if in.JwtHs256KeyRef != nil {
+		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeyRef != nil {
+		in, out := &in.JwtKeyRef, &out.JwtKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerRef != nil {
+		in, out := &in.ControllerRef, &out.ControllerRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
 	}
 	if in.Lifetime != nil {
 		in, out := &in.Lifetime, &out.Lifetime
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"flag"
@@ -39,7 +40,9 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/restapi"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token"
+	"github.com/SlinkyProject/slurm-operator/internal/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -48,7 +51,10 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
-const defaultProfileAddr = "localhost:6060"
+const (
+	defaultProfileAddr     = "localhost:6060"
+	tracingShutdownTimeout = 5 * time.Second
+)
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
@@ -60,16 +66,20 @@ func init() {
 
 // Input flags to the command
 type Flags struct {
-	enableLeaderElection     bool
-	leaderElectionNamespace  string
-	probeAddr                string
-	metricsAddr              string
-	secureMetrics            bool
-	enableHTTP2              bool
-	namespaces               string
-	propagatedNodeConditions string
-	profile                  bool
-	profileAddr              string
+	enableLeaderElection      bool
+	leaderElectionNamespace   string
+	probeAddr                 string
+	metricsAddr               string
+	secureMetrics             bool
+	enableHTTP2               bool
+	namespaces                string
+	propagatedNodeConditions  string
+	terminationNodeTaints     string
+	terminationNodeConditions string
+	profile                   bool
+	profileAddr               string
+	tracingEndpoint           string
+	tracingSampleRatio        float64
 }
 
 func parseFlags(flags *Flags) {
@@ -106,6 +116,10 @@ func parseFlags(flags *Flags) {
 		"Comma-separated list of namespaces the controller will watch. If empty, all namespaces are watched.")
 	flag.StringVar(&flags.propagatedNodeConditions, "propagated-node-conditions", "",
 		"Comma-separated list of Kube node conditions, by type field, the controller will parse when setting drain reason on Slurm nodes.")
+	flag.StringVar(&flags.terminationNodeTaints, "termination-node-taints", "",
+		"Comma-separated list of Kube node taints, by key, which signal that the node is about to be terminated.")
+	flag.StringVar(&flags.terminationNodeConditions, "termination-node-conditions", "",
+		"Comma-separated list of Kube node conditions, by type field, which signal that the node is about to be terminated when true.")
 	flag.BoolVar(&flags.profile, "profile", false,
 		"If set the Go profiling endpoints will be exposed via HTTP")
 	flag.StringVar(
@@ -114,6 +128,10 @@ func parseFlags(flags *Flags) {
 		defaultProfileAddr,
 		"The address the Go profiling endpoint binds to. This should never be exposed publicly. If empty and profiling is enabled, defaults to localhost:6060.",
 	)
+	flag.StringVar(&flags.tracingEndpoint, "tracing-endpoint", "",
+		"The URL of the OTLP/HTTP collector (e.g. http://otel-collector:4318) to export traces to. If empty, tracing is disabled.")
+	flag.Float64Var(&flags.tracingSampleRatio, "tracing-sample-ratio", 1,
+		"The ratio of reconciles which are traced, from 0 to 1.")
 	flag.Parse()
 }
 
@@ -177,6 +195,20 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:    flags.tracingEndpoint,
+		SampleRatio: flags.tracingSampleRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	if flags.tracingEndpoint != "" {
+		setupLog.Info("exporting traces", "endpoint", flags.tracingEndpoint)
+	}
+
 	if flags.profile {
 		if _, err := startProfileServer(flags.profileAddr); err != nil {
 			setupLog.Error(err, "unable to start pprof server")
@@ -213,6 +245,24 @@ func main() {
 		setupLog.Info("propagated node conditions", "propagatedNodeConditions", flags.propagatedNodeConditions)
 	}
 
+	var terminationSignals nodeset.TerminationSignals
+	for taintKey := range strings.SplitSeq(flags.terminationNodeTaints, ",") {
+		taintKey = strings.TrimSpace(taintKey)
+		if taintKey != "" {
+			terminationSignals.Taints = append(terminationSignals.Taints, taintKey)
+		}
+	}
+	for nodeCondType := range strings.SplitSeq(flags.terminationNodeConditions, ",") {
+		nodeCondType = strings.TrimSpace(nodeCondType)
+		if nodeCondType != "" {
+			terminationSignals.Conditions = append(terminationSignals.Conditions, corev1.NodeConditionType(nodeCondType))
+		}
+	}
+	if len(terminationSignals.Taints) > 0 || len(terminationSignals.Conditions) > 0 {
+		setupLog.Info("node termination signals",
+			"terminationNodeTaints", flags.terminationNodeTaints, "terminationNodeConditions", flags.terminationNodeConditions)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
@@ -233,35 +283,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	k8sClient := mgr.GetClient()
+	if flags.tracingEndpoint != "" {
+		k8sClient = tracing.NewClient(k8sClient)
+	}
+
 	clientMap := clientmap.NewClientMap()
-	if err := controller.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+	if err := controller.NewReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Controller")
 		os.Exit(1)
 	}
-	if err := restapi.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := restapi.NewReconciler(k8sClient).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Restapi")
 		os.Exit(1)
 	}
-	if err := accounting.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := accounting.NewReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Accounting")
 		os.Exit(1)
 	}
-	if err := nodeset.NewReconciler(mgr.GetClient(), clientMap, propagatedNodeConditions).SetupWithManager(mgr); err != nil {
+	if err := nodeset.NewReconciler(k8sClient, clientMap, propagatedNodeConditions, terminationSignals).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeSet")
 		os.Exit(1)
 	}
-	if err := loginset.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := loginset.NewReconciler(k8sClient).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LoginSet")
 		os.Exit(1)
 	}
-	if err := slurmclient.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+	if err := slurmclient.NewReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SlurmClient")
 		os.Exit(1)
 	}
-	if err := token.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := token.NewReconciler(k8sClient).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Token")
 		os.Exit(1)
 	}
+	if err := slurmdb.NewSlurmAccountReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmAccount")
+		os.Exit(1)
+	}
+	if err := slurmdb.NewSlurmUserReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmUser")
+		os.Exit(1)
+	}
+	if err := slurmdb.NewSlurmQOSReconciler(k8sClient, clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmQOS")
+		os.Exit(1)
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -273,8 +340,14 @@ func main() {
 	}
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running controller")
 		os.Exit(1)
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
 }
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -27,6 +28,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient"
 	slinkywebhook "github.com/SlinkyProject/slurm-operator/internal/webhook"
 	// +kubebuilder:scaffold:imports
 )
@@ -53,6 +57,10 @@ type Flags struct {
 	secureMetrics           bool
 	enableHTTP2             bool
 	namespaces              string
+
+	enableTokenExchange      bool
+	tokenExchangeMaxLifetime time.Duration
+	tokenExchangeAudience    string
 }
 
 func parseFlags(flags *Flags) {
@@ -94,6 +102,20 @@ func parseFlags(flags *Flags) {
 		":9443",
 		"The address the webhook server binds to.",
 	)
+	flag.BoolVar(&flags.enableTokenExchange, "enable-token-exchange", false,
+		"If set, the webhook server exchanges ServiceAccount tokens for Slurm JWTs at "+slinkywebhook.TokenExchangePath)
+	flag.DurationVar(
+		&flags.tokenExchangeMaxLifetime,
+		"token-exchange-max-lifetime",
+		slinkywebhook.DefaultTokenExchangeMaxLifetime,
+		"The longest lifetime of a Slurm JWT from the token exchange.",
+	)
+	flag.StringVar(
+		&flags.tokenExchangeAudience,
+		"token-exchange-audience",
+		slinkywebhook.DefaultTokenExchangeAudience,
+		"The audience which ServiceAccount tokens must be valid for, to be exchanged.",
+	)
 	flag.Parse()
 }
 
@@ -179,6 +201,13 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+
+	// The Slurm clients allow the eviction webhook to drain Slurm nodes.
+	clientMap := clientmap.NewClientMap()
+	if err := slurmclient.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmClient")
+		os.Exit(1)
+	}
 	if err := (&slinkywebhook.ControllerWebhook{
 		Client: mgr.GetClient(),
 	}).SetupWebhookWithManager(mgr); err != nil {
@@ -205,12 +234,54 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Token")
 		os.Exit(1)
 	}
+	if err = (&slinkywebhook.SlurmAccountWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmAccount")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmUserWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmUser")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmQOSWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmQOS")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.PartitionWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Partition")
+		os.Exit(1)
+	}
 	if err = (&slinkywebhook.PodBindingWebhook{
 		Client: mgr.GetClient(),
 	}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "pods/binding")
 		os.Exit(1)
 	}
+	if err = (&slinkywebhook.PodEvictionWebhook{
+		Client:       mgr.GetClient(),
+		SlurmControl: slurmcontrol.NewSlurmControl(clientMap),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "pods/eviction")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.PodTokenWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "pods")
+		os.Exit(1)
+	}
+	if flags.enableTokenExchange {
+		if err = (&slinkywebhook.TokenExchangeHandler{
+			Client:      mgr.GetClient(),
+			Reader:      mgr.GetAPIReader(),
+			Audiences:   []string{flags.tokenExchangeAudience},
+			MaxLifetime: flags.tokenExchangeMaxLifetime,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create handler", "handler", "token-exchange")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -7,6 +7,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -57,3 +58,45 @@ func Test_parseFlags_namespaces(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseFlags_tokenExchange(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		wantEnabled     bool
+		wantMaxLifetime time.Duration
+		wantAudience    string
+	}{
+		{
+			name:            "default is disabled",
+			args:            []string{"test"},
+			wantEnabled:     false,
+			wantMaxLifetime: time.Hour,
+			wantAudience:    "slurm-token-exchange",
+		},
+		{
+			name:            "enabled",
+			args:            []string{"test", "--enable-token-exchange", "--token-exchange-max-lifetime", "30m", "--token-exchange-audience", "ci"},
+			wantEnabled:     true,
+			wantMaxLifetime: 30 * time.Minute,
+			wantAudience:    "ci",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag.CommandLine = flag.NewFlagSet(tt.args[0], flag.ContinueOnError)
+			os.Args = tt.args
+			flags := Flags{}
+			parseFlags(&flags)
+			if flags.enableTokenExchange != tt.wantEnabled {
+				t.Errorf("parseFlags() enableTokenExchange = %v, want %v", flags.enableTokenExchange, tt.wantEnabled)
+			}
+			if flags.tokenExchangeMaxLifetime != tt.wantMaxLifetime {
+				t.Errorf("parseFlags() tokenExchangeMaxLifetime = %v, want %v", flags.tokenExchangeMaxLifetime, tt.wantMaxLifetime)
+			}
+			if flags.tokenExchangeAudience != tt.wantAudience {
+				t.Errorf("parseFlags() tokenExchangeAudience = %v, want %v", flags.tokenExchangeAudience, tt.wantAudience)
+			}
+		})
+	}
+}
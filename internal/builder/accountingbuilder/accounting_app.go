@@ -130,14 +130,7 @@ func accountingVolumes(accounting *slinkyv1beta1.Accounting) []corev1.Volume {
 							},
 						},
 						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: accounting.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: accounting.AuthSlurmRef().Key, Path: common.SlurmKeyFile},
-								},
-							},
+							Secret: new(common.SlurmKeyProjection(accounting.AuthSlurmRef())),
 						},
 						{
 							Secret: &corev1.SecretProjection{
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accountingbuilder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+func (b *AccountingBuilder) BuildAccountingSlurmJwks(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
+	opts := common.SlurmJwksOpts{
+		Key: accounting.AuthSlurmJwksKey(),
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: accounting.Annotations,
+			Labels:      structutils.MergeMaps(accounting.Labels, labels.NewBuilder().WithAccountingLabels(accounting).Build()),
+		},
+		Rotation: accounting.Spec.SlurmKeyRotation,
+		Status:   accounting.Status.SlurmKeyRotation,
+	}
+
+	return b.CommonBuilder.BuildSlurmJwksSecret(opts, accounting)
+}
+
+func (b *AccountingBuilder) BuildAccountingSlurmKey(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
+	ref := accounting.AuthSlurmGeneratedRef()
+	return b.buildAccountingAuthKey(accounting, ref)
+}
+
+func (b *AccountingBuilder) BuildAccountingJwtKey(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
+	ref := accounting.AuthJwtGeneratedRef()
+	return b.buildAccountingAuthKey(accounting, ref)
+}
+
+func (b *AccountingBuilder) buildAccountingAuthKey(accounting *slinkyv1beta1.Accounting, ref corev1.SecretKeySelector) (*corev1.Secret, error) {
+	opts := common.AuthKeyOpts{
+		Key: types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: accounting.Namespace,
+		},
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: accounting.Annotations,
+			Labels:      structutils.MergeMaps(accounting.Labels, labels.NewBuilder().WithAccountingLabels(accounting).Build()),
+		},
+		SecretKey: ref.Key,
+	}
+
+	return b.CommonBuilder.BuildAuthKeySecret(opts, accounting)
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+)
+
+type AuthKeyOpts struct {
+	Key      types.NamespacedName
+	Metadata slinkyv1beta1.Metadata
+	// SecretKey is the key of the Secret which contains the key.
+	SecretKey string
+}
+
+// BuildAuthKeySecret returns an immutable Secret with a newly generated key.
+// Because the key differs each time, the Secret should only be created.
+func (b *CommonBuilder) BuildAuthKeySecret(opts AuthKeyOpts, owner metav1.Object) (*corev1.Secret, error) {
+	secretOpts := SecretOpts{
+		Key:      opts.Key,
+		Metadata: opts.Metadata,
+		Data: map[string][]byte{
+			opts.SecretKey: crypto.NewSigningKey(),
+		},
+		Immutable: true,
+	}
+
+	return b.BuildSecret(secretOpts, owner)
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestBuilder_BuildAuthKeySecret(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	opts := AuthKeyOpts{
+		Key:       types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm-slurm-key"},
+		SecretKey: slinkyv1beta1.AuthSlurmKeyFile,
+	}
+	tests := []struct {
+		name    string
+		owner   metav1.Object
+		wantErr bool
+	}{
+		{
+			name:  "generated",
+			owner: controller,
+		},
+		{
+			name:    "no owner",
+			owner:   nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildAuthKeySecret(opts, tt.owner)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Builder.BuildAuthKeySecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != opts.Key.Name {
+				t.Errorf("Builder.BuildAuthKeySecret() Name = %v, want %v", got.Name, opts.Key.Name)
+			}
+			if !ptr.Deref(got.Immutable, false) {
+				t.Errorf("Builder.BuildAuthKeySecret() Immutable = %v, want true", got.Immutable)
+			}
+			if len(got.OwnerReferences) != 1 || got.OwnerReferences[0].Name != controller.Name {
+				t.Errorf("Builder.BuildAuthKeySecret() OwnerReferences = %v", got.OwnerReferences)
+			}
+			key := got.Data[opts.SecretKey]
+			if len(key) != crypto.DefaultSigningKeyLength {
+				t.Errorf("Builder.BuildAuthKeySecret() key length = %v, want %v", len(key), crypto.DefaultSigningKeyLength)
+			}
+			other, err := b.BuildAuthKeySecret(opts, tt.owner)
+			if err != nil {
+				t.Fatalf("Builder.BuildAuthKeySecret() error = %v", err)
+			}
+			if bytes.Equal(key, other.Data[opts.SecretKey]) {
+				t.Errorf("Builder.BuildAuthKeySecret() generated the same key twice")
+			}
+		})
+	}
+}
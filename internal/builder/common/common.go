@@ -18,6 +18,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/keyrotation"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
@@ -29,9 +30,14 @@ func ConfiglessArgs(controller *slinkyv1beta1.Controller) []string {
 		host = externalConfig.Host
 		port = externalConfig.Port
 	}
+	server := fmt.Sprintf("%s:%d", host, port)
+	if controller.IsHighAvailability() {
+		// Fetch the config from whichever slurmctld is available.
+		server = fmt.Sprintf("%s:%d,%s:%d", controller.PrimaryFQDN(), port, controller.BackupFQDN(), port)
+	}
 	args := []string{
 		"--conf-server",
-		fmt.Sprintf("%s:%d", host, port),
+		server,
 	}
 	return args
 }
@@ -221,6 +227,26 @@ func (b *CommonBuilder) GetContainerResourceLimits(container corev1.Container) (
 	return cpu, memory
 }
 
+// GetNodeSetGres returns the GRES of the NodeSet pods (e.g. "gpu:h100:8"),
+// by the extended resource limits of the slurmd container.
+func GetNodeSetGres(nodeset *slinkyv1beta1.NodeSetSpec, resources []slinkyv1beta1.ControllerGresResource) []string {
+	gres := []string{}
+	for _, resource := range resources {
+		limit := nodeset.Slurmd.Container.Resources.Limits[resource.ResourceName]
+		count := limit.Value()
+		if count <= 0 {
+			continue
+		}
+		spec := []string{resource.Name}
+		if resource.Type != "" {
+			spec = append(spec, resource.Type)
+		}
+		spec = append(spec, fmt.Sprint(count))
+		gres = append(gres, strings.Join(spec, ":"))
+	}
+	return gres
+}
+
 func JwtSecretProjection(secret *corev1.SecretKeySelector, path string) corev1.SecretProjection {
 	return corev1.SecretProjection{
 		LocalObjectReference: corev1.LocalObjectReference{
@@ -243,6 +269,34 @@ func JwksConfigProjection(configMap *corev1.ConfigMapKeySelector, path string) c
 	}
 }
 
+// SlurmKeyProjection returns the projection of the Slurm key Secret, as
+// `slurm.jwks` when the keys are rotated, otherwise as `slurm.key`.
+func SlurmKeyProjection(ref corev1.SecretKeySelector) corev1.SecretProjection {
+	path := SlurmKeyFile
+	if ref.Key == slinkyv1beta1.AuthSlurmJwksFile {
+		path = slinkyv1beta1.AuthSlurmJwksFile
+	}
+	return corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: ref.Name,
+		},
+		Items: []corev1.KeyToPath{
+			{Key: ref.Key, Path: path},
+		},
+	}
+}
+
+// SlurmKeyRotationHashes returns the hash annotation of the rotated Slurm keys
+// of the controller, so pods are replaced as the keys are rotated.
+func SlurmKeyRotationHashes(controller *slinkyv1beta1.Controller) map[string]string {
+	if controller.Spec.SlurmKeyRotation == nil {
+		return nil
+	}
+	return map[string]string{
+		AnnotationAuthSlurmKeyHash: keyrotation.Hash(controller.Status.SlurmKeyRotation),
+	}
+}
+
 // BuildMergedConfig returns a slurm.conf snippet containing merged parameter options.
 func BuildMergedConfig(confRaw string, mergeConfig map[string][]string) string {
 	conf := config.NewBuilder().WithFinalNewline(false)
@@ -12,6 +12,9 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/keyrotation"
 )
 
 func Test_mergeEnvVar(t *testing.T) {
@@ -483,3 +486,138 @@ func Test_parseKVKey(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetNodeSetGres(t *testing.T) {
+	resources := []slinkyv1beta1.ControllerGresResource{
+		{ResourceName: "example.com/gpu", Name: "gpu", Type: "h100"},
+		{ResourceName: "example.com/nic", Name: "nic"},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSetSpec
+		want    []string
+	}{
+		{
+			name:    "empty",
+			nodeset: &slinkyv1beta1.NodeSetSpec{},
+			want:    []string{},
+		},
+		{
+			name: "pod limits are ignored",
+			nodeset: &slinkyv1beta1.NodeSetSpec{
+				Template: slinkyv1beta1.PodTemplate{
+					PodSpecWrapper: slinkyv1beta1.PodSpecWrapper{
+						PodSpec: corev1.PodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									"example.com/gpu": resource.MustParse("4"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "container limits",
+			nodeset: &slinkyv1beta1.NodeSetSpec{
+				Slurmd: slinkyv1beta1.ContainerWrapper{
+					Container: corev1.Container{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								"example.com/gpu": resource.MustParse("8"),
+								"example.com/nic": resource.MustParse("2"),
+								"example.com/foo": resource.MustParse("1"),
+							},
+						},
+					},
+				},
+			},
+			want: []string{"gpu:h100:8", "nic:2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetNodeSetGres(tt.nodeset, resources)
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetNodeSetGres() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SlurmKeyProjection(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  corev1.SecretKeySelector
+		want string
+	}{
+		{
+			name: "slurm.key",
+			ref: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "slurm-auth-slurm"},
+				Key:                  "custom.key",
+			},
+			want: SlurmKeyFile,
+		},
+		{
+			name: "slurm.jwks",
+			ref: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "slurm-slurm-jwks"},
+				Key:                  slinkyv1beta1.AuthSlurmJwksFile,
+			},
+			want: slinkyv1beta1.AuthSlurmJwksFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SlurmKeyProjection(tt.ref)
+			if got.Name != tt.ref.Name || len(got.Items) != 1 || got.Items[0].Key != tt.ref.Key {
+				t.Errorf("SlurmKeyProjection() = %v, want ref %v", got, tt.ref)
+			}
+			if got.Items[0].Path != tt.want {
+				t.Errorf("SlurmKeyProjection() path = %v, want %v", got.Items[0].Path, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SlurmKeyRotationHashes(t *testing.T) {
+	status := &slinkyv1beta1.AuthKeyRotationStatus{
+		ActiveKeyID: "key1",
+		KeyIDs:      []string{"key1", "key2"},
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       map[string]string
+	}{
+		{
+			name:       "no rotation",
+			controller: &slinkyv1beta1.Controller{},
+			want:       nil,
+		},
+		{
+			name: "rotation",
+			controller: &slinkyv1beta1.Controller{
+				Spec: slinkyv1beta1.ControllerSpec{
+					SlurmKeyRotation: &slinkyv1beta1.AuthKeyRotation{},
+				},
+				Status: slinkyv1beta1.ControllerStatus{
+					SlurmKeyRotation: status,
+				},
+			},
+			want: map[string]string{
+				AnnotationAuthSlurmKeyHash: keyrotation.Hash(status),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlurmKeyRotationHashes(tt.controller); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("SlurmKeyRotationHashes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
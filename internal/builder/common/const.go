@@ -89,3 +89,12 @@ const (
 	AnnotationSssdConfHash    = slinkyv1beta1.SlinkyPrefix + "sssd-conf-hash"
 	AnnotationSshHostKeysHash = slinkyv1beta1.SlinkyPrefix + "ssh-host-keys-hash"
 )
+
+// Token
+const (
+	PodTokenVolume = "slurm-token"
+	PodTokenDir    = "/var/run/secrets/slinky.slurm.net/token"
+	PodTokenFile   = "SLURM_JWT"
+	PodTokenPath   = PodTokenDir + "/" + PodTokenFile
+	PodTokenEnv    = "SLURM_JWT"
+)
@@ -16,6 +16,7 @@ SLURM_DIR=/mnt/etc/slurm
 mkdir -p "$SLURM_DIR"
 find "${SLURM_MOUNT}" -type f -name "*.conf" -print0 | xargs -0r cp -vt "${SLURM_DIR}"
 find "${SLURM_MOUNT}" -type f -name "*.key" -print0 | xargs -0r cp -vt "${SLURM_DIR}"
+find "${SLURM_MOUNT}" -type f -name "*.jwks" -print0 | xargs -0r cp -vt "${SLURM_DIR}"
 
 # Set general permissions and ownership
 find "${SLURM_DIR}" -type f -print0 | xargs -0r chown -v "${SLURM_USER_UID}:${SLURM_USER_GID}"
@@ -23,6 +24,8 @@ find "${SLURM_DIR}" -type f -name "*.conf" -print0 | xargs -0r chmod -v 644
 find "${SLURM_DIR}" -type f -name "slurmdbd.conf" -print0 | xargs -0r chmod -v 600
 find "${SLURM_DIR}" -type f -name "*.key" -print0 | xargs -0r chmod -v 600
 find "${SLURM_DIR}" -type f -name "*.key" -print0 | xargs -0r chown -v "${SLURM_USER_UID}:${SLURM_USER_GID}"
+find "${SLURM_DIR}" -type f -name "*.jwks" -print0 | xargs -0r chmod -v 600
+find "${SLURM_DIR}" -type f -name "*.jwks" -print0 | xargs -0r chown -v "${SLURM_USER_UID}:${SLURM_USER_GID}"
 
 # Display Slurm directory files
 ls -lAF "${SLURM_DIR}"
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/set"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/keyrotation"
+)
+
+type SlurmJwksOpts struct {
+	Key      types.NamespacedName
+	Metadata slinkyv1beta1.Metadata
+	Rotation *slinkyv1beta1.AuthKeyRotation
+	Status   *slinkyv1beta1.AuthKeyRotationStatus
+}
+
+// BuildSlurmJwksSecret returns the Secret with the `slurm.jwks` of the
+// accepted keys of the status, of which its active key signs.
+func (b *CommonBuilder) BuildSlurmJwksSecret(opts SlurmJwksOpts, owner metav1.Object) (*corev1.Secret, error) {
+	ctx := context.TODO()
+
+	if opts.Rotation == nil || opts.Status == nil {
+		return nil, fmt.Errorf("failed to specify a key rotation")
+	}
+
+	keyIDs := set.New(opts.Status.KeyIDs...)
+	keys := make(map[string][]byte, keyIDs.Len())
+	for _, key := range opts.Rotation.Keys {
+		if !keyIDs.Has(key.ID) {
+			continue
+		}
+		data, err := b.refResolver.GetSecretKeyRef(ctx, key.SecretRef, owner.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		keys[key.ID] = data
+	}
+
+	jwks, err := keyrotation.BuildSlurmJwks(keys, opts.Status.ActiveKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s: %w", slinkyv1beta1.AuthSlurmJwksFile, err)
+	}
+
+	secretOpts := SecretOpts{
+		Key:      opts.Key,
+		Metadata: opts.Metadata,
+		StringData: map[string]string{
+			slinkyv1beta1.AuthSlurmJwksFile: jwks,
+		},
+	}
+
+	return b.BuildSecret(secretOpts, owner)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestBuilder_BuildSlurmJwksSecret(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	newKeySecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      name,
+			},
+			Data: map[string][]byte{
+				"slurm.key": []byte(name),
+			},
+		}
+	}
+	newKey := func(id string) slinkyv1beta1.AuthKey {
+		return slinkyv1beta1.AuthKey{
+			ID: id,
+			SecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: id,
+				},
+				Key: "slurm.key",
+			},
+		}
+	}
+	rotation := &slinkyv1beta1.AuthKeyRotation{
+		Keys:        []slinkyv1beta1.AuthKey{newKey("key1"), newKey("key2")},
+		ActiveKeyID: "key2",
+	}
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		opts SlurmJwksOpts
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantKids   []string
+		wantActive string
+		wantErr    bool
+	}{
+		{
+			name: "status keys",
+			fields: fields{
+				client: fake.NewFakeClient(newKeySecret("key1"), newKeySecret("key2")),
+			},
+			args: args{
+				opts: SlurmJwksOpts{
+					Key:      types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm-slurm-jwks"},
+					Rotation: rotation,
+					Status: &slinkyv1beta1.AuthKeyRotationStatus{
+						ActiveKeyID: "key1",
+						KeyIDs:      []string{"key1", "key2"},
+					},
+				},
+			},
+			wantKids:   []string{"key1", "key2"},
+			wantActive: "key1",
+		},
+		{
+			name: "missing secret",
+			fields: fields{
+				client: fake.NewFakeClient(newKeySecret("key1")),
+			},
+			args: args{
+				opts: SlurmJwksOpts{
+					Key:      types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm-slurm-jwks"},
+					Rotation: rotation,
+					Status: &slinkyv1beta1.AuthKeyRotationStatus{
+						ActiveKeyID: "key1",
+						KeyIDs:      []string{"key1", "key2"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no status",
+			fields: fields{
+				client: fake.NewFakeClient(newKeySecret("key1"), newKeySecret("key2")),
+			},
+			args: args{
+				opts: SlurmJwksOpts{
+					Key:      types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm-slurm-jwks"},
+					Rotation: rotation,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.fields.client)
+			got, err := b.BuildSlurmJwksSecret(tt.args.opts, controller)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Builder.BuildSlurmJwksSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			jwks := struct {
+				Keys []struct {
+					Kid string `json:"kid"`
+					Use string `json:"use"`
+				} `json:"keys"`
+			}{}
+			if err := json.Unmarshal([]byte(got.StringData[slinkyv1beta1.AuthSlurmJwksFile]), &jwks); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			var gotKids []string
+			var gotActive string
+			for _, key := range jwks.Keys {
+				gotKids = append(gotKids, key.Kid)
+				if key.Use == "default" {
+					gotActive = key.Kid
+				}
+			}
+			if diff := cmp.Diff(tt.wantKids, gotKids); diff != "" {
+				t.Errorf("kids (-want,+got):\n%s", diff)
+			}
+			if gotActive != tt.wantActive {
+				t.Errorf("active kid = %v, want %v", gotActive, tt.wantActive)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// IsPodTokenInjected returns true if the pod opts into the pod token webhook.
+func IsPodTokenInjected(pod *corev1.Pod) bool {
+	return pod.Labels[slinkyv1beta1.LabelPodTokenInject] == "true"
+}
+
+// NewPodToken returns the Token which the pod requests to be created by its
+// annotations, owned by the pod, or nil if it requests none. Whether the
+// Controller allows the username is not checked.
+func NewPodToken(pod *corev1.Pod) *slinkyv1beta1.Token {
+	if !IsPodTokenInjected(pod) {
+		return nil
+	}
+	name := pod.Annotations[slinkyv1beta1.AnnotationPodToken]
+	username := pod.Annotations[slinkyv1beta1.AnnotationTokenUsername]
+	controllerName := pod.Annotations[slinkyv1beta1.AnnotationTokenController]
+	if name == "" || username == "" || controllerName == "" {
+		return nil
+	}
+
+	return &slinkyv1beta1.Token{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pod.Namespace,
+			// A Token may be requested by many pods, but only one may be its
+			// controller, so none are.
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Pod",
+					Name:       pod.Name,
+					UID:        pod.UID,
+				},
+			},
+		},
+		Spec: slinkyv1beta1.TokenSpec{
+			Username: username,
+			ControllerRef: &corev1.LocalObjectReference{
+				Name: controllerName,
+			},
+		},
+	}
+}
+
+// ProjectPodToken projects the Secret of the Token into every container of the
+// pod, as the SLURM_JWT env var and file. The file is refreshed with the
+// Secret, unlike the env var.
+func ProjectPodToken(pod *corev1.Pod, secretRef corev1.SecretKeySelector) {
+	if !slices.ContainsFunc(pod.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == PodTokenVolume }) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: PodTokenVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretRef.Name,
+					Items: []corev1.KeyToPath{
+						{Key: secretRef.Key, Path: PodTokenFile},
+					},
+				},
+			},
+		})
+	}
+
+	projectContainer := func(container *corev1.Container) {
+		if !slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return m.Name == PodTokenVolume }) {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      PodTokenVolume,
+				MountPath: PodTokenDir,
+				ReadOnly:  true,
+			})
+		}
+		if !slices.ContainsFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == PodTokenEnv }) {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name: PodTokenEnv,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &secretRef,
+				},
+			})
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		projectContainer(&pod.Spec.InitContainers[i])
+	}
+	for i := range pod.Spec.Containers {
+		projectContainer(&pod.Spec.Containers[i])
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestNewPodToken(t *testing.T) {
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "notebook",
+				Namespace: corev1.NamespaceDefault,
+				UID:       "uid-notebook",
+				Labels: map[string]string{
+					slinkyv1beta1.LabelPodTokenInject: "true",
+				},
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want *slinkyv1beta1.Token
+	}{
+		{
+			name: "Requested",
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationPodToken:        "token",
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			want: &slinkyv1beta1.Token{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "token",
+					Namespace: corev1.NamespaceDefault,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "v1",
+							Kind:       "Pod",
+							Name:       "notebook",
+							UID:        "uid-notebook",
+						},
+					},
+				},
+				Spec: slinkyv1beta1.TokenSpec{
+					Username: "alice",
+					ControllerRef: &corev1.LocalObjectReference{
+						Name: "slurm",
+					},
+				},
+			},
+		},
+		{
+			name: "Existing Token",
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationPodToken: "token",
+			}),
+		},
+		{
+			name: "No Token name",
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+		},
+		{
+			name: "Not annotated",
+			pod:  newPod(nil),
+		},
+		{
+			name: "Not labeled",
+			pod: func() *corev1.Pod {
+				pod := newPod(map[string]string{
+					slinkyv1beta1.AnnotationPodToken:        "token",
+					slinkyv1beta1.AnnotationTokenUsername:   "alice",
+					slinkyv1beta1.AnnotationTokenController: "slurm",
+				})
+				pod.Labels = nil
+				return pod
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPodToken(tt.pod); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("NewPodToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectPodToken(t *testing.T) {
+	secretRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "token-jwt-alice",
+		},
+		Key: "SLURM_JWT",
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "init"},
+			},
+			Containers: []corev1.Container{
+				{Name: "notebook"},
+				{
+					Name: "custom",
+					Env: []corev1.EnvVar{
+						{Name: PodTokenEnv, Value: "foo"},
+					},
+				},
+			},
+		},
+	}
+	ProjectPodToken(pod, secretRef)
+	// Projecting again is a no-op.
+	ProjectPodToken(pod, secretRef)
+
+	if len(pod.Spec.Volumes) != 1 {
+		t.Fatalf("ProjectPodToken() Volumes = %v", pod.Spec.Volumes)
+	}
+	if got := pod.Spec.Volumes[0].Secret; got.SecretName != secretRef.Name || got.Items[0].Key != secretRef.Key || got.Items[0].Path != PodTokenFile {
+		t.Errorf("ProjectPodToken() Secret = %v", got)
+	}
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != PodTokenDir || !container.VolumeMounts[0].ReadOnly {
+			t.Errorf("ProjectPodToken() %s VolumeMounts = %v", container.Name, container.VolumeMounts)
+		}
+		if len(container.Env) != 1 || container.Env[0].Name != PodTokenEnv {
+			t.Errorf("ProjectPodToken() %s Env = %v", container.Name, container.Env)
+		}
+	}
+	if got := pod.Spec.Containers[1].Env[0]; got.Value != "foo" || got.ValueFrom != nil {
+		t.Errorf("ProjectPodToken() overrode Env = %v", got)
+	}
+}
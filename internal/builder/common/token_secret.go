@@ -8,23 +8,27 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
-	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 )
 
 func (b *CommonBuilder) BuildTokenSecret(token *slinkyv1beta1.Token) (*corev1.Secret, error) {
 	ctx := context.TODO()
 
-	jwtRef := token.JwtRef()
-	signingKey, err := b.refResolver.GetSecretKeyRef(ctx, jwtRef, token.Namespace)
+	jwtRef, err := b.refResolver.GetTokenJwtRef(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := b.refResolver.GetTokenSigningKey(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
-	authToken, err := slurmjwt.NewToken(signingKey).
+	authToken, err := slurmjwt.NewTokenWithKey(signingKey).
 		WithUsername(token.Username()).
 		WithLifetime(token.Lifetime()).
 		NewSignedToken()
@@ -45,7 +49,11 @@ func (b *CommonBuilder) BuildTokenSecret(token *slinkyv1beta1.Token) (*corev1.Se
 	}
 
 	jwtSecret := &corev1.Secret{}
-	if err := b.client.Get(ctx, token.JwtKey(), jwtSecret); err != nil {
+	jwtKey := types.NamespacedName{
+		Name:      jwtRef.Name,
+		Namespace: token.Namespace,
+	}
+	if err := b.client.Get(ctx, jwtKey, jwtSecret); err != nil {
 		return nil, err
 	}
 
@@ -61,6 +61,39 @@ func TestBuilder_BuildTokenSecret(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "controllerRef",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(jwtSecret, &slinkyv1beta1.Controller{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "slurm",
+						},
+						Spec: slinkyv1beta1.ControllerSpec{
+							JwtKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "slurm-jwtkey",
+								},
+								Key: "jwt.key",
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				token: &slinkyv1beta1.Token{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.TokenSpec{
+						Username: "foo",
+						ControllerRef: &corev1.LocalObjectReference{
+							Name: "slurm",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "not found",
 			fields: fields{
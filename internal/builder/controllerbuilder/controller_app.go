@@ -7,6 +7,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"maps"
 	"path"
 	"slices"
 
@@ -42,6 +43,12 @@ func (b *ControllerBuilder) BuildController(controller *slinkyv1beta1.Controller
 
 	persistence := controller.Spec.Persistence
 
+	replicas := int32(1)
+	if controller.IsHighAvailability() {
+		// The primary and backup slurmctld.
+		replicas = 2
+	}
+
 	podTemplate, err := b.controllerPodTemplate(controller)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build pod template: %w", err)
@@ -51,7 +58,7 @@ func (b *ControllerBuilder) BuildController(controller *slinkyv1beta1.Controller
 		ObjectMeta: objectMeta,
 		Spec: appsv1.StatefulSetSpec{
 			PodManagementPolicy:  appsv1.ParallelPodManagement,
-			Replicas:             ptr.To[int32](1),
+			Replicas:             ptr.To(replicas),
 			RevisionHistoryLimit: ptr.To[int32](0),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
@@ -158,12 +165,14 @@ func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Cont
 		},
 		Base: corev1.PodSpec{
 			AutomountServiceAccountToken: ptr.To(false),
+			Affinity:                     controllerAffinity(controller),
 			Containers: []corev1.Container{
 				b.slurmctldContainer(spec.Slurmctld.Container, controller.ClusterName()),
 			},
 			InitContainers: func() []corev1.Container {
 				var initContainers []corev1.Container
-				if controller.Spec.InplaceReconfigure {
+				// Topology changes follow NodeSet pod placement, so they are applied by reconfigure.
+				if controller.Spec.InplaceReconfigure || len(controller.Spec.Topology) > 0 {
 					initContainers = append(initContainers, b.reconfigureContainer(spec.Reconfigure))
 				}
 				initContainers = append(initContainers, b.CommonBuilder.LogfileContainer(spec.LogFile, common.SlurmctldLogFilePath))
@@ -183,6 +192,29 @@ func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Cont
 	return b.CommonBuilder.BuildPodTemplate(opts), nil
 }
 
+// controllerAffinity prefers to schedule the primary and backup slurmctld on
+// different nodes, so that they do not fail together.
+func controllerAffinity(controller *slinkyv1beta1.Controller) *corev1.Affinity {
+	if !controller.IsHighAvailability() {
+		return nil
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []corev1.Volume {
 	out := []corev1.Volume{
 		{
@@ -199,14 +231,7 @@ func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []c
 							},
 						},
 						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: common.SlurmKeyFile},
-								},
-							},
+							Secret: new(common.SlurmKeyProjection(controller.AuthSlurmRef())),
 						},
 						{
 							Secret: &corev1.SecretProjection{
@@ -359,7 +384,10 @@ func (b *ControllerBuilder) getHashes(ctx context.Context, controller *slinkyv1b
 			return nil, err
 		}
 	}
-	slurmConfigHash := crypto.CheckSumFromMap(config.Data)
+	// topology.yaml is applied by reconfigure instead of a restart.
+	configData := maps.Clone(config.Data)
+	delete(configData, TopologyYamlFile)
+	slurmConfigHash := crypto.CheckSumFromMap(configData)
 
 	hashMap = structutils.MergeMaps(hashMap, map[string]string{
 		annotationSlurmConfigHash: slurmConfigHash,
@@ -86,6 +86,53 @@ func TestBuilder_BuildController(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with high availability",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Persistence: slinkyv1beta1.ControllerPersistence{
+							Enabled:       ptr.To(true),
+							ExistingClaim: "pvc",
+						},
+						HighAvailability: slinkyv1beta1.ControllerHighAvailability{
+							Enabled: true,
+						},
+						JwtKeyRef: &corev1.SecretKeySelector{},
+					},
+				},
+			},
+		},
+		{
+			name: "with topology",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Topology: []slinkyv1beta1.ControllerTopology{
+							{
+								Name: "topo-switch",
+								Tree: &slinkyv1beta1.ControllerTopologyTree{
+									LabelKeys: []string{corev1.LabelTopologyZone},
+								},
+							},
+						},
+						JwtKeyRef: &corev1.SecretKeySelector{},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -126,11 +173,58 @@ func TestBuilder_BuildController(t *testing.T) {
 			case got.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort != common.SlurmctldPort:
 				t.Errorf("Template.Spec.Containers[0].Ports[0].ContainerPort = %v , want = %v",
 					got.Spec.Template.Spec.Containers[0].Ports[0].Name, common.SlurmctldPort)
+
+			case tt.args.controller.IsHighAvailability() && ptr.Deref(got.Spec.Replicas, 0) != 2:
+				t.Errorf("Replicas = %v , want = %v", ptr.Deref(got.Spec.Replicas, 0), 2)
+
+			case tt.args.controller.IsHighAvailability() && got.Spec.Template.Spec.Affinity == nil:
+				t.Errorf("Template.Spec.Affinity = %v , want pod anti-affinity", got.Spec.Template.Spec.Affinity)
+
+			case len(tt.args.controller.Spec.Topology) > 0 && got.Spec.Template.Spec.InitContainers[0].Name != "reconfigure":
+				t.Errorf("Template.Spec.InitContainers[0].Name = %v , want = %v",
+					got.Spec.Template.Spec.InitContainers[0].Name, "reconfigure")
 			}
 		})
 	}
 }
 
+func TestBuilder_getHashes(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	newConfig := func(topologyYaml string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      controller.ConfigKey().Name,
+				Namespace: controller.ConfigKey().Namespace,
+			},
+			Data: map[string]string{
+				SlurmConfFile:    "ClusterName=slurm\n",
+				TopologyYamlFile: topologyYaml,
+			},
+		}
+	}
+	getHash := func(config *corev1.ConfigMap) string {
+		b := New(fake.NewFakeClient(config))
+		hashMap, err := b.getHashes(t.Context(), controller)
+		if err != nil {
+			t.Fatalf("Builder.getHashes() error = %v", err)
+		}
+		return hashMap[annotationSlurmConfigHash]
+	}
+
+	if got, want := getHash(newConfig("- topology: a\n")), getHash(newConfig("- topology: b\n")); got != want {
+		t.Errorf("Builder.getHashes() = %v, want = %v", got, want)
+	}
+	config := newConfig("- topology: a\n")
+	config.Data[SlurmConfFile] = "ClusterName=other\n"
+	if got, unwant := getHash(config), getHash(newConfig("- topology: a\n")); got == unwant {
+		t.Errorf("Builder.getHashes() = %v, want != %v", got, unwant)
+	}
+}
+
 func BenchmarkBuilder_BuildController(b *testing.B) {
 	type fields struct {
 		client client.Client
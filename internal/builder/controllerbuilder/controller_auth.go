@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerbuilder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+func (b *ControllerBuilder) BuildControllerSlurmJwks(controller *slinkyv1beta1.Controller) (*corev1.Secret, error) {
+	opts := common.SlurmJwksOpts{
+		Key: controller.AuthSlurmJwksKey(),
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: controller.Annotations,
+			Labels:      structutils.MergeMaps(controller.Labels, labels.NewBuilder().WithControllerLabels(controller).Build()),
+		},
+		Rotation: controller.Spec.SlurmKeyRotation,
+		Status:   controller.Status.SlurmKeyRotation,
+	}
+
+	return b.CommonBuilder.BuildSlurmJwksSecret(opts, controller)
+}
+
+func (b *ControllerBuilder) BuildControllerSlurmKey(controller *slinkyv1beta1.Controller) (*corev1.Secret, error) {
+	ref := controller.AuthSlurmGeneratedRef()
+	return b.buildControllerAuthKey(controller, ref)
+}
+
+func (b *ControllerBuilder) BuildControllerJwtKey(controller *slinkyv1beta1.Controller) (*corev1.Secret, error) {
+	ref := controller.AuthJwtGeneratedRef()
+	return b.buildControllerAuthKey(controller, ref)
+}
+
+func (b *ControllerBuilder) buildControllerAuthKey(controller *slinkyv1beta1.Controller, ref corev1.SecretKeySelector) (*corev1.Secret, error) {
+	opts := common.AuthKeyOpts{
+		Key: types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: controller.Namespace,
+		},
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: controller.Annotations,
+			Labels:      structutils.MergeMaps(controller.Labels, labels.NewBuilder().WithControllerLabels(controller).Build()),
+		},
+		SecretKey: ref.Key,
+	}
+
+	return b.CommonBuilder.BuildAuthKeySecret(opts, controller)
+}
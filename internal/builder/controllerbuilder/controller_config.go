@@ -13,18 +13,26 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmconf"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topology"
 )
 
 const (
-	SlurmConfFile  = "slurm.conf"
-	CgroupConfFile = "cgroup.conf"
+	SlurmConfFile    = "slurm.conf"
+	CgroupConfFile   = "cgroup.conf"
+	GresConfFile     = "gres.conf"
+	TopologyYamlFile = "topology.yaml"
 )
 
 func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*corev1.ConfigMap, error) {
@@ -46,6 +54,11 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		return nil, err
 	}
 
+	partitionList, err := b.refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
 	configFilesList := &corev1.ConfigMapList{
 		Items: make([]corev1.ConfigMap, 0, len(controller.Spec.ConfigFileRefs)),
 	}
@@ -61,10 +74,13 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		configFilesList.Items = append(configFilesList.Items, *cm)
 	}
 	hasCgroupConfFile := false
+	hasGresConfFile := false
 	for _, configMap := range configFilesList.Items {
 		if _, ok := configMap.Data[CgroupConfFile]; ok {
 			hasCgroupConfFile = true
-			break
+		}
+		if _, ok := configMap.Data[GresConfFile]; ok {
+			hasGresConfFile = true
 		}
 	}
 
@@ -136,7 +152,7 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		},
 		Data: map[string]string{
 			SlurmConfFile: buildSlurmConf(
-				controller, accounting, nodesetList,
+				controller, accounting, nodesetList, partitionList,
 				prologScripts, epilogScripts,
 				prologSlurmctldScripts, epilogSlurmctldScripts,
 			),
@@ -145,15 +161,63 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 	if !hasCgroupConfFile {
 		opts.Data[CgroupConfFile] = buildCgroupConf()
 	}
+	if !hasGresConfFile && len(buildGresTypes(controller, nodesetList)) > 0 {
+		opts.Data[GresConfFile] = buildGresConf(controller)
+	}
+	if len(controller.Spec.Topology) > 0 {
+		nodes, err := b.getNodeSetNodes(ctx, nodesetList)
+		if err != nil {
+			return nil, err
+		}
+		topologyYaml, err := topology.BuildConfig(controller.Spec.Topology, nodes)
+		if err != nil {
+			return nil, err
+		}
+		opts.Data[TopologyYamlFile] = topologyYaml
+	}
 
 	return b.CommonBuilder.BuildConfigMap(opts, controller)
 }
 
+// getNodeSetNodes returns the Kubernetes nodes hosting the pods of the NodeSets.
+func (b *ControllerBuilder) getNodeSetNodes(ctx context.Context, nodesetList *slinkyv1beta1.NodeSetList) ([]corev1.Node, error) {
+	nodeNames := set.New[string]()
+	for _, nodeset := range nodesetList.Items {
+		podList := &corev1.PodList{}
+		opts := []client.ListOption{
+			client.InNamespace(nodeset.Namespace),
+			client.MatchingLabels(labels.NewBuilder().WithWorkerSelectorLabels(&nodeset).Build()),
+		}
+		if err := b.client.List(ctx, podList, opts...); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Spec.NodeName != "" {
+				nodeNames.Insert(pod.Spec.NodeName)
+			}
+		}
+	}
+
+	nodes := make([]corev1.Node, 0, nodeNames.Len())
+	for _, nodeName := range nodeNames.SortedList() {
+		node := &corev1.Node{}
+		if err := b.client.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
 // https://slurm.schedmd.com/slurm.conf.html
 func buildSlurmConf(
 	controller *slinkyv1beta1.Controller,
 	accounting *slinkyv1beta1.Accounting,
 	nodesetList *slinkyv1beta1.NodeSetList,
+	partitionList *slinkyv1beta1.PartitionList,
 	prologScripts, epilogScripts []string,
 	prologSlurmctldScripts, epilogSlurmctldScripts []string,
 ) string {
@@ -174,7 +238,27 @@ func buildSlurmConf(
 		}(),
 	}
 
-	controllerHost := fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.ServiceFQDNShort())
+	gresTypes := buildGresTypes(controller, nodesetList)
+	if len(gresTypes) > 0 {
+		mergeConfig["GresTypes"] = gresTypes
+	}
+
+	powerSaveEnabled := isPowerSaveEnabled(nodesetList)
+	if powerSaveEnabled {
+		// Resumed CLOUD nodes take their addresses from slurmd registration.
+		mergeConfig["SlurmctldParameters"] = append(mergeConfig["SlurmctldParameters"], "cloud_reg_addrs")
+	}
+
+	controllerHosts := []string{
+		fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.ServiceFQDNShort()),
+	}
+	if controller.IsHighAvailability() {
+		// The service is headless, so each slurmctld is addressed by pod.
+		controllerHosts = []string{
+			fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.PrimaryFQDN()),
+			fmt.Sprintf("%s(%s)", controller.BackupName(), controller.BackupFQDN()),
+		}
+	}
 
 	conf := config.NewBuilder()
 
@@ -182,7 +266,9 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewPropertyRaw("### GENERAL ###"))
 	conf.AddProperty(config.NewProperty("ClusterName", controller.ClusterName()))
 	conf.AddProperty(config.NewProperty("SlurmUser", common.SlurmUser))
-	conf.AddProperty(config.NewProperty("SlurmctldHost", controllerHost))
+	for _, host := range controllerHosts {
+		conf.AddProperty(config.NewProperty("SlurmctldHost", host))
+	}
 	conf.AddProperty(config.NewProperty("SlurmctldPort", common.SlurmctldPort))
 	conf.AddProperty(config.NewProperty("StateSaveLocation", clusterSpoolDir(controller.ClusterName())))
 	conf.AddProperty(config.NewProperty("SlurmdUser", common.SlurmdUser))
@@ -204,12 +290,23 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewProperty("AuthAltParameters", strings.Join(mergeConfig["AuthAltParameters"], ",")))
 	conf.AddProperty(config.NewProperty("AuthInfo", strings.Join(mergeConfig["AuthInfo"], ",")))
 	conf.AddProperty(config.NewProperty("SlurmctldParameters", strings.Join(mergeConfig["SlurmctldParameters"], ",")))
+	if len(gresTypes) > 0 {
+		conf.AddProperty(config.NewProperty("GresTypes", strings.Join(gresTypes, ",")))
+	}
 
 	metricsEnabled := controller.Spec.Metrics.Enabled
 	if metricsEnabled {
 		conf.AddProperty(config.NewProperty("MetricsType", "metrics/openmetrics"))
 	}
 
+	if params := slurmconf.FromSpec(controller.Spec.SlurmConf); len(params) > 0 {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### SCHEDULING & TIMEOUTS ###"))
+		for _, param := range params {
+			conf.AddProperty(config.NewProperty(param.Key, param.Value))
+		}
+	}
+
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### ACCOUNTING ###"))
 	if accounting != nil {
@@ -232,12 +329,24 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewPropertyRaw(snippet))
 	}
 
+	if powerSaveEnabled {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### POWER SAVING ###"))
+		conf.AddProperty(config.NewPropertyRaw(buildPowerSaveConf()))
+	}
+
 	if snippet := buildNodeSetConf(nodesetList); snippet != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### NODESET & PARTITION ###"))
 		conf.AddProperty(config.NewPropertyRaw(snippet))
 	}
 
+	if snippet := buildPartitionConf(partitionList, nodesetList); snippet != "" {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### PARTITION ###"))
+		conf.AddProperty(config.NewPropertyRaw(snippet))
+	}
+
 	extraConf := controller.Spec.ExtraConf
 	if extraConf != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
@@ -321,8 +430,14 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 		partitionLine := []string{
 			fmt.Sprintf("PartitionName=%v", name),
 			fmt.Sprintf("Nodes=%v", name),
-			partition.Config,
 		}
+		if powerSave := nodeset.Spec.PowerSave; powerSave.Enabled {
+			partitionLine = append(partitionLine,
+				fmt.Sprintf("SuspendTime=%d", int64(powerSave.SuspendTime.Seconds())),
+				fmt.Sprintf("ResumeTimeout=%d", int64(powerSave.ResumeTimeout.Seconds())),
+			)
+		}
+		partitionLine = append(partitionLine, partition.Config)
 		partitionLineRendered := strings.Join(partitionLine, " ")
 		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
 	}
@@ -330,6 +445,134 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 	return conf.WithFinalNewline(false).Build()
 }
 
+// buildPartitionConf() returns a slurm.conf snippet containing the Partitions
+// and the NodeSets they select.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+func buildPartitionConf(partitionList *slinkyv1beta1.PartitionList, nodesetList *slinkyv1beta1.NodeSetList) string {
+	conf := config.NewBuilder()
+
+	sort.Slice(partitionList.Items, func(i, j int) bool {
+		return partitionList.Items[i].PartitionName() < partitionList.Items[j].PartitionName()
+	})
+	for _, partition := range partitionList.Items {
+		spec := partition.Spec
+		partitionLine := []string{
+			fmt.Sprintf("PartitionName=%v", partition.PartitionName()),
+		}
+		if nodesets := selectPartitionNodeSets(&partition, nodesetList); len(nodesets) > 0 {
+			partitionLine = append(partitionLine, fmt.Sprintf("Nodes=%v", strings.Join(nodesets, ",")))
+		}
+		if spec.Default {
+			partitionLine = append(partitionLine, "Default=YES")
+		}
+		if spec.MaxTime != nil {
+			partitionLine = append(partitionLine, fmt.Sprintf("MaxTime=%d", int64(spec.MaxTime.Minutes())))
+		}
+		if spec.DefaultTime != nil {
+			partitionLine = append(partitionLine, fmt.Sprintf("DefaultTime=%d", int64(spec.DefaultTime.Minutes())))
+		}
+		if spec.PriorityTier != nil {
+			partitionLine = append(partitionLine, fmt.Sprintf("PriorityTier=%d", *spec.PriorityTier))
+		}
+		if len(spec.AllowAccounts) > 0 {
+			partitionLine = append(partitionLine, fmt.Sprintf("AllowAccounts=%v", strings.Join(spec.AllowAccounts, ",")))
+		}
+		if spec.QOS != "" {
+			partitionLine = append(partitionLine, fmt.Sprintf("QOS=%v", spec.QOS))
+		}
+		if spec.OverSubscribe != "" {
+			partitionLine = append(partitionLine, fmt.Sprintf("OverSubscribe=%v", strings.ToUpper(string(spec.OverSubscribe))))
+		}
+		if spec.Config != "" {
+			partitionLine = append(partitionLine, spec.Config)
+		}
+		partitionLineRendered := strings.Join(partitionLine, " ")
+		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
+	}
+
+	return conf.WithFinalNewline(false).Build()
+}
+
+// selectPartitionNodeSets returns the sorted Slurm names of the NodeSets
+// selected by the Partition.
+func selectPartitionNodeSets(partition *slinkyv1beta1.Partition, nodesetList *slinkyv1beta1.NodeSetList) []string {
+	if partition.Spec.NodeSetSelector == nil {
+		return nil
+	}
+	// NOTE: the webhook rejects invalid selectors.
+	selector, err := metav1.LabelSelectorAsSelector(partition.Spec.NodeSetSelector)
+	if err != nil {
+		return nil
+	}
+
+	nodesets := []string{}
+	for _, nodeset := range nodesetList.Items {
+		if selector.Matches(k8slabels.Set(nodeset.Labels)) {
+			nodesets = append(nodesets, common.GetSlurmNodeSetName(&nodeset))
+		}
+	}
+	sort.Strings(nodesets)
+
+	return nodesets
+}
+
+// isPowerSaveEnabled reports if any NodeSet uses Slurm power saving.
+func isPowerSaveEnabled(nodesetList *slinkyv1beta1.NodeSetList) bool {
+	for _, nodeset := range nodesetList.Items {
+		if nodeset.Spec.PowerSave.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPowerSaveConf() returns a slurm.conf snippet containing power saving config.
+// The NodeSet controller creates and deletes pods as slurmctld resumes and suspends
+// nodes, so the programs have nothing to do.
+//
+// https://slurm.schedmd.com/power_save.html
+// https://slurm.schedmd.com/slurm.conf.html#OPT_ResumeProgram
+// https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendProgram
+func buildPowerSaveConf() string {
+	conf := config.NewBuilder()
+
+	conf.AddProperty(config.NewProperty("ResumeProgram", "/bin/true"))
+	conf.AddProperty(config.NewProperty("SuspendProgram", "/bin/true"))
+
+	return conf.WithFinalNewline(false).Build()
+}
+
+// buildGresTypes returns the GRES names of the NodeSet pods, derived from their extended resources.
+//
+// https://slurm.schedmd.com/slurm.conf.html#OPT_GresTypes
+func buildGresTypes(controller *slinkyv1beta1.Controller, nodesetList *slinkyv1beta1.NodeSetList) []string {
+	gresTypes := set.New[string]()
+	for _, nodeset := range nodesetList.Items {
+		for _, gres := range common.GetNodeSetGres(&nodeset.Spec, controller.Spec.Gres.Resources) {
+			name, _, _ := strings.Cut(gres, ":")
+			gresTypes.Insert(name)
+		}
+	}
+	return gresTypes.SortedList()
+}
+
+// buildGresConf returns the gres.conf of the GRES derived from extended resources.
+// Their counts are registered by each slurmd, so only device detection is configured.
+//
+// https://slurm.schedmd.com/gres.conf.html
+func buildGresConf(controller *slinkyv1beta1.Controller) string {
+	conf := config.NewBuilder()
+
+	autoDetect := controller.Spec.Gres.AutoDetect
+	if autoDetect == "" {
+		autoDetect = "off"
+	}
+	conf.AddProperty(config.NewProperty("AutoDetect", autoDetect))
+
+	return conf.Build()
+}
+
 // https://slurm.schedmd.com/cgroup.conf.html
 func buildCgroupConf() string {
 	conf := config.NewBuilder()
@@ -5,17 +5,40 @@ package controllerbuilder
 
 import (
 	"math/rand/v2"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestBuilder_BuildControllerConfig(t *testing.T) {
+	topologyNodeSet := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "slurm-worker", Namespace: "slinky"},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+		},
+	}
+	newTopologyPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "slinky",
+				Labels:    labels.NewBuilder().WithWorkerSelectorLabels(topologyNodeSet).Build(),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+		}
+	}
 	type fields struct {
 		client client.Client
 	}
@@ -23,11 +46,13 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 		controller *slinkyv1beta1.Controller
 	}
 	tests := []struct {
-		name        string
-		fields      fields
-		args        args
-		wantErr     bool
-		wantScripts []string
+		name         string
+		fields       fields
+		args         args
+		wantErr      bool
+		wantScripts  []string
+		wantConf     []string
+		wantTopology []string
 	}{
 		{
 			name: "default",
@@ -77,7 +102,7 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "with accounting, nodesets, config",
+			name: "with accounting, nodesets, partitions, config",
 			fields: fields{
 				client: fake.NewClientBuilder().
 					WithObjects(&slinkyv1beta1.Accounting{
@@ -113,6 +138,17 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 							},
 						},
 					}).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "all",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+							NodeSetSelector: &metav1.LabelSelector{},
+						},
+					}).
 					WithObjects(&corev1.ConfigMap{
 						ObjectMeta: metav1.ObjectMeta{
 							Name: "slurm-config",
@@ -142,9 +178,22 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 						ConfigFileRefs: []corev1.LocalObjectReference{
 							{Name: "slurm-config"},
 						},
+						SlurmConf: slinkyv1beta1.SlurmConf{
+							Scheduling: slinkyv1beta1.SlurmConfScheduling{
+								SchedulerType: "sched/backfill",
+							},
+							Timeouts: slinkyv1beta1.SlurmConfTimeouts{
+								SlurmdTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						},
 					},
 				},
 			},
+			wantScripts: []string{
+				"PartitionName=all Nodes=foo",
+				"SchedulerType=sched/backfill",
+				"SlurmdTimeout=300",
+			},
 		},
 		{
 			name: "multiple prolog configmaps",
@@ -220,6 +269,77 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 			},
 			wantScripts: []string{"00-cleanup.sh", "90-finalize.sh"},
 		},
+		{
+			name: "with high availability",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm", Namespace: "slinky"},
+					Spec: slinkyv1beta1.ControllerSpec{
+						HighAvailability: slinkyv1beta1.ControllerHighAvailability{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantConf: []string{
+				"SlurmctldHost=slurm-controller-0(slurm-controller-0.slurm-controller.slinky)\n",
+				"SlurmctldHost=slurm-controller-1(slurm-controller-1.slurm-controller.slinky)\n",
+			},
+		},
+		{
+			name: "with topology",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(topologyNodeSet).
+					WithObjects(newTopologyPod("slurm-worker-0", "node0")).
+					WithObjects(newTopologyPod("slurm-worker-1", "node1")).
+					WithObjects(newTopologyPod("slurm-worker-2", "")).
+					WithObjects(&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "node0",
+							Labels: map[string]string{corev1.LabelTopologyZone: "a"},
+						},
+					}).
+					WithObjects(&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "node1",
+							Labels: map[string]string{corev1.LabelTopologyZone: "b"},
+						},
+					}).
+					WithObjects(&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "node2",
+							Labels: map[string]string{corev1.LabelTopologyZone: "c"},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm", Namespace: "slinky"},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Topology: []slinkyv1beta1.ControllerTopology{
+							{
+								Name:           "topo-switch",
+								ClusterDefault: true,
+								Tree: &slinkyv1beta1.ControllerTopologyTree{
+									LabelKeys: []string{corev1.LabelTopologyZone},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantTopology: []string{
+				"topology: topo-switch\n",
+				"- children: a,b\n      switch: root\n",
+				"- switch: a\n",
+				"- switch: b\n",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -243,6 +363,19 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 					t.Errorf("Expected %s in slurm.conf", script)
 				}
 			}
+			for _, line := range tt.wantConf {
+				if !strings.Contains(got.Data[SlurmConfFile], line) {
+					t.Errorf("Expected %q in slurm.conf", line)
+				}
+			}
+			for _, line := range tt.wantTopology {
+				if !strings.Contains(got.Data[TopologyYamlFile], line) {
+					t.Errorf("Expected %q in topology.yaml", line)
+				}
+			}
+			if len(tt.wantTopology) == 0 && got.Data[TopologyYamlFile] != "" {
+				t.Errorf("Unexpected topology.yaml: %s", got.Data[TopologyYamlFile])
+			}
 		})
 	}
 }
@@ -499,6 +632,33 @@ PartitionName=nodeset-1 Nodes=nodeset-1 MaxTime=UNLIMITED OverSubscribe=EXCLUSIV
 NodeSet=nodeset-2 Feature=nodeset-2
 PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 		},
+		{
+			name: "power save",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "nodeset-0",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Partition: slinkyv1beta1.NodeSetPartition{
+								Enabled: true,
+								Config:  "MaxTime=UNLIMITED",
+							},
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled:       true,
+								MaxNodes:      10,
+								SuspendTime:   metav1.Duration{Duration: 10 * time.Minute},
+								ResumeTimeout: metav1.Duration{Duration: 5 * time.Minute},
+							},
+						},
+					},
+				},
+			},
+			want: `NodeSet=nodeset-0 Feature=nodeset-0
+PartitionName=nodeset-0 Nodes=nodeset-0 SuspendTime=600 ResumeTimeout=300 MaxTime=UNLIMITED`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -518,6 +678,158 @@ PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 	}
 }
 
+func Test_buildPartitionConf(t *testing.T) {
+	nodesetList := &slinkyv1beta1.NodeSetList{
+		Items: []slinkyv1beta1.NodeSet{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "gpu-b",
+					Labels: map[string]string{"tier": "gpu"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "gpu-a",
+					Labels: map[string]string{"tier": "gpu"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "cpu",
+					Labels: map[string]string{"tier": "cpu"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		partitionList *slinkyv1beta1.PartitionList
+		want          string
+	}{
+		{
+			name: "empty",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{},
+			},
+			want: "",
+		},
+		{
+			name: "no selector",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{
+					{ObjectMeta: metav1.ObjectMeta{Name: "debug"}},
+				},
+			},
+			want: "PartitionName=debug",
+		},
+		{
+			name: "empty selector",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "all"},
+						Spec: slinkyv1beta1.PartitionSpec{
+							NodeSetSelector: &metav1.LabelSelector{},
+						},
+					},
+				},
+			},
+			want: "PartitionName=all Nodes=cpu,gpu-a,gpu-b",
+		},
+		{
+			name: "typed fields",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "gpu-partition"},
+						Spec: slinkyv1beta1.PartitionSpec{
+							Name: "gpu",
+							NodeSetSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "gpu"},
+							},
+							Default:       true,
+							MaxTime:       &metav1.Duration{Duration: 24*time.Hour + 30*time.Second},
+							DefaultTime:   &metav1.Duration{Duration: time.Hour},
+							PriorityTier:  ptr.To[int32](10),
+							AllowAccounts: []string{"science", "physics"},
+							QOS:           "gpu",
+							OverSubscribe: slinkyv1beta1.PartitionOverSubscribeExclusive,
+							Config:        "PreemptMode=REQUEUE",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "cpu"},
+						Spec: slinkyv1beta1.PartitionSpec{
+							NodeSetSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "cpu"},
+							},
+						},
+					},
+				},
+			},
+			want: `PartitionName=cpu Nodes=cpu
+PartitionName=gpu Nodes=gpu-a,gpu-b Default=YES MaxTime=1440 DefaultTime=60 PriorityTier=10 AllowAccounts=science,physics QOS=gpu OverSubscribe=EXCLUSIVE PreemptMode=REQUEUE`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildPartitionConf(tt.partitionList, nodesetList); got != tt.want {
+				t.Errorf("buildPartitionConf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isPowerSaveEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        bool
+	}{
+		{
+			name: "empty",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{},
+			},
+			want: false,
+		},
+		{
+			name: "disabled",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{ObjectMeta: metav1.ObjectMeta{Name: "nodeset-0"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "nodeset-1"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "enabled",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{ObjectMeta: metav1.ObjectMeta{Name: "nodeset-0"}},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "nodeset-1"},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPowerSaveEnabled(tt.nodesetList); got != tt.want {
+				t.Errorf("isPowerSaveEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_buildPrologEpilogConf(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -647,3 +959,84 @@ EpilogSlurmctld=/etc/slurm/epilog-slurmctld-2.sh`,
 		})
 	}
 }
+
+func Test_buildGresTypes(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		Spec: slinkyv1beta1.ControllerSpec{
+			Gres: slinkyv1beta1.ControllerGres{
+				Resources: []slinkyv1beta1.ControllerGresResource{
+					{ResourceName: "example.com/gpu", Name: "gpu"},
+					{ResourceName: "example.com/nic", Name: "nic"},
+				},
+			},
+		},
+	}
+	newNodeSet := func(name string, limits corev1.ResourceList) slinkyv1beta1.NodeSet {
+		nodeset := slinkyv1beta1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		nodeset.Spec.Slurmd.Resources.Limits = limits
+		return nodeset
+	}
+	tests := []struct {
+		name        string
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        []string
+	}{
+		{
+			name:        "empty",
+			nodesetList: &slinkyv1beta1.NodeSetList{},
+			want:        []string{},
+		},
+		{
+			name: "extended resources",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newNodeSet("nodeset-0", corev1.ResourceList{"example.com/nic": resource.MustParse("1")}),
+					newNodeSet("nodeset-1", corev1.ResourceList{"example.com/gpu": resource.MustParse("8")}),
+					newNodeSet("nodeset-2", corev1.ResourceList{"example.com/gpu": resource.MustParse("4")}),
+					newNodeSet("nodeset-3", nil),
+				},
+			},
+			want: []string{"gpu", "nic"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildGresTypes(controller, tt.nodesetList)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("buildGresTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildGresConf(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       string
+	}{
+		{
+			name:       "default",
+			controller: &slinkyv1beta1.Controller{},
+			want:       "AutoDetect=off\n",
+		},
+		{
+			name: "autoDetect",
+			controller: &slinkyv1beta1.Controller{
+				Spec: slinkyv1beta1.ControllerSpec{
+					Gres: slinkyv1beta1.ControllerGres{
+						AutoDetect: "nvml",
+					},
+				},
+			},
+			want: "AutoDetect=nvml\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildGresConf(tt.controller); !strings.HasSuffix(got, tt.want) {
+				t.Errorf("buildGresConf() = %v, want suffix %v", got, tt.want)
+			}
+		})
+	}
+}
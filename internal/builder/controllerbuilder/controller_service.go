@@ -25,6 +25,8 @@ func (b *ControllerBuilder) BuildControllerService(controller *slinkyv1beta1.Con
 		Selector: labels.NewBuilder().
 			WithControllerSelectorLabels(controller).
 			Build(),
+		// The primary and backup slurmctld must be addressable by pod.
+		Headless: controller.IsHighAvailability(),
 	}
 
 	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
@@ -121,6 +121,43 @@ func TestBuilder_BuildControllerService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with high availability",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						JwtKeyRef: &corev1.SecretKeySelector{},
+						HighAvailability: slinkyv1beta1.ControllerHighAvailability{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			want: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					ClusterIP:                corev1.ClusterIPNone,
+					PublishNotReadyAddresses: true,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "slurmctld",
+							Protocol:   "TCP",
+							Port:       6817,
+							TargetPort: intstr.FromString("slurmctld"),
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/instance": "slurm",
+						"app.kubernetes.io/name":     "slurmctld",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
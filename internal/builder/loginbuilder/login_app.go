@@ -125,6 +125,7 @@ func (b *LoginBuilder) loginPodTemplate(loginset *slinkyv1beta1.LoginSet) (corev
 		WithMetadata(loginset.Spec.Template.Metadata).
 		WithLabels(labels.NewBuilder().WithLoginLabels(loginset).Build()).
 		WithAnnotations(hashMap).
+		WithAnnotations(common.SlurmKeyRotationHashes(controller)).
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.LoginApp,
 		}).
@@ -179,14 +180,7 @@ func loginVolumes(loginset *slinkyv1beta1.LoginSet, controller *slinkyv1beta1.Co
 					DefaultMode: ptr.To[int32](0o600),
 					Sources: []corev1.VolumeProjection{
 						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: common.SlurmKeyFile},
-								},
-							},
+							Secret: new(common.SlurmKeyProjection(controller.AuthSlurmRef())),
 						},
 					},
 				},
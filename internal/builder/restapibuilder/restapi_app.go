@@ -85,6 +85,7 @@ func (b *RestapiBuilder) restapiPodTemplate(restapi *slinkyv1beta1.RestApi) (cor
 		WithLabels(restapi.Labels).
 		WithMetadata(restapi.Spec.Template.Metadata).
 		WithLabels(labels.NewBuilder().WithRestapiLabels(restapi).Build()).
+		WithAnnotations(common.SlurmKeyRotationHashes(controller)).
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.RestapiApp,
 		}).
@@ -137,14 +138,7 @@ func restapiVolumes(controller *slinkyv1beta1.Controller) []corev1.Volume {
 							},
 						},
 						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: common.SlurmKeyFile},
-								},
-							},
+							Secret: new(common.SlurmKeyProjection(controller.AuthSlurmRef())),
 						},
 					},
 				},
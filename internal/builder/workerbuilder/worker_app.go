@@ -44,6 +44,7 @@ func (b *WorkerBuilder) BuildWorkerPodTemplate(nodeset *slinkyv1beta1.NodeSet, c
 		WithMetadata(nodeset.Spec.Template.Metadata).
 		WithLabels(labels.NewBuilder().WithWorkerLabels(nodeset).Build()).
 		WithAnnotations(hashMap).
+		WithAnnotations(common.SlurmKeyRotationHashes(controller)).
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.WorkerApp,
 		}).
@@ -93,14 +94,7 @@ func nodesetVolumes(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Co
 					DefaultMode: ptr.To[int32](0o600),
 					Sources: []corev1.VolumeProjection{
 						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: common.SlurmKeyFile},
-								},
-							},
+							Secret: new(common.SlurmKeyProjection(controller.AuthSlurmRef())),
 						},
 					},
 				},
@@ -279,11 +273,11 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 func slurmdArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Controller) []string {
 	args := []string{"-Z"}
 	args = append(args, common.ConfiglessArgs(controller)...)
-	args = append(args, slurmdConfArgs(nodeset)...)
+	args = append(args, slurmdConfArgs(nodeset, controller)...)
 	return args
 }
 
-func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
+func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Controller) []string {
 	extraConf := []string{}
 	if nodeset.Spec.ExtraConf != "" {
 		extraConf = strings.Split(nodeset.Spec.ExtraConf, " ")
@@ -293,6 +287,9 @@ func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
 	confMap := map[string]string{
 		"Features": name,
 	}
+	if gres := common.GetNodeSetGres(&nodeset.Spec, controller.Spec.Gres.Resources); len(gres) > 0 {
+		confMap["Gres"] = strings.Join(gres, ",")
+	}
 	for _, item := range extraConf {
 		pair := strings.SplitN(item, "=", 2)
 		key := cases.Title(language.English).String(pair[0])
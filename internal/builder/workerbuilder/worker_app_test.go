@@ -370,3 +370,55 @@ func TestWorkerBuilder_getResourceLimits(t *testing.T) {
 		})
 	}
 }
+
+func Test_slurmdConfArgs(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			Gres: slinkyv1beta1.ControllerGres{
+				Resources: []slinkyv1beta1.ControllerGresResource{
+					{ResourceName: "example.com/gpu", Name: "gpu", Type: "h100"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    string
+	}{
+		{
+			name: "default",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			want: "'Features=foo'",
+		},
+		{
+			name: "gres",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := &slinkyv1beta1.NodeSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					Spec: slinkyv1beta1.NodeSetSpec{
+						ExtraConf: "Gres=nic:1",
+					},
+				}
+				nodeset.Spec.Slurmd.Resources.Limits = corev1.ResourceList{
+					"example.com/gpu": resource.MustParse("8"),
+				}
+				return nodeset
+			}(),
+			want: "'Features=foo Gres=gpu:h100:8,nic:1'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slurmdConfArgs(tt.nodeset, controller)
+			if len(got) != 2 || got[1] != tt.want {
+				t.Errorf("slurmdConfArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/accountingbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/accounting/eventhandler"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
@@ -54,6 +55,8 @@ type AccountingReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	ClientMap *clientmap.ClientMap
+
 	builder       *builder.AccountingBuilder
 	refResolver   *refresolver.RefResolver
 	eventRecorder events.EventRecorder
@@ -62,7 +65,7 @@ type AccountingReconciler struct {
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings/finalizers,verbs=update
-// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
@@ -114,6 +117,7 @@ func (r *AccountingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Watches(&slinkyv1beta1.Accounting{}, eventhandler.NewAccountingEventHandler(r.Client)).
+		Watches(&slinkyv1beta1.Controller{}, eventhandler.NewControllerEventHandler(r.Client)).
 		Watches(&corev1.Secret{}, eventhandler.NewSecretEventHandler(r.Client)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
@@ -121,12 +125,14 @@ func (r *AccountingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func NewReconciler(c client.Client) *AccountingReconciler {
+func NewReconciler(c client.Client, cm *clientmap.ClientMap) *AccountingReconciler {
 	s := c.Scheme()
 	return &AccountingReconciler{
 		Client: c,
 		Scheme: s,
 
+		ClientMap: cm,
+
 		builder:       builder.New(c),
 		refResolver:   refresolver.New(c),
 		eventRecorder: events.NewFakeRecorder(100),
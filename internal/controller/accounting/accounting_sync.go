@@ -6,16 +6,22 @@ package accounting
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/keyrotation"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
 )
 
@@ -56,6 +62,31 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "AuthKeys",
+			SyncFn: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+				if accounting.Spec.External {
+					return nil
+				}
+				if err := r.syncGeneratedAuthKeys(ctx, accounting); err != nil {
+					return err
+				}
+				if err := r.syncAuthKeyRotations(ctx, accounting, time.Now()); err != nil {
+					return err
+				}
+				if accounting.Spec.SlurmKeyRotation == nil {
+					return nil
+				}
+				object, err := r.builder.BuildAccountingSlurmJwks(accounting)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Config",
 			SyncFn: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
@@ -101,3 +132,104 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 
 	return r.syncStatus(ctx, accounting)
 }
+
+// syncGeneratedAuthKeys creates the Secrets of the keys which the Accounting
+// omits, and records them in its status. The Secrets are only created, never
+// replaced, so the keys are kept. They are shared by the Controllers which
+// reference the Accounting, so slurmdbd and slurmctld authenticate each other.
+func (r *AccountingReconciler) syncGeneratedAuthKeys(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+	accounting.Status.SlurmKeyRef = nil
+	accounting.Status.JwtKeyRef = nil
+
+	if accounting.IsAuthSlurmRefOmitted() {
+		object, err := r.builder.BuildAccountingSlurmKey(accounting)
+		if err != nil {
+			return fmt.Errorf("failed to build: %w", err)
+		}
+		if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, object, false); err != nil {
+			return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+		}
+		accounting.Status.SlurmKeyRef = new(accounting.AuthSlurmGeneratedRef())
+	}
+
+	if accounting.IsAuthJwtRefOmitted() {
+		object, err := r.builder.BuildAccountingJwtKey(accounting)
+		if err != nil {
+			return fmt.Errorf("failed to build: %w", err)
+		}
+		if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, object, false); err != nil {
+			return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+		}
+		accounting.Status.JwtKeyRef = new(accounting.AuthJwtGeneratedRef())
+	}
+
+	return nil
+}
+
+// syncAuthKeyRotations sets the status of the rotated keys of the Accounting,
+// and requeues it until the active key of the spec may sign. The status is
+// stored at once, as the grace period is timed from it.
+func (r *AccountingReconciler) syncAuthKeyRotations(ctx context.Context, accounting *slinkyv1beta1.Accounting, now time.Time) error {
+	rolledOut, err := r.isSlurmKeyRolledOut(ctx, accounting)
+	if err != nil {
+		return fmt.Errorf("failed to get Slurm key rollout: %w", err)
+	}
+	slurmKeyRotation, requeue := keyrotation.SyncStatus(
+		accounting.Spec.SlurmKeyRotation, accounting.Status.SlurmKeyRotation, rolledOut, now)
+
+	if !apiequality.Semantic.DeepEqual(accounting.Status.SlurmKeyRotation, slurmKeyRotation) {
+		mutateFn := func(toPatch *slinkyv1beta1.Accounting) error {
+			toPatch.Status.SlurmKeyRotation = slurmKeyRotation
+			return nil
+		}
+		if err := objectutils.StatusPatchObject(r.Client, ctx, accounting.DeepCopy(), mutateFn); err != nil {
+			return fmt.Errorf("failed to patch Accounting (%s) status: %w", klog.KObj(accounting), err)
+		}
+	}
+	accounting.Status.SlurmKeyRotation = slurmKeyRotation
+
+	// The duration store keeps the greater duration, so the requeue is capped
+	// to not delay the refresh of the Slurm status.
+	requeue = min(requeue, SlurmStatusRefreshInterval)
+	if requeue > 0 {
+		durationStore.Push(client.ObjectKeyFromObject(accounting).String(), requeue)
+	}
+	return nil
+}
+
+// isSlurmKeyRolledOut returns true if slurmdbd, and the slurmctld of every
+// Controller which references the Accounting, has rolled out the rotated Slurm
+// keys of its status.
+func (r *AccountingReconciler) isSlurmKeyRolledOut(ctx context.Context, accounting *slinkyv1beta1.Accounting) (bool, error) {
+	if accounting.Spec.SlurmKeyRotation == nil || accounting.Status.SlurmKeyRotation == nil {
+		return true, nil
+	}
+
+	rolledOut, err := keyrotation.IsStatefulSetRolledOut(ctx, r.Client,
+		accounting.Key(), accounting.AuthSlurmKey(), common.AnnotationAuthSlurmKeyHash)
+	if err != nil || !rolledOut {
+		return false, err
+	}
+
+	controllerList, err := r.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return false, err
+	}
+	for _, controller := range controllerList.Items {
+		if controller.Spec.External {
+			continue
+		}
+		// A Controller which shares the keys has no rotation of its own.
+		if !controller.IsAuthSlurmRefOmitted() && (controller.Status.SlurmKeyRotation == nil ||
+			!slices.Contains(controller.Status.SlurmKeyRotation.KeyIDs, accounting.Spec.SlurmKeyRotation.ActiveKeyID)) {
+			return false, nil
+		}
+		rolledOut, err := keyrotation.IsStatefulSetRolledOut(ctx, r.Client,
+			controller.Key(), controller.AuthSlurmKey(), common.AnnotationAuthSlurmKeyHash)
+		if err != nil || !rolledOut {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -6,34 +6,55 @@ package accounting
 import (
 	"context"
 	"fmt"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/rolloututils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurminfo"
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
+// SlurmStatusRefreshInterval is how often the Slurm status is refreshed
+// (e.g. slurmdbd reachability).
+const SlurmStatusRefreshInterval = 30 * time.Second
+
 // syncStatus handles determining and updating the status.
 func (r *AccountingReconciler) syncStatus(
 	ctx context.Context,
 	accounting *slinkyv1beta1.Accounting,
 ) error {
-	logger := log.FromContext(ctx)
-
 	newStatus := slinkyv1beta1.AccountingStatus{
 		Conditions: []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, accounting.Status.Conditions...)
+	newStatus.SlurmKeyRotation = accounting.Status.SlurmKeyRotation
+	newStatus.SlurmKeyRef = accounting.Status.SlurmKeyRef
+	newStatus.JwtKeyRef = accounting.Status.JwtKeyRef
 
-	if apiequality.Semantic.DeepEqual(accounting.Status, newStatus) {
-		logger.V(2).Info("Accounting Status has not changed, skipping status update",
-			"accounting", klog.KObj(accounting), "status", accounting.Status)
-		return nil
+	if !accounting.Spec.External {
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, accounting.Key(), sts); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get StatefulSet: %w", err)
+			}
+			sts = nil
+		}
+		rolloututils.SetConditions(&newStatus.Conditions, accounting.Generation, rolloututils.FromStatefulSet(sts))
+	}
+
+	if err := r.syncSlurmdbdCondition(ctx, accounting, &newStatus); err != nil {
+		return err
 	}
 
 	if err := r.updateStatus(ctx, accounting, &newStatus); err != nil {
@@ -44,6 +65,64 @@ func (r *AccountingReconciler) syncStatus(
 	return nil
 }
 
+// syncSlurmdbdCondition sets the SlurmdbdReachable condition, by way of the
+// slurmrestd of a Controller which references the Accounting. While slurmdbd
+// is not reachable, the Accounting is not ready.
+func (r *AccountingReconciler) syncSlurmdbdCondition(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+	newStatus *slinkyv1beta1.AccountingStatus,
+) error {
+	condition := metav1.Condition{
+		Type:               conditions.AccountingConditionSlurmdbdReachable,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "ClientNotReady",
+		Message:            "There is no Slurm client for a Controller which references the Accounting",
+		ObservedGeneration: accounting.Generation,
+	}
+
+	controllerList, err := r.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return fmt.Errorf("failed to get Controllers for Accounting: %w", err)
+	}
+	for _, controller := range controllerList.Items {
+		key := client.ObjectKeyFromObject(&controller)
+		slurmClient := r.ClientMap.Get(key)
+		if slurmClient == nil {
+			continue
+		}
+		durationStore.Push(client.ObjectKeyFromObject(accounting).String(), SlurmStatusRefreshInterval)
+		if err := slurminfo.PingSlurmdbd(ctx, slurmClient); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "NotReachable"
+			condition.Message = fmt.Sprintf("slurmdbd is not reachable from Controller(%s): %v", key, err)
+		} else {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "Reachable"
+			condition.Message = fmt.Sprintf("slurmdbd is reachable from Controller(%s)", key)
+		}
+		break
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	// An external slurmdbd has no rollout, so is only ready while it is reachable.
+	switch {
+	case accounting.Spec.External:
+	case condition.Status == metav1.ConditionFalse &&
+		meta.IsStatusConditionTrue(newStatus.Conditions, conditions.ConditionReady):
+	default:
+		return nil
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+		Type:               conditions.ConditionReady,
+		Status:             condition.Status,
+		Reason:             "Slurmdbd" + condition.Reason,
+		Message:            condition.Message,
+		ObservedGeneration: accounting.Generation,
+	})
+	return nil
+}
+
 func (r *AccountingReconciler) updateStatus(
 	ctx context.Context,
 	accounting *slinkyv1beta1.Accounting,
@@ -66,6 +145,11 @@ func (r *AccountingReconciler) updateStatus(
 			}
 			return err
 		}
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("Accounting Status has not changed, skipping status update",
+				"accounting", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
@@ -4,16 +4,25 @@
 package accounting
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/accountingbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	"k8s.io/client-go/tools/events"
@@ -25,6 +34,7 @@ func newAccountingController(client client.Client) *AccountingReconciler {
 	r := &AccountingReconciler{
 		Client:        client,
 		Scheme:        client.Scheme(),
+		ClientMap:     clientmap.NewClientMap(),
 		builder:       builder.New(client),
 		refResolver:   refresolver.New(client),
 		eventRecorder: events.NewFakeRecorder(10),
@@ -78,6 +88,222 @@ func TestAccountingReconciler_sync(t *testing.T) {
 	}
 }
 
+func TestAccountingReconciler_syncGeneratedAuthKeys(t *testing.T) {
+	slurmKey := testutils.NewSlurmKeyRef("slurmkey")
+	jwtKey := testutils.NewJwtKeyRef("jwtkey")
+	password := testutils.NewPasswordRef("password")
+	newAccounting := func(slurmKeyRef corev1.SecretKeySelector, jwtKeyRef *corev1.SecretKeySelector) *slinkyv1beta1.Accounting {
+		accounting := testutils.NewAccounting("slurm", slurmKeyRef, corev1.SecretKeySelector{}, password)
+		accounting.Spec.JwtKeyRef = jwtKeyRef
+		return accounting
+	}
+	omitted := newAccounting(corev1.SecretKeySelector{}, nil)
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, omitted)
+	controller.Spec.JwtKeyRef = nil
+	generatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      omitted.AuthSlurmGeneratedRef().Name,
+		},
+		Data: map[string][]byte{
+			slinkyv1beta1.AuthSlurmKeyFile: []byte("existing"),
+		},
+	}
+
+	type fields struct {
+		Client client.Client
+	}
+	type args struct {
+		accounting *slinkyv1beta1.Accounting
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantSlurmRef  *corev1.SecretKeySelector
+		wantJwtRef    *corev1.SecretKeySelector
+		wantSlurmData []byte
+	}{
+		{
+			name: "given",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				accounting: newAccounting(slurmKey, &jwtKey),
+			},
+		},
+		{
+			name: "generated",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				accounting: omitted.DeepCopy(),
+			},
+			wantSlurmRef: new(omitted.AuthSlurmGeneratedRef()),
+			wantJwtRef:   new(omitted.AuthJwtGeneratedRef()),
+		},
+		{
+			name: "generated, existing",
+			fields: fields{
+				Client: fake.NewFakeClient(generatedSecret.DeepCopy()),
+			},
+			args: args{
+				accounting: newAccounting(corev1.SecretKeySelector{}, &jwtKey),
+			},
+			wantSlurmRef:  new(omitted.AuthSlurmGeneratedRef()),
+			wantSlurmData: []byte("existing"),
+		},
+		{
+			name: "generated, referenced by controller",
+			fields: fields{
+				Client: fake.NewFakeClient(controller.DeepCopy()),
+			},
+			args: args{
+				accounting: omitted.DeepCopy(),
+			},
+			wantSlurmRef: new(omitted.AuthSlurmGeneratedRef()),
+			wantJwtRef:   new(omitted.AuthJwtGeneratedRef()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAccountingController(tt.fields.Client)
+			if err := r.syncGeneratedAuthKeys(context.TODO(), tt.args.accounting); err != nil {
+				t.Fatalf("AccountingReconciler.syncGeneratedAuthKeys() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantSlurmRef, tt.args.accounting.Status.SlurmKeyRef); diff != "" {
+				t.Errorf("Status.SlurmKeyRef (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantJwtRef, tt.args.accounting.Status.JwtKeyRef); diff != "" {
+				t.Errorf("Status.JwtKeyRef (-want,+got):\n%s", diff)
+			}
+			if tt.wantSlurmRef == nil || tt.wantSlurmRef.Name != omitted.AuthSlurmGeneratedRef().Name {
+				return
+			}
+			got, err := r.refResolver.GetSecretKeyRef(context.TODO(), *tt.wantSlurmRef, corev1.NamespaceDefault)
+			if err != nil {
+				t.Fatalf("GetSecretKeyRef() error = %v", err)
+			}
+			if tt.wantSlurmData != nil && !bytes.Equal(got, tt.wantSlurmData) {
+				t.Errorf("generated key = %q, want %q", got, tt.wantSlurmData)
+			}
+		})
+	}
+}
+
+func TestAccountingReconciler_syncAuthKeyRotations(t *testing.T) {
+	now := time.Now()
+	password := testutils.NewPasswordRef("password")
+	newAccounting := func(status *slinkyv1beta1.AuthKeyRotationStatus) *slinkyv1beta1.Accounting {
+		accounting := testutils.NewAccounting("slurm", corev1.SecretKeySelector{}, testutils.NewJwtKeyRef("jwtkey"), password)
+		accounting.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("slurmkey", "a", "b")
+		accounting.Status.SlurmKeyRotation = status
+		return accounting
+	}
+	graced := &slinkyv1beta1.AuthKeyRotationStatus{
+		ActiveKeyID:        "a",
+		KeyIDs:             []string{"a", "b"},
+		LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+	}
+	newStatefulSet := func(accounting *slinkyv1beta1.Accounting, annotation string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      accounting.Key().Name,
+				Namespace: accounting.Namespace,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							common.AnnotationAuthSlurmKeyHash: annotation,
+						},
+					},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				UpdatedReplicas:   1,
+				AvailableReplicas: 1,
+			},
+		}
+	}
+	jwksSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      newAccounting(nil).AuthSlurmKey().Name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			slinkyv1beta1.AuthSlurmJwksFile: []byte("jwks"),
+		},
+	}
+	tests := []struct {
+		name         string
+		accounting   *slinkyv1beta1.Accounting
+		objs         []client.Object
+		wantActiveID string
+	}{
+		{
+			name:         "Initial",
+			accounting:   newAccounting(nil),
+			wantActiveID: "b",
+		},
+		{
+			name:         "Rolled out",
+			accounting:   newAccounting(graced.DeepCopy()),
+			objs:         []client.Object{jwksSecret.DeepCopy(), newStatefulSet(newAccounting(nil), crypto.CheckSumFromMap(jwksSecret.Data))},
+			wantActiveID: "b",
+		},
+		{
+			name:         "Pending slurmdbd rollout",
+			accounting:   newAccounting(graced.DeepCopy()),
+			objs:         []client.Object{jwksSecret.DeepCopy(), newStatefulSet(newAccounting(nil), "stale")},
+			wantActiveID: "a",
+		},
+		{
+			name:       "Pending slurmctld keys",
+			accounting: newAccounting(graced.DeepCopy()),
+			objs: []client.Object{
+				testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurmkey"), testutils.NewJwtKeyRef("jwtkey"), newAccounting(nil)),
+			},
+			wantActiveID: "a",
+		},
+		{
+			name:       "Rolled out, shared by slurmctld",
+			accounting: newAccounting(graced.DeepCopy()),
+			objs: []client.Object{
+				jwksSecret.DeepCopy(),
+				newStatefulSet(newAccounting(nil), crypto.CheckSumFromMap(jwksSecret.Data)),
+				testutils.NewController("slurm", corev1.SecretKeySelector{}, testutils.NewJwtKeyRef("jwtkey"), newAccounting(nil)),
+			},
+			wantActiveID: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(append(tt.objs, tt.accounting.DeepCopy())...).
+				WithStatusSubresource(&slinkyv1beta1.Accounting{}).
+				Build()
+			r := newAccountingController(c)
+			if err := r.syncAuthKeyRotations(context.TODO(), tt.accounting, now); err != nil {
+				t.Fatalf("AccountingReconciler.syncAuthKeyRotations() error = %v", err)
+			}
+			stored := &slinkyv1beta1.Accounting{}
+			if err := c.Get(context.TODO(), client.ObjectKeyFromObject(tt.accounting), stored); err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status.SlurmKeyRotation == nil {
+				t.Fatal("Status.SlurmKeyRotation was not stored")
+			}
+			if got := stored.Status.SlurmKeyRotation.ActiveKeyID; got != tt.wantActiveID {
+				t.Errorf("Status.SlurmKeyRotation.ActiveKeyID = %v, want %v", got, tt.wantActiveID)
+			}
+		})
+	}
+}
+
 func BenchmarkAccountingReconciler_sync(b *testing.B) {
 	slurmKeyRef := testutils.NewSlurmKeyRef("slurmkey")
 	slurmKey := testutils.NewSlurmKeySecret(slurmKeyRef)
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewControllerEventHandler(reader client.Reader) *ControllerEventHandler {
+	return &ControllerEventHandler{
+		Reader:      reader,
+		refResolver: refresolver.New(reader),
+	}
+}
+
+var _ handler.EventHandler = &ControllerEventHandler{}
+
+// ControllerEventHandler enqueues the Accounting which the Controller
+// references, which may share its keys.
+type ControllerEventHandler struct {
+	client.Reader
+	refResolver *refresolver.RefResolver
+}
+
+func (e *ControllerEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ControllerEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldController, ok := evt.ObjectOld.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+	newController, ok := evt.ObjectNew.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+	// Only the reference and the keys concern the Accounting.
+	if apiequality.Semantic.DeepEqual(oldController.Spec.AccountingRef, newController.Spec.AccountingRef) &&
+		apiequality.Semantic.DeepEqual(oldController.AuthSlurmRef(), newController.AuthSlurmRef()) &&
+		apiequality.Semantic.DeepEqual(oldController.AuthJwtRef(), newController.AuthJwtRef()) {
+		return
+	}
+	e.enqueueRequest(ctx, oldController, q)
+	e.enqueueRequest(ctx, newController, q)
+}
+
+func (e *ControllerEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ControllerEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *ControllerEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	controller, ok := obj.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+
+	if controller.Spec.AccountingRef == nil {
+		return
+	}
+
+	accounting, err := e.refResolver.GetAccounting(ctx, *controller.Spec.AccountingRef, controller.Namespace)
+	if err != nil {
+		return
+	}
+
+	objectutils.EnqueueRequest(q, accounting)
+}
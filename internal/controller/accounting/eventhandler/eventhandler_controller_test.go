@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_ControllerEventHandler_Create(t *testing.T) {
+	accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), accounting),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "not referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "missing",
+			fields: fields{
+				Reader: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), accounting),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ControllerEventHandler_Update(t *testing.T) {
+	accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+	newController := func(mutate func(controller *slinkyv1beta1.Controller)) *slinkyv1beta1.Controller {
+		controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), accounting)
+		if mutate != nil {
+			mutate(controller)
+		}
+		return controller
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "no key change",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newController(nil),
+					ObjectNew: newController(func(controller *slinkyv1beta1.Controller) {
+						controller.Status.Primary = "slurm-controller-0"
+					}),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "generated key",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newController(func(controller *slinkyv1beta1.Controller) {
+						controller.Spec.SlurmKeyRef = corev1.SecretKeySelector{}
+					}),
+					ObjectNew: newController(func(controller *slinkyv1beta1.Controller) {
+						controller.Spec.SlurmKeyRef = corev1.SecretKeySelector{}
+						controller.Status.SlurmKeyRef = new(testutils.NewSlurmKeyRef("other"))
+					}),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ControllerEventHandler_Delete(t *testing.T) {
+	accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), accounting),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Delete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package eventhandler
 
 import (
 	"context"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -85,7 +86,8 @@ func (e *SecretEventHandler) enqueueRequest(
 		slurmKeyKey := accounting.AuthSlurmKey()
 		jwtKeyKey := accounting.AuthJwtKey()
 		if !refresolver.IsKeyMatch(secretKey, slurmKeyKey) &&
-			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) {
+			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) &&
+			!slices.Contains(accounting.AuthRotationKeys(), secretKey) {
 			continue
 		}
 		objectutils.EnqueueRequest(q, &accounting)
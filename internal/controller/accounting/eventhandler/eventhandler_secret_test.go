@@ -26,6 +26,9 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 	passwordRef := testutils.NewPasswordRef(name)
 	passwordSecret := testutils.NewPasswordSecret(passwordRef)
 	accounting := testutils.NewAccounting(name, slurmKeyRef, jwtKeyRef, passwordRef)
+	rotatedAccounting := testutils.NewAccounting("rotated", slurmKeyRef, jwtKeyRef, passwordRef)
+	rotatedAccounting.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("rotated", "key1", "key2")
+	rotatedKeySecret := testutils.NewSlurmKeySecret(rotatedAccounting.Spec.SlurmKeyRotation.Keys[1].SecretRef)
 	type fields struct {
 		Reader client.Reader
 	}
@@ -80,6 +83,26 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "rotated key",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					rotatedKeySecret,
+					controller,
+					passwordSecret,
+					accounting,
+					rotatedAccounting,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: rotatedKeySecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -7,20 +7,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/keyrotation"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmconf"
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // Sync implements control logic for synchronizing a Controller.
@@ -60,6 +71,28 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "AuthKeys",
+			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if err := r.syncGeneratedAuthKeys(ctx, controller); err != nil {
+					return err
+				}
+				if err := r.syncAuthKeyRotations(ctx, controller, time.Now()); err != nil {
+					return err
+				}
+				if controller.Spec.SlurmKeyRotation == nil {
+					return nil
+				}
+				object, err := r.builder.BuildControllerSlurmJwks(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Config",
 			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -73,6 +106,12 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				if err != nil {
 					return fmt.Errorf("failed to build: %w", err)
 				}
+				if !controller.Spec.External {
+					// Hold the last-known-good config, instead of crash-looping slurmctld.
+					if err := validateSlurmConf(ctx, controller, object); err != nil {
+						return err
+					}
+				}
 				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
 					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
 				}
@@ -155,6 +194,203 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 	return r.syncStatus(ctx, controller)
 }
 
+// syncGeneratedAuthKeys creates the Secrets of the keys which the Controller
+// omits, and records them in its status. The Secrets are only created, never
+// replaced, so the keys are kept. A Controller which references an Accounting
+// shares its keys instead, so slurmctld and slurmdbd authenticate each other.
+func (r *ControllerReconciler) syncGeneratedAuthKeys(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+	controller.Status.SlurmKeyRef = nil
+	controller.Status.JwtKeyRef = nil
+	if controller.Spec.External {
+		return nil
+	}
+	if !controller.IsAuthSlurmRefOmitted() && !controller.IsAuthJwtRefOmitted() {
+		return nil
+	}
+
+	var accounting *slinkyv1beta1.Accounting
+	if controller.Spec.AccountingRef != nil {
+		var err error
+		accounting, err = r.refResolver.GetAccounting(ctx, *controller.Spec.AccountingRef, controller.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get Accounting, whose keys are shared: %w", err)
+		}
+	}
+
+	// An external Accounting generates no keys, so only given keys are shared.
+	if controller.IsAuthSlurmRefOmitted() {
+		if accounting != nil && (!accounting.Spec.External || !accounting.IsAuthSlurmRefOmitted()) {
+			controller.Status.SlurmKeyRef = new(accounting.AuthSlurmRef())
+		} else {
+			object, err := r.builder.BuildControllerSlurmKey(controller)
+			if err != nil {
+				return fmt.Errorf("failed to build: %w", err)
+			}
+			if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, false); err != nil {
+				return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+			}
+			controller.Status.SlurmKeyRef = new(controller.AuthSlurmGeneratedRef())
+		}
+	}
+
+	if controller.IsAuthJwtRefOmitted() {
+		if accounting != nil && (!accounting.Spec.External || !accounting.IsAuthJwtRefOmitted()) {
+			controller.Status.JwtKeyRef = new(accounting.AuthJwtRef())
+		} else {
+			object, err := r.builder.BuildControllerJwtKey(controller)
+			if err != nil {
+				return fmt.Errorf("failed to build: %w", err)
+			}
+			if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, false); err != nil {
+				return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+			}
+			controller.Status.JwtKeyRef = new(controller.AuthJwtGeneratedRef())
+		}
+	}
+
+	return nil
+}
+
+// syncAuthKeyRotations sets the status of the rotated keys of the Controller,
+// and requeues it until the active key of the spec may sign. The status is
+// stored at once, as the grace period is timed from it.
+func (r *ControllerReconciler) syncAuthKeyRotations(ctx context.Context, controller *slinkyv1beta1.Controller, now time.Time) error {
+	rolledOut, err := r.isSlurmKeyRolledOut(ctx, controller)
+	if err != nil {
+		return fmt.Errorf("failed to get Slurm key rollout: %w", err)
+	}
+	slurmKeyRotation, requeue := keyrotation.SyncStatus(
+		controller.Spec.SlurmKeyRotation, controller.Status.SlurmKeyRotation, rolledOut, now)
+
+	if !apiequality.Semantic.DeepEqual(controller.Status.SlurmKeyRotation, slurmKeyRotation) {
+		mutateFn := func(toPatch *slinkyv1beta1.Controller) error {
+			toPatch.Status.SlurmKeyRotation = slurmKeyRotation
+			return nil
+		}
+		if err := objectutils.StatusPatchObject(r.Client, ctx, controller.DeepCopy(), mutateFn); err != nil {
+			return fmt.Errorf("failed to patch Controller (%s) status: %w", klog.KObj(controller), err)
+		}
+	}
+	controller.Status.SlurmKeyRotation = slurmKeyRotation
+
+	// The duration store keeps the greater duration, so the requeue is capped
+	// to not delay the refresh of the Slurm status.
+	requeue = min(requeue, SlurmStatusRefreshInterval)
+	if requeue > 0 {
+		durationStore.Push(client.ObjectKeyFromObject(controller).String(), requeue)
+	}
+	return nil
+}
+
+// isSlurmKeyRolledOut returns true if every Slurm pod of the Controller, and
+// slurmdbd, has rolled out the rotated Slurm keys of its status.
+func (r *ControllerReconciler) isSlurmKeyRolledOut(ctx context.Context, controller *slinkyv1beta1.Controller) (bool, error) {
+	if controller.Spec.SlurmKeyRotation == nil || controller.Status.SlurmKeyRotation == nil {
+		return true, nil
+	}
+
+	if !controller.Spec.External {
+		rolledOut, err := keyrotation.IsStatefulSetRolledOut(ctx, r.Client,
+			controller.Key(), controller.AuthSlurmKey(), common.AnnotationAuthSlurmKeyHash)
+		if err != nil || !rolledOut {
+			return false, err
+		}
+	}
+
+	if controller.Spec.AccountingRef != nil {
+		accounting, err := r.refResolver.GetAccounting(ctx, *controller.Spec.AccountingRef, controller.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if err == nil && !accounting.Spec.External {
+			if accounting.Status.SlurmKeyRotation == nil ||
+				!slices.Contains(accounting.Status.SlurmKeyRotation.KeyIDs, controller.Spec.SlurmKeyRotation.ActiveKeyID) {
+				return false, nil
+			}
+			rolledOut, err := keyrotation.IsStatefulSetRolledOut(ctx, r.Client,
+				accounting.Key(), accounting.AuthSlurmKey(), common.AnnotationAuthSlurmKeyHash)
+			if err != nil || !rolledOut {
+				return false, err
+			}
+		}
+	}
+
+	hash := keyrotation.Hash(controller.Status.SlurmKeyRotation)
+	restapiList, err := r.refResolver.GetRestapisForController(ctx, controller)
+	if err != nil {
+		return false, err
+	}
+	for _, restapi := range restapiList.Items {
+		rolledOut, err := keyrotation.IsDeploymentRolledOut(ctx, r.Client,
+			restapi.Key(), common.AnnotationAuthSlurmKeyHash, hash)
+		if err != nil || !rolledOut {
+			return false, err
+		}
+	}
+	loginsetList, err := r.refResolver.GetLoginSetsForController(ctx, controller)
+	if err != nil {
+		return false, err
+	}
+	for _, loginset := range loginsetList.Items {
+		rolledOut, err := keyrotation.IsDeploymentRolledOut(ctx, r.Client,
+			loginset.Key(), common.AnnotationAuthSlurmKeyHash, hash)
+		if err != nil || !rolledOut {
+			return false, err
+		}
+	}
+	nodesetList, err := r.refResolver.GetNodeSetsForController(ctx, controller)
+	if err != nil {
+		return false, err
+	}
+	for _, nodeset := range nodesetList.Items {
+		podList := &corev1.PodList{}
+		opts := []client.ListOption{
+			client.InNamespace(nodeset.Namespace),
+			client.MatchingLabels(labels.NewBuilder().WithWorkerSelectorLabels(&nodeset).Build()),
+		}
+		if err := r.List(ctx, podList, opts...); err != nil {
+			return false, err
+		}
+		for _, pod := range podList.Items {
+			if pod.DeletionTimestamp.IsZero() && pod.Annotations[common.AnnotationAuthSlurmKeyHash] != hash {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// validateSlurmConf validates the rendered slurm.conf of the config, and sets
+// the ConfigInvalid condition of the Controller accordingly. Unknown
+// parameters are only logged, as Slurm may know them.
+func validateSlurmConf(ctx context.Context, controller *slinkyv1beta1.Controller, config *corev1.ConfigMap) error {
+	logger := log.FromContext(ctx)
+
+	condition := metav1.Condition{
+		Type:               conditions.ControllerConditionConfigInvalid,
+		Status:             metav1.ConditionFalse,
+		Reason:             "ValidationSucceeded",
+		Message:            "slurm.conf is valid",
+		ObservedGeneration: controller.Generation,
+	}
+	warns, errs := slurmconf.Validate(config.Data[builder.SlurmConfFile])
+	for _, warn := range warns {
+		logger.Info("slurm.conf warning", "controller", klog.KObj(controller), "warning", warn)
+	}
+	if len(errs) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ValidationFailed"
+		condition.Message = utilerrors.NewAggregate(errs).Error()
+	}
+	meta.SetStatusCondition(&controller.Status.Conditions, condition)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s, keeping the last applied config: %w",
+			builder.SlurmConfFile, utilerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
 func isServiceMonitorUnavailable(err error) bool {
 	if meta.IsNoMatchError(err) {
 		return true
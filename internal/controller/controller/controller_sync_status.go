@@ -6,36 +6,72 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/rolloututils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurminfo"
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
+// SlurmStatusRefreshInterval is how often the Slurm status is refreshed
+// (e.g. slurmctld ping).
+const SlurmStatusRefreshInterval = 30 * time.Second
+
 // syncStatus handles determining and updating the status.
 func (r *ControllerReconciler) syncStatus(
 	ctx context.Context,
 	controller *slinkyv1beta1.Controller,
 ) error {
-	logger := log.FromContext(ctx)
-
 	newStatus := slinkyv1beta1.ControllerStatus{
 		Conditions: []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, controller.Status.Conditions...)
+	newStatus.SlurmKeyRotation = controller.Status.SlurmKeyRotation
+	newStatus.SlurmKeyRef = controller.Status.SlurmKeyRef
+	newStatus.JwtKeyRef = controller.Status.JwtKeyRef
 
-	if apiequality.Semantic.DeepEqual(controller.Status, newStatus) {
-		logger.V(2).Info("Controller Status has not changed, skipping status update",
-			"controller", klog.KObj(controller), "status", controller.Status)
-		return nil
+	newStatus.Primary = controller.Status.Primary
+
+	if !controller.Spec.External {
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, controller.Key(), sts); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get StatefulSet: %w", err)
+			}
+			sts = nil
+		}
+		rolloututils.SetConditions(&newStatus.Conditions, controller.Generation, rolloututils.FromStatefulSet(sts))
 	}
 
+	configHash, err := r.getConfigHash(ctx, controller)
+	if err != nil {
+		return fmt.Errorf("failed to get config hash: %w", err)
+	}
+	newStatus.ConfigHash = configHash
+
+	pings := r.syncSlurmStatus(ctx, controller, &newStatus)
+	if !controller.Spec.External && pings != nil {
+		newStatus.Primary = getPrimary(controller, pings)
+	}
+	setFailoverCondition(controller, &newStatus)
+	setReadyCondition(controller, &newStatus)
+
 	if err := r.updateStatus(ctx, controller, &newStatus); err != nil {
 		return fmt.Errorf("error updating Controller(%s) status: %w",
 			klog.KObj(controller), err)
@@ -44,6 +80,151 @@ func (r *ControllerReconciler) syncStatus(
 	return nil
 }
 
+// getPrimary returns the name of the slurmctld pod which is in control, as
+// reported by the slurmrestd ping, or empty if there is none.
+func getPrimary(
+	controller *slinkyv1beta1.Controller,
+	pings []slurminfo.ControllerPing,
+) string {
+	names := []string{controller.PrimaryName()}
+	if controller.IsHighAvailability() {
+		names = append(names, controller.BackupName())
+	}
+	if primary := slurminfo.GetPrimary(pings); slices.Contains(names, primary) {
+		return primary
+	}
+	return ""
+}
+
+// setFailoverCondition sets the Failover condition, which is true while the
+// backup slurmctld is the primary. It is unchanged while neither is ready.
+func setFailoverCondition(
+	controller *slinkyv1beta1.Controller,
+	newStatus *slinkyv1beta1.ControllerStatus,
+) {
+	if !controller.IsHighAvailability() {
+		meta.RemoveStatusCondition(&newStatus.Conditions, conditions.ControllerConditionFailover)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               conditions.ControllerConditionFailover,
+		ObservedGeneration: controller.Generation,
+	}
+	switch newStatus.Primary {
+	case controller.PrimaryName():
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PrimaryActive"
+		condition.Message = fmt.Sprintf("The primary slurmctld (%s) is in control", newStatus.Primary)
+	case controller.BackupName():
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "BackupActive"
+		condition.Message = fmt.Sprintf("The backup slurmctld (%s) has taken over from the primary slurmctld (%s)",
+			newStatus.Primary, controller.PrimaryName())
+	default:
+		return
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+}
+
+// getConfigHash returns the checksum of the applied Slurm configuration. It is
+// held at the last valid configuration, see validateSlurmConf.
+func (r *ControllerReconciler) getConfigHash(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) (string, error) {
+	config := &corev1.ConfigMap{}
+	if err := r.Get(ctx, controller.ConfigKey(), config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return crypto.CheckSumFromMap(config.Data), nil
+}
+
+// syncSlurmStatus sets the SlurmctldResponding condition and the Slurm version
+// from slurmrestd, and requeues the Controller to refresh them. It returns the
+// slurmctld pings, or nil if slurmctld could not be pinged.
+func (r *ControllerReconciler) syncSlurmStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	newStatus *slinkyv1beta1.ControllerStatus,
+) []slurminfo.ControllerPing {
+	logger := log.FromContext(ctx)
+
+	condition := metav1.Condition{
+		Type:               conditions.ControllerConditionSlurmctldResponding,
+		ObservedGeneration: controller.Generation,
+	}
+	defer func() {
+		meta.SetStatusCondition(&newStatus.Conditions, condition)
+	}()
+
+	key := client.ObjectKeyFromObject(controller)
+	slurmClient := r.ClientMap.Get(key)
+	if slurmClient == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "ClientNotReady"
+		condition.Message = "There is no Slurm client for the Controller (e.g. no RestApi is ready)"
+		return nil
+	}
+	durationStore.Push(key.String(), SlurmStatusRefreshInterval)
+
+	pings, err := slurminfo.PingControllers(ctx, slurmClient)
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "PingFailed"
+		condition.Message = fmt.Sprintf("Failed to ping slurmctld: %v", err)
+	case slurminfo.IsResponding(pings):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Responding"
+		condition.Message = "slurmctld is responding"
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NotResponding"
+		condition.Message = "slurmctld is not responding"
+	}
+
+	versions, err := slurminfo.GetVersions(ctx, slurmClient)
+	if err != nil {
+		logger.Error(err, "failed to get Slurm version", "controller", klog.KObj(controller))
+		return pings
+	}
+	if len(versions) > 0 {
+		newStatus.SlurmVersion = strings.Join(versions, ",")
+	}
+	return pings
+}
+
+// setReadyCondition amends the Ready condition with the SlurmctldResponding
+// condition. An external slurmctld has no rollout, so is only ready while it
+// is responding.
+func setReadyCondition(
+	controller *slinkyv1beta1.Controller,
+	newStatus *slinkyv1beta1.ControllerStatus,
+) {
+	responding := meta.FindStatusCondition(newStatus.Conditions, conditions.ControllerConditionSlurmctldResponding)
+	if responding == nil {
+		return
+	}
+	switch {
+	case controller.Spec.External:
+	case responding.Status == metav1.ConditionFalse &&
+		meta.IsStatusConditionTrue(newStatus.Conditions, conditions.ConditionReady):
+	default:
+		return
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+		Type:               conditions.ConditionReady,
+		Status:             responding.Status,
+		Reason:             "Slurmctld" + responding.Reason,
+		Message:            responding.Message,
+		ObservedGeneration: controller.Generation,
+	})
+}
+
 func (r *ControllerReconciler) updateStatus(
 	ctx context.Context,
 	controller *slinkyv1beta1.Controller,
@@ -66,6 +247,13 @@ func (r *ControllerReconciler) updateStatus(
 			}
 			return err
 		}
+		// The status of the object may have been changed in memory by the sync,
+		// so it is compared with the stored one.
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("Controller Status has not changed, skipping status update",
+				"controller", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
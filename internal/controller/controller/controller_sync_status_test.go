@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurminfo"
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_getPrimary(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			HighAvailability: slinkyv1beta1.ControllerHighAvailability{
+				Enabled: true,
+			},
+		},
+	}
+	tests := []struct {
+		name  string
+		pings []slurminfo.ControllerPing
+		want  string
+	}{
+		{
+			name: "no pings",
+			want: "",
+		},
+		{
+			name: "primary in control",
+			pings: []slurminfo.ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: true, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Responding: true, Mode: "backup1"},
+			},
+			want: "slurm-controller-0",
+		},
+		{
+			name: "backup took over",
+			pings: []slurminfo.ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Responding: true, Mode: "backup1"},
+			},
+			want: "slurm-controller-1",
+		},
+		{
+			name: "none responding",
+			pings: []slurminfo.ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Responding: false, Mode: "backup1"},
+			},
+			want: "",
+		},
+		{
+			name: "unknown host",
+			pings: []slurminfo.ControllerPing{
+				{Hostname: "other-0", Responding: true, Primary: true, Mode: "primary"},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPrimary(controller, tt.pings); got != tt.want {
+				t.Errorf("getPrimary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setFailoverCondition(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			HighAvailability: slinkyv1beta1.ControllerHighAvailability{
+				Enabled: true,
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		primary    string
+		conditions []metav1.Condition
+		want       metav1.ConditionStatus
+	}{
+		{
+			name:       "primary",
+			controller: controller,
+			primary:    "slurm-controller-0",
+			want:       metav1.ConditionFalse,
+		},
+		{
+			name:       "backup",
+			controller: controller,
+			primary:    "slurm-controller-1",
+			want:       metav1.ConditionTrue,
+		},
+		{
+			name:       "none, unchanged",
+			controller: controller,
+			primary:    "",
+			conditions: []metav1.Condition{
+				{Type: conditions.ControllerConditionFailover, Status: metav1.ConditionTrue},
+			},
+			want: metav1.ConditionTrue,
+		},
+		{
+			name: "not high availability",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+			},
+			primary: "slurm-controller-0",
+			conditions: []metav1.Condition{
+				{Type: conditions.ControllerConditionFailover, Status: metav1.ConditionTrue},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newStatus := &slinkyv1beta1.ControllerStatus{
+				Conditions: tt.conditions,
+				Primary:    tt.primary,
+			}
+			setFailoverCondition(tt.controller, newStatus)
+			var got metav1.ConditionStatus
+			if cond := meta.FindStatusCondition(newStatus.Conditions, conditions.ControllerConditionFailover); cond != nil {
+				got = cond.Status
+			}
+			if got != tt.want {
+				t.Errorf("setFailoverCondition() status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setReadyCondition(t *testing.T) {
+	newConditions := func(ready, responding metav1.ConditionStatus) []metav1.Condition {
+		conds := []metav1.Condition{}
+		if ready != "" {
+			conds = append(conds, metav1.Condition{Type: conditions.ConditionReady, Status: ready, Reason: "RolloutComplete"})
+		}
+		if responding != "" {
+			conds = append(conds, metav1.Condition{Type: conditions.ControllerConditionSlurmctldResponding, Status: responding, Reason: "Test"})
+		}
+		return conds
+	}
+	tests := []struct {
+		name       string
+		external   bool
+		conditions []metav1.Condition
+		want       metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "ready and responding",
+			conditions: newConditions(metav1.ConditionTrue, metav1.ConditionTrue),
+			want:       metav1.ConditionTrue,
+			wantReason: "RolloutComplete",
+		},
+		{
+			name:       "ready and not responding",
+			conditions: newConditions(metav1.ConditionTrue, metav1.ConditionFalse),
+			want:       metav1.ConditionFalse,
+			wantReason: "SlurmctldTest",
+		},
+		{
+			name:       "ready and no client",
+			conditions: newConditions(metav1.ConditionTrue, metav1.ConditionUnknown),
+			want:       metav1.ConditionTrue,
+			wantReason: "RolloutComplete",
+		},
+		{
+			name:       "not ready and not responding",
+			conditions: newConditions(metav1.ConditionFalse, metav1.ConditionFalse),
+			want:       metav1.ConditionFalse,
+			wantReason: "RolloutComplete",
+		},
+		{
+			name:       "external and responding",
+			external:   true,
+			conditions: newConditions("", metav1.ConditionTrue),
+			want:       metav1.ConditionTrue,
+			wantReason: "SlurmctldTest",
+		},
+		{
+			name:       "external and no client",
+			external:   true,
+			conditions: newConditions("", metav1.ConditionUnknown),
+			want:       metav1.ConditionUnknown,
+			wantReason: "SlurmctldTest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					External: tt.external,
+				},
+			}
+			newStatus := &slinkyv1beta1.ControllerStatus{
+				Conditions: tt.conditions,
+			}
+			setReadyCondition(controller, newStatus)
+			cond := meta.FindStatusCondition(newStatus.Conditions, conditions.ConditionReady)
+			if cond == nil {
+				t.Fatalf("setReadyCondition() = %v, want Ready", newStatus.Conditions)
+			}
+			if cond.Status != tt.want || cond.Reason != tt.wantReason {
+				t.Errorf("setReadyCondition() = %v (%v), want %v (%v)", cond.Status, cond.Reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
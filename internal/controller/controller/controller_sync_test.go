@@ -7,7 +7,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -21,6 +23,8 @@ import (
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -89,6 +93,32 @@ func TestControllerReconciler_sync(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid slurm.conf",
+			fields: fields{
+				Client: fake.NewFakeClient(&slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						ExtraConf: "PartitionName=debug Nodes=missing",
+					},
+				}),
+				ClientMap: func() *clientmap.ClientMap {
+					sclient := clientfake.NewClientBuilder().WithInterceptorFuncs(sinterceptor.Funcs{}).Build()
+					return newClientMap(controller.Name, sclient)
+				}(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name: "slurm",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -100,6 +130,148 @@ func TestControllerReconciler_sync(t *testing.T) {
 	}
 }
 
+func Test_validateSlurmConf(t *testing.T) {
+	tests := []struct {
+		name       string
+		slurmConf  string
+		wantErr    bool
+		wantStatus metav1.ConditionStatus
+	}{
+		{
+			name:       "valid",
+			slurmConf:  "ClusterName=slurm\nNodeSet=cpu Feature=cpu\nPartitionName=cpu Nodes=cpu",
+			wantErr:    false,
+			wantStatus: metav1.ConditionFalse,
+		},
+		{
+			name:       "unknown parameter",
+			slurmConf:  "ClusterName=slurm\nSchedulerNewOption=1",
+			wantErr:    false,
+			wantStatus: metav1.ConditionFalse,
+		},
+		{
+			name:       "invalid",
+			slurmConf:  "ClusterName=slurm\nPartitionName=cpu Nodes=cpu",
+			wantErr:    true,
+			wantStatus: metav1.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+			}
+			config := &corev1.ConfigMap{
+				Data: map[string]string{
+					builder.SlurmConfFile: tt.slurmConf,
+				},
+			}
+			if err := validateSlurmConf(context.TODO(), controller, config); (err != nil) != tt.wantErr {
+				t.Errorf("validateSlurmConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			cond := meta.FindStatusCondition(controller.Status.Conditions, conditions.ControllerConditionConfigInvalid)
+			if cond == nil || cond.Status != tt.wantStatus {
+				t.Errorf("validateSlurmConf() condition = %v, want status %v", cond, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestControllerReconciler_syncGeneratedAuthKeys(t *testing.T) {
+	newController := func(slurmKeyRef corev1.SecretKeySelector, jwtKeyRef *corev1.SecretKeySelector) *slinkyv1beta1.Controller {
+		controller := testutils.NewController("slurm", slurmKeyRef, corev1.SecretKeySelector{}, nil)
+		controller.Spec.JwtKeyRef = jwtKeyRef
+		return controller
+	}
+	omitted := newController(corev1.SecretKeySelector{}, nil)
+	external := omitted.DeepCopy()
+	external.Spec.External = true
+	accounting := testutils.NewAccounting("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, testutils.NewPasswordRef("slurm"))
+	accounting.Spec.JwtKeyRef = nil
+	accounting.Status.SlurmKeyRef = new(accounting.AuthSlurmGeneratedRef())
+	accounting.Status.JwtKeyRef = new(accounting.AuthJwtGeneratedRef())
+	sharing := omitted.DeepCopy()
+	sharing.Spec.AccountingRef = &corev1.LocalObjectReference{Name: accounting.Name}
+	externalAccounting := accounting.DeepCopy()
+	externalAccounting.Spec.External = true
+	tests := []struct {
+		name         string
+		controller   *slinkyv1beta1.Controller
+		objs         []client.Object
+		wantSlurmRef *corev1.SecretKeySelector
+		wantJwtRef   *corev1.SecretKeySelector
+		wantErr      bool
+	}{
+		{
+			name:       "given",
+			controller: newController(testutils.NewSlurmKeyRef("slurm"), new(testutils.NewJwtKeyRef("slurm"))),
+		},
+		{
+			name:         "generated",
+			controller:   omitted.DeepCopy(),
+			wantSlurmRef: new(omitted.AuthSlurmGeneratedRef()),
+			wantJwtRef:   new(omitted.AuthJwtGeneratedRef()),
+		},
+		{
+			name:       "generated jwt",
+			controller: newController(testutils.NewSlurmKeyRef("slurm"), nil),
+			wantJwtRef: new(omitted.AuthJwtGeneratedRef()),
+		},
+		{
+			name:       "external",
+			controller: external,
+		},
+		{
+			name:       "shared by accounting",
+			controller: sharing.DeepCopy(),
+			objs: []client.Object{
+				accounting.DeepCopy(),
+				testutils.NewSlurmKeySecret(accounting.AuthSlurmGeneratedRef()),
+				testutils.NewJwtKeySecret(accounting.AuthJwtGeneratedRef()),
+			},
+			wantSlurmRef: new(accounting.AuthSlurmGeneratedRef()),
+			wantJwtRef:   new(accounting.AuthJwtGeneratedRef()),
+		},
+		{
+			name:         "generated, external accounting",
+			controller:   sharing.DeepCopy(),
+			objs:         []client.Object{externalAccounting.DeepCopy()},
+			wantSlurmRef: new(omitted.AuthSlurmGeneratedRef()),
+			wantJwtRef:   new(omitted.AuthJwtGeneratedRef()),
+		},
+		{
+			name:       "accounting not found",
+			controller: sharing.DeepCopy(),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewFakeClient(tt.objs...)
+			r := newControllerController(kubeClient, clientmap.NewClientMap())
+			if err := r.syncGeneratedAuthKeys(context.TODO(), tt.controller); (err != nil) != tt.wantErr {
+				t.Fatalf("ControllerReconciler.syncGeneratedAuthKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantSlurmRef, tt.controller.Status.SlurmKeyRef); diff != "" {
+				t.Errorf("Status.SlurmKeyRef (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantJwtRef, tt.controller.Status.JwtKeyRef); diff != "" {
+				t.Errorf("Status.JwtKeyRef (-want,+got):\n%s", diff)
+			}
+			for _, ref := range []*corev1.SecretKeySelector{tt.wantSlurmRef, tt.wantJwtRef} {
+				if ref == nil {
+					continue
+				}
+				if _, err := r.refResolver.GetSecretKeyRef(context.TODO(), *ref, corev1.NamespaceDefault); err != nil {
+					t.Errorf("GetSecretKeyRef(%v) error = %v", ref.Name, err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkControllerReconciler_sync(b *testing.B) {
 	benchmarks := []struct {
 		name    string
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topology"
+)
+
+func NewNodeEventHandler(reader client.Reader) *NodeEventHandler {
+	return &NodeEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &NodeEventHandler{}
+
+// NodeEventHandler enqueues the Controllers whose topology is derived from the
+// labels of the Kubernetes node.
+type NodeEventHandler struct {
+	client.Reader
+}
+
+func (e *NodeEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, nil, evt.Object, q)
+}
+
+func (e *NodeEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Skip the frequent status updates without listing Controllers.
+	if maps.Equal(evt.ObjectOld.GetLabels(), evt.ObjectNew.GetLabels()) {
+		return
+	}
+	e.enqueueRequest(ctx, evt.ObjectOld, evt.ObjectNew, q)
+}
+
+func (e *NodeEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, nil, evt.Object, q)
+}
+
+func (e *NodeEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// enqueueRequest enqueues the Controllers with a topology label of the node,
+// whose value changed when oldObj is not nil.
+func (e *NodeEventHandler) enqueueRequest(
+	ctx context.Context,
+	oldObj, obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	oldNode, _ := oldObj.(*corev1.Node)
+
+	controllerList := &slinkyv1beta1.ControllerList{}
+	if err := e.List(ctx, controllerList); err != nil {
+		logger.Error(err, "failed to list controller CRs")
+	}
+
+	for _, controller := range controllerList.Items {
+		if !topologyLabelsChanged(oldNode, node, controller.Spec.Topology) {
+			continue
+		}
+		objectutils.EnqueueRequest(q, &controller)
+	}
+}
+
+// topologyLabelsChanged returns true if the node has a label of the
+// topologies, or a value of one changed since oldNode.
+func topologyLabelsChanged(oldNode, node *corev1.Node, topologies []slinkyv1beta1.ControllerTopology) bool {
+	for _, key := range topology.LabelKeys(topologies) {
+		value, ok := node.Labels[key]
+		if oldNode == nil {
+			if ok {
+				return true
+			}
+			continue
+		}
+		oldValue, oldOk := oldNode.Labels[key]
+		if ok != oldOk || value != oldValue {
+			return true
+		}
+	}
+	return false
+}
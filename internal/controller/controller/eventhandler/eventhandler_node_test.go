@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newTopologyController(name string) *slinkyv1beta1.Controller {
+	controller := testutils.NewController(name, testutils.NewSlurmKeyRef("foo"), testutils.NewJwtKeyRef("foo"), nil)
+	controller.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+		{
+			Name: "topo-block",
+			Block: &slinkyv1beta1.ControllerTopologyBlock{
+				LabelKey: corev1.LabelTopologyZone,
+			},
+		},
+	}
+	return controller
+}
+
+func newTopologyNode(name, zone string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{},
+		},
+	}
+	if zone != "" {
+		node.Labels[corev1.LabelTopologyZone] = zone
+	}
+	return node
+}
+
+func Test_NodeEventHandler_Create(t *testing.T) {
+	controller := newTopologyController("slurm")
+	controllerNoTopology := testutils.NewController("other", testutils.NewSlurmKeyRef("bar"), testutils.NewJwtKeyRef("bar"), nil)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "topology label",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, controllerNoTopology),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newTopologyNode("node0", "a"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "no topology label",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, controllerNoTopology),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newTopologyNode("node0", ""),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NodeEventHandler_Delete(t *testing.T) {
+	controller := newTopologyController("slurm")
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "topology label",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: newTopologyNode("node0", "a"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeEventHandler.Delete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NodeEventHandler_Generic(t *testing.T) {
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.GenericEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				Reader: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.GenericEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Generic(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeEventHandler.Generic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NodeEventHandler_Update(t *testing.T) {
+	controller := newTopologyController("slurm")
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "topology label changed",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newTopologyNode("node0", "a"),
+					ObjectNew: newTopologyNode("node0", "b"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "topology label removed",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newTopologyNode("node0", "a"),
+					ObjectNew: newTopologyNode("node0", ""),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "other label changed",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newTopologyNode("node0", "a"),
+					ObjectNew: func() *corev1.Node {
+						node := newTopologyNode("node0", "a")
+						node.Labels["foo"] = "bar"
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "labels unchanged",
+			fields: fields{
+				Reader: fake.NewClientBuilder().
+					WithObjects(controller).
+					WithInterceptorFuncs(interceptor.Funcs{
+						List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+							t.Errorf("NodeEventHandler.Update() listed %T", list)
+							return client.List(ctx, list, opts...)
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newTopologyNode("node0", "a"),
+					ObjectNew: func() *corev1.Node {
+						node := newTopologyNode("node0", "a")
+						node.Status.Phase = corev1.NodeRunning
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
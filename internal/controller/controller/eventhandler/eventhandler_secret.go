@@ -5,6 +5,7 @@ package eventhandler
 
 import (
 	"context"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -85,7 +86,8 @@ func (e *SecretEventHandler) enqueueRequest(
 		slurmKeyKey := controller.AuthSlurmKey()
 		jwtKeyKey := controller.AuthJwtKey()
 		if !refresolver.IsKeyMatch(secretKey, slurmKeyKey) &&
-			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) {
+			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) &&
+			!slices.Contains(controller.AuthRotationKeys(), secretKey) {
 			continue
 		}
 		objectutils.EnqueueRequest(q, &controller)
@@ -23,6 +23,9 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 	jwtKeySecret := testutils.NewJwtKeySecret(jwtKeyRef)
 	controller := testutils.NewController("slurm", slurmKeyRef, jwtKeyRef, nil)
 	nodeset := testutils.NewNodeset("slurm", controller, 2)
+	rotatedController := testutils.NewController("rotated", slurmKeyRef, jwtKeyRef, nil)
+	rotatedController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("rotated", "key1", "key2")
+	rotatedKeySecret := testutils.NewSlurmKeySecret(rotatedController.Spec.SlurmKeyRotation.Keys[1].SecretRef)
 	type fields struct {
 		Reader client.Reader
 	}
@@ -75,6 +78,24 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "rotated key",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					rotatedKeySecret,
+					controller,
+					rotatedController,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: rotatedKeySecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/rolloututils"
 )
 
 // syncStatus handles determining and updating the status.
@@ -26,8 +27,6 @@ func (r *LoginSetReconciler) syncStatus(
 	ctx context.Context,
 	loginset *slinkyv1beta1.LoginSet,
 ) error {
-	logger := log.FromContext(ctx)
-
 	selectorLabels := labels.NewBuilder().WithLoginSelectorLabels(loginset).Build()
 	selector := k8slabels.SelectorFromSet(k8slabels.Set(selectorLabels))
 
@@ -42,12 +41,7 @@ func (r *LoginSetReconciler) syncStatus(
 		Conditions: []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, loginset.Status.Conditions...)
-
-	if apiequality.Semantic.DeepEqual(loginset.Status, newStatus) {
-		logger.V(2).Info("LoginSet Status has not changed, skipping status update",
-			"loginset", klog.KObj(loginset), "status", loginset.Status)
-		return nil
-	}
+	rolloututils.SetConditions(&newStatus.Conditions, loginset.Generation, replicaStatus.Rollout)
 
 	if err := r.updateStatus(ctx, loginset, &newStatus); err != nil {
 		return fmt.Errorf("error updating LoginSet(%s) status: %w",
@@ -59,6 +53,7 @@ func (r *LoginSetReconciler) syncStatus(
 
 type replicaStatus struct {
 	Replicas int32
+	Rollout  *rolloututils.Rollout
 }
 
 // calculateReplicaStatus will calculate the status of the given pods.
@@ -74,6 +69,7 @@ func (r *LoginSetReconciler) calculateReplicaStatus(
 
 	status := replicaStatus{
 		Replicas: deployment.Status.Replicas,
+		Rollout:  rolloututils.FromDeployment(deployment),
 	}
 
 	return status, nil
@@ -101,6 +97,11 @@ func (r *LoginSetReconciler) updateStatus(
 			}
 			return err
 		}
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("LoginSet Status has not changed, skipping status update",
+				"loginset", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
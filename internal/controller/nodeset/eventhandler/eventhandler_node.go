@@ -76,14 +76,33 @@ func (h *NodeEventHandler) Update(
 		return
 	}
 
-	// Detect node cordoning/uncordoning or metadata changed.
+	// Detect node cordoning/uncordoning, metadata changed, or termination signals (taints, conditions) changed.
 	if oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
 		!apiequality.Semantic.DeepEqual(oldNode.Annotations, newNode.Annotations) ||
-		!apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) {
+		!apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!apiequality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+		nodeConditionsChanged(oldNode, newNode) {
 		h.enqueueNodeSetsForNode(ctx, newNode, q)
 	}
 }
 
+// nodeConditionsChanged returns true if the status of any node condition has changed, ignoring heartbeats.
+func nodeConditionsChanged(oldNode, newNode *corev1.Node) bool {
+	if len(oldNode.Status.Conditions) != len(newNode.Status.Conditions) {
+		return true
+	}
+	oldStatus := make(map[corev1.NodeConditionType]corev1.ConditionStatus, len(oldNode.Status.Conditions))
+	for _, cond := range oldNode.Status.Conditions {
+		oldStatus[cond.Type] = cond.Status
+	}
+	for _, cond := range newNode.Status.Conditions {
+		if status, ok := oldStatus[cond.Type]; !ok || status != cond.Status {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *NodeEventHandler) enqueueNodeSetsForNode(
 	ctx context.Context,
 	node *corev1.Node,
@@ -191,6 +191,80 @@ func Test_NodeEventHandler_Update(t *testing.T) {
 			},
 			want: 1, // Should enqueue 1 NodeSet for reconciliation
 		},
+		{
+			name: "Node tainted - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(cl, nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode("test-node", false),
+					ObjectNew: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/spot-termination", Effect: corev1.TaintEffectNoSchedule}}
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Node condition changed - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(cl, nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Status.Conditions = []corev1.NodeCondition{{Type: "TerminationNotice", Status: corev1.ConditionFalse}}
+						return node
+					}(),
+					ObjectNew: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Status.Conditions = []corev1.NodeCondition{{Type: "TerminationNotice", Status: corev1.ConditionTrue}}
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Node condition heartbeat - should not enqueue",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(cl, nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+						return node
+					}(),
+					ObjectNew: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.Now()}}
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
 		{
 			name: "No cordon change - should not enqueue",
 			fields: fields{
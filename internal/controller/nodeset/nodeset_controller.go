@@ -38,6 +38,10 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// PodEvictionTimeout is the time after the last denied eviction of a pod, after which the eviction is considered
+	// abandoned (e.g. `kubectl drain` was aborted), and the pod is uncordoned.
+	PodEvictionTimeout = 2 * time.Minute
 )
 
 // Reasons for NodeSet events
@@ -64,10 +68,42 @@ const (
 	DefunctSlurmNodePrunedReason = "DefunctSlurmNodePruned"
 	// RollingUpdateReason is added to an event when pods are being replaced during a rolling update.
 	RollingUpdateReason = "RollingUpdate"
+	// RollingUpdateCanaryReason is added to an event when the canary stage of a rolling update changes.
+	RollingUpdateCanaryReason = "RollingUpdateCanary"
+	// RollingUpdateCanaryFailedReason is added to an event when a canary pod fails in Slurm during its soak.
+	RollingUpdateCanaryFailedReason = "RollingUpdateCanaryFailed"
+	// RollbackReason is added to an event when the NodeSet is reverted to a previous revision.
+	RollbackReason = "Rollback"
+	// RollbackFailedReason is added to an event when the NodeSet cannot be reverted to a previous revision.
+	RollbackFailedReason = "RollbackFailed"
+	// AutoscalingReason is added to an event when the autoscaler changes the desired replica count.
+	AutoscalingReason = "Autoscaling"
+	// PowerSaveResumeReason is added to an event when pods are being created for Slurm nodes resumed by slurmctld.
+	PowerSaveResumeReason = "PowerSaveResume"
+	// PowerSaveSuspendReason is added to an event when pods are being deleted for Slurm nodes suspended by slurmctld.
+	PowerSaveSuspendReason = "PowerSaveSuspend"
+	// RemediationReason is added to an event when a pod with an unhealthy Slurm node is being remediated.
+	RemediationReason = "Remediation"
+	// RemediationPausedReason is added to an event when remediation is paused because too many pods are unhealthy.
+	RemediationPausedReason = "RemediationPaused"
 	// ControllerRefFailedReason is added to an event when the referenced Controller CR cannot be fetched.
 	ControllerRefFailedReason = "ControllerRefFailed"
+	// NodeTerminationReason is added to an event when a pod is replaced because its Kubernetes node is terminating.
+	NodeTerminationReason = "NodeTermination"
+	// EvictionAbandonedReason is added to an event when a pod cordoned for eviction is uncordoned because no eviction
+	// followed within the timeout.
+	EvictionAbandonedReason = "EvictionAbandoned"
 )
 
+// TerminationSignals are the signals that a Kubernetes node is about to be terminated (e.g. a spot instance
+// interruption notice).
+type TerminationSignals struct {
+	// Taints are the keys of Kubernetes node taints which signal termination.
+	Taints []string
+	// Conditions are the types of Kubernetes node conditions which signal termination, when true.
+	Conditions []corev1.NodeConditionType
+}
+
 func init() {
 	flag.IntVar(&maxConcurrentReconciles, "nodeset-workers", maxConcurrentReconciles, "Max concurrent workers for NodeSet controller.")
 }
@@ -90,6 +126,7 @@ type NodeSetReconciler struct {
 	ClientMap *clientmap.ClientMap
 
 	propagatedNodeConditions []corev1.NodeConditionType
+	terminationSignals       TerminationSignals
 
 	builder        *builder.WorkerBuilder
 	refResolver    *refresolver.RefResolver
@@ -173,7 +210,12 @@ func (r *NodeSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func NewReconciler(c client.Client, cm *clientmap.ClientMap, propagatedNodeConditions []corev1.NodeConditionType) *NodeSetReconciler {
+func NewReconciler(
+	c client.Client,
+	cm *clientmap.ClientMap,
+	propagatedNodeConditions []corev1.NodeConditionType,
+	terminationSignals TerminationSignals,
+) *NodeSetReconciler {
 	s := c.Scheme()
 	er := events.NewFakeRecorder(100)
 	if cm == nil {
@@ -186,6 +228,7 @@ func NewReconciler(c client.Client, cm *clientmap.ClientMap, propagatedNodeCondi
 		ClientMap: cm,
 
 		propagatedNodeConditions: propagatedNodeConditions,
+		terminationSignals:       terminationSignals,
 
 		builder:        builder.New(c),
 		refResolver:    refresolver.New(c),
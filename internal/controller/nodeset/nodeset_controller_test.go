@@ -41,6 +41,8 @@ func newFakeClientList(interceptorFuncs interceptor.Funcs, initObjLists ...objec
 				switch stateReq {
 				case slurmapi.V0044UpdateNodeMsgStateUNDRAIN:
 					stateSet.Delete(slurmapi.V0044NodeStateDRAIN)
+				case slurmapi.V0044UpdateNodeMsgStateRESUME:
+					stateSet.Delete(slurmapi.V0044NodeStateDOWN, slurmapi.V0044NodeStateDRAIN)
 				default:
 					stateSet.Insert(slurmapi.V0044NodeState(stateReq))
 				}
@@ -49,6 +51,10 @@ func newFakeClientList(interceptorFuncs interceptor.Funcs, initObjLists ...objec
 			o.Comment = r.Comment
 			o.Reason = r.Reason
 			o.Topology = r.TopologyStr
+			if r.Features != nil {
+				o.Features = r.Features
+				o.ActiveFeatures = r.FeaturesAct
+			}
 		default:
 			return errors.New("failed to cast slurm object")
 		}
@@ -7,14 +7,27 @@ package nodeset
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"maps"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/kubernetes/pkg/controller/history"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // truncateHistory truncates any non-live ControllerRevisions in revisions from nodeset's history. The UpdateRevision and
@@ -45,9 +58,13 @@ func (r *NodeSetReconciler) truncateHistory(
 	for i := range pods {
 		live[historycontrol.GetRevision(pods[i].GetLabels())] = true
 	}
+	// the stable revision is the target of an automatic rollback
+	if nodeset.Status.StableRevision != "" {
+		live[nodeset.Status.StableRevision] = true
+	}
 	// collect live revisions and historic revisions
 	for i := range revisions {
-		if !live[revisions[i].Name] {
+		if !live[revisions[i].Name] && !live[historycontrol.GetRevision(revisions[i].GetLabels())] {
 			history = append(history, revisions[i])
 		}
 	}
@@ -217,3 +234,207 @@ func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
 	patch, err := json.Marshal(objCopy)
 	return patch, err
 }
+
+// syncRollback reverts the NodeSet to a previous revision, either as requested
+// by rollbackTo, or to the stable revision when pods of the update revision
+// fail in Slurm. It returns true if the NodeSet was updated, in which case the
+// NodeSet will be synced again from its next revision.
+func (r *NodeSetReconciler) syncRollback(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	revisions []*appsv1.ControllerRevision,
+	updateRevision *appsv1.ControllerRevision,
+	pods []*corev1.Pod,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+	hash := historycontrol.GetRevision(updateRevision.GetLabels())
+
+	if rollbackTo := nodeset.Spec.UpdateStrategy.RollbackTo; rollbackTo != "" {
+		target := findRevision(revisions, rollbackTo)
+		if target == nil {
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, RollbackFailedReason, "Rollback",
+				"Rollback: revision %q not found", rollbackTo)
+			_, err := r.applyRevision(ctx, nodeset, nil)
+			return true, err
+		}
+		targetHash := historycontrol.GetRevision(target.GetLabels())
+		logger.Info("Rolling back NodeSet, as requested by rollbackTo",
+			"revision", hash, "rollbackTo", targetHash)
+		generation, err := r.applyRevision(ctx, nodeset, target)
+		if err != nil {
+			return false, fmt.Errorf("failed to roll back to revision %s: %w", targetHash, err)
+		}
+		msg := fmt.Sprintf("Rolled back from revision %s to %s, as requested by rollbackTo", hash, targetHash)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollbackReason, "Rollback", msg)
+		meta.SetStatusCondition(&nodeset.Status.Conditions, metav1.Condition{
+			Type:               slurmconditions.NodeSetConditionRolledBack,
+			Status:             metav1.ConditionTrue,
+			Reason:             "RollbackTo",
+			Message:            msg,
+			ObservedGeneration: generation,
+		})
+		return true, nil
+	}
+
+	rollback := nodeset.Spec.UpdateStrategy.Rollback
+	stableRevision := nodeset.Status.StableRevision
+	if !rollback.Enabled || stableRevision == "" || stableRevision == hash {
+		return false, nil
+	}
+
+	failedPods := findFailedRevisionPods(pods, hash, rollback.ProgressDeadline.Duration)
+	if len(failedPods) == 0 {
+		return false, nil
+	}
+	target := findRevision(revisions, stableRevision)
+	if target == nil {
+		logger.Info("Cannot roll back failed revision, stable revision not found",
+			"revision", hash, "stableRevision", stableRevision, "failedPods", len(failedPods))
+		return false, nil
+	}
+
+	logger.Info("Rolling back NodeSet, pods of the update revision failed in Slurm",
+		"revision", hash, "stableRevision", stableRevision, "failedPods", len(failedPods))
+	generation, err := r.applyRevision(ctx, nodeset, target)
+	if err != nil {
+		return false, fmt.Errorf("failed to roll back to revision %s: %w", stableRevision, err)
+	}
+	msg := fmt.Sprintf("Rolled back from revision %s to %s, %d pod(s) failed in Slurm (e.g. %s)",
+		hash, stableRevision, len(failedPods), failedPods[0].Name)
+	r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, RollbackReason, "Rollback", msg)
+	meta.SetStatusCondition(&nodeset.Status.Conditions, metav1.Condition{
+		Type:               slurmconditions.NodeSetConditionRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RevisionFailed",
+		Message:            msg,
+		ObservedGeneration: generation,
+	})
+	return true, nil
+}
+
+// applyRevision restores the NodeSet to the state recorded in revision and
+// clears rollbackTo. If revision is nil, only rollbackTo is cleared. It returns
+// the generation of the updated NodeSet.
+func (r *NodeSetReconciler) applyRevision(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	revision *appsv1.ControllerRevision,
+) (int64, error) {
+	namespacedName := types.NamespacedName{
+		Namespace: nodeset.GetNamespace(),
+		Name:      nodeset.GetName(),
+	}
+	var generation int64
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.NodeSet{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			return err
+		}
+		if revision != nil {
+			original, err := json.Marshal(toUpdate)
+			if err != nil {
+				return err
+			}
+			patched, err := strategicpatch.StrategicMergePatch(original, revision.Data.Raw, toUpdate)
+			if err != nil {
+				return err
+			}
+			restored := &slinkyv1beta1.NodeSet{}
+			if err := json.Unmarshal(patched, restored); err != nil {
+				return err
+			}
+			toUpdate = restored
+		}
+		toUpdate.Spec.UpdateStrategy.RollbackTo = ""
+		if err := r.Update(ctx, toUpdate); err != nil {
+			return err
+		}
+		generation = toUpdate.Generation
+		return nil
+	})
+	return generation, err
+}
+
+// syncRolledBackCondition sets the RolledBack condition to False once a
+// revision after the rollback has been rolled out to every pod. The condition
+// records the generation of the rollback, so the rollout of the revision which
+// was rolled back to does not clear it.
+func syncRolledBackCondition(
+	nodeset *slinkyv1beta1.NodeSet,
+	conditions *[]metav1.Condition,
+	currentRevision, updateRevision *appsv1.ControllerRevision,
+) {
+	condition := meta.FindStatusCondition(*conditions, slurmconditions.NodeSetConditionRolledBack)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return
+	}
+	if nodeset.Generation <= condition.ObservedGeneration || currentRevision.Name != updateRevision.Name {
+		return
+	}
+	hash := historycontrol.GetRevision(updateRevision.GetLabels())
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               slurmconditions.NodeSetConditionRolledBack,
+		Status:             metav1.ConditionFalse,
+		Reason:             "RolledOut",
+		Message:            fmt.Sprintf("Revision %s was rolled out after the rollback", hash),
+		ObservedGeneration: nodeset.Generation,
+	})
+}
+
+// findRevision returns the revision with the given "controller-revision-hash"
+// or name, or nil if there is none.
+func findRevision(revisions []*appsv1.ControllerRevision, revision string) *appsv1.ControllerRevision {
+	for i := range revisions {
+		if revisions[i].Name == revision || historycontrol.GetRevision(revisions[i].GetLabels()) == revision {
+			return revisions[i]
+		}
+	}
+	return nil
+}
+
+// findFailedRevisionPods returns the pods of the given revision which have
+// failed in Slurm. Within the progress deadline a pod fails when its Slurm node
+// is NOT_RESPONDING; after it, when its Slurm node has not registered. A pod
+// whose Slurm node is INVALID_REG has always failed.
+func findFailedRevisionPods(pods []*corev1.Pod, hash string, deadline time.Duration) []*corev1.Pod {
+	var failedPods []*corev1.Pod
+	now := time.Now()
+	for _, pod := range pods {
+		if podutils.IsTerminating(pod) || historycontrol.GetRevision(pod.GetLabels()) != hash {
+			continue
+		}
+		switch {
+		case slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionInvalidReg):
+			failedPods = append(failedPods, pod)
+		case now.Sub(pod.CreationTimestamp.Time) < deadline:
+			if slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionNotResponding) {
+				failedPods = append(failedPods, pod)
+			}
+		case !slurmconditions.IsNodeRegistered(&pod.Status):
+			failedPods = append(failedPods, pod)
+		}
+	}
+	return failedPods
+}
+
+// calculateStableRevision returns the update revision once every pod was
+// updated to it and registered with slurmctld, otherwise the last stable
+// revision.
+func calculateStableRevision(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod, hash string) string {
+	var replicas int32
+	for _, pod := range pods {
+		if podutils.IsTerminating(pod) {
+			continue
+		}
+		if historycontrol.GetRevision(pod.GetLabels()) != hash ||
+			!slurmconditions.IsNodeRegistered(&pod.Status) {
+			return nodeset.Status.StableRevision
+		}
+		replicas++
+	}
+	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset && !nodeset.Spec.PowerSave.Enabled &&
+		replicas < ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas) {
+		return nodeset.Status.StableRevision
+	}
+	return hash
+}
@@ -5,11 +5,14 @@ package nodeset
 
 import (
 	"context"
+	"slices"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubernetes/pkg/controller/history"
 	"k8s.io/utils/ptr"
@@ -17,7 +20,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 func TestNodeSetReconciler_truncateHistory(t *testing.T) {
@@ -267,3 +273,483 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 		})
 	}
 }
+
+func newRevisionPod(
+	nodeset *slinkyv1beta1.NodeSet,
+	ordinal int,
+	hash string,
+	age time.Duration,
+	podConditions ...corev1.PodConditionType,
+) *corev1.Pod {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeset.Spec.ControllerRef.Name,
+		},
+	}
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, ordinal, hash)
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+	pod = makePodHealthy(pod)
+	for _, condType := range podConditions {
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:   condType,
+			Status: corev1.ConditionTrue,
+		})
+	}
+	return pod
+}
+
+func TestNodeSetReconciler_syncRollback(t *testing.T) {
+	type fields struct {
+		Client client.Client
+	}
+	type args struct {
+		ctx       context.Context
+		nodeset   *slinkyv1beta1.NodeSet
+		revisions []*appsv1.ControllerRevision
+		update    *appsv1.ControllerRevision
+		pods      []*corev1.Pod
+	}
+	type testCaseFields struct {
+		name          string
+		fields        fields
+		args          args
+		want          bool
+		wantErr       bool
+		wantImage     string
+		wantCondition bool
+	}
+	tests := []testCaseFields{
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			stable, err := newRevision(nodeset, 1, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			pods := []*corev1.Pod{
+				newRevisionPod(nodeset, 0, historycontrol.GetRevision(update.GetLabels()), time.Minute,
+					slurmconditions.PodConditionIdle),
+			}
+
+			return testCaseFields{
+				name: "rollback disabled",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset),
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset.DeepCopy(),
+					revisions: []*appsv1.ControllerRevision{stable, update},
+					update:    update,
+					pods:      pods,
+				},
+				want:      false,
+				wantImage: "slurmd:new",
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			stable, err := newRevision(nodeset, 1, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			nodeset.Spec.UpdateStrategy.RollbackTo = historycontrol.GetRevision(stable.GetLabels())
+			update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+
+			return testCaseFields{
+				name: "rollbackTo revision",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset),
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset.DeepCopy(),
+					revisions: []*appsv1.ControllerRevision{stable, update},
+					update:    update,
+				},
+				want:          true,
+				wantImage:     "slurmd",
+				wantCondition: true,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			nodeset.Spec.UpdateStrategy.RollbackTo = "does-not-exist"
+			update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+
+			return testCaseFields{
+				name: "rollbackTo revision not found",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset),
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset.DeepCopy(),
+					revisions: []*appsv1.ControllerRevision{update},
+					update:    update,
+				},
+				want:      true,
+				wantImage: "slurmd:new",
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			nodeset.Spec.UpdateStrategy.Rollback = slinkyv1beta1.NodeSetRollback{
+				Enabled:          true,
+				ProgressDeadline: metav1.Duration{Duration: 10 * time.Minute},
+			}
+			stable, err := newRevision(nodeset, 1, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			nodeset.Status.StableRevision = historycontrol.GetRevision(stable.GetLabels())
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			hash := historycontrol.GetRevision(update.GetLabels())
+			pods := []*corev1.Pod{
+				newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+				newRevisionPod(nodeset, 1, hash, time.Minute, slurmconditions.PodConditionIdle),
+			}
+
+			return testCaseFields{
+				name: "update revision is healthy",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset),
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset.DeepCopy(),
+					revisions: []*appsv1.ControllerRevision{stable, update},
+					update:    update,
+					pods:      pods,
+				},
+				want:      false,
+				wantImage: "slurmd:new",
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			nodeset.Spec.UpdateStrategy.Rollback = slinkyv1beta1.NodeSetRollback{
+				Enabled:          true,
+				ProgressDeadline: metav1.Duration{Duration: 10 * time.Minute},
+			}
+			stable, err := newRevision(nodeset, 1, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			nodeset.Status.StableRevision = historycontrol.GetRevision(stable.GetLabels())
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+			if err != nil {
+				panic(err)
+			}
+			hash := historycontrol.GetRevision(update.GetLabels())
+			pods := []*corev1.Pod{
+				newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+				newRevisionPod(nodeset, 1, hash, time.Minute,
+					slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding),
+			}
+
+			return testCaseFields{
+				name: "update revision failed",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset),
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset.DeepCopy(),
+					revisions: []*appsv1.ControllerRevision{stable, update},
+					update:    update,
+					pods:      pods,
+				},
+				want:          true,
+				wantImage:     "slurmd",
+				wantCondition: true,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(tt.fields.Client, nil)
+			got, err := r.syncRollback(tt.args.ctx, tt.args.nodeset, tt.args.revisions, tt.args.update, tt.args.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncRollback() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NodeSetReconciler.syncRollback() = %v, want %v", got, tt.want)
+			}
+			nodeset := &slinkyv1beta1.NodeSet{}
+			if err := r.Get(tt.args.ctx, client.ObjectKeyFromObject(tt.args.nodeset), nodeset); err != nil {
+				t.Fatalf("failed to get NodeSet: %v", err)
+			}
+			if nodeset.Spec.Slurmd.Image != tt.wantImage {
+				t.Errorf("NodeSet slurmd image = %v, want %v", nodeset.Spec.Slurmd.Image, tt.wantImage)
+			}
+			if tt.want && nodeset.Spec.UpdateStrategy.RollbackTo != "" {
+				t.Errorf("NodeSet rollbackTo = %v, want empty", nodeset.Spec.UpdateStrategy.RollbackTo)
+			}
+			gotCondition := meta.IsStatusConditionTrue(tt.args.nodeset.Status.Conditions, slurmconditions.NodeSetConditionRolledBack)
+			if gotCondition != tt.wantCondition {
+				t.Errorf("NodeSet RolledBack condition = %v, want %v", gotCondition, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func Test_syncRolledBackCondition(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 2)
+	stable, err := newRevision(nodeset, 1, ptr.To[int32](0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeset.Spec.Slurmd.Image = "slurmd:new"
+	update, err := newRevision(nodeset, 2, ptr.To[int32](0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rolledBack := metav1.Condition{
+		Type:               slurmconditions.NodeSetConditionRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RevisionFailed",
+		ObservedGeneration: 2,
+	}
+	tests := []struct {
+		name       string
+		generation int64
+		conditions []metav1.Condition
+		current    *appsv1.ControllerRevision
+		update     *appsv1.ControllerRevision
+		want       metav1.ConditionStatus
+	}{
+		{
+			name:       "no condition",
+			generation: 3,
+			current:    update,
+			update:     update,
+		},
+		{
+			name:       "rolled back revision rolled out",
+			generation: 2,
+			conditions: []metav1.Condition{rolledBack},
+			current:    stable,
+			update:     stable,
+			want:       metav1.ConditionTrue,
+		},
+		{
+			name:       "new revision rolling out",
+			generation: 3,
+			conditions: []metav1.Condition{rolledBack},
+			current:    stable,
+			update:     update,
+			want:       metav1.ConditionTrue,
+		},
+		{
+			name:       "new revision rolled out",
+			generation: 3,
+			conditions: []metav1.Condition{rolledBack},
+			current:    update,
+			update:     update,
+			want:       metav1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := nodeset.DeepCopy()
+			nodeset.Generation = tt.generation
+			conditions := slices.Clone(tt.conditions)
+			syncRolledBackCondition(nodeset, &conditions, tt.current, tt.update)
+			condition := meta.FindStatusCondition(conditions, slurmconditions.NodeSetConditionRolledBack)
+			if tt.want == "" {
+				if condition != nil {
+					t.Errorf("syncRolledBackCondition() condition = %v, want none", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tt.want {
+				t.Errorf("syncRolledBackCondition() condition = %v, want status %v", condition, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findFailedRevisionPods(t *testing.T) {
+	const hash = "12345"
+	nodeset := newNodeSet("foo", "slurm", 2)
+	type args struct {
+		pods     []*corev1.Pod
+		hash     string
+		deadline time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "registered",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Hour, slurmconditions.PodConditionIdle),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 0,
+		},
+		{
+			name: "not registered within deadline",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 0,
+		},
+		{
+			name: "not registered after deadline",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Hour),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 1,
+		},
+		{
+			name: "not responding within deadline",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute,
+						slurmconditions.PodConditionIdle, slurmconditions.PodConditionNotResponding),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 1,
+		},
+		{
+			name: "invalid registration",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Hour,
+						slurmconditions.PodConditionDown, slurmconditions.PodConditionInvalidReg),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 1,
+		},
+		{
+			name: "other revision",
+			args: args{
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, "67890", time.Hour,
+						slurmconditions.PodConditionDown, slurmconditions.PodConditionInvalidReg),
+				},
+				hash:     hash,
+				deadline: 10 * time.Minute,
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findFailedRevisionPods(tt.args.pods, tt.args.hash, tt.args.deadline); len(got) != tt.want {
+				t.Errorf("findFailedRevisionPods() = %v, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_calculateStableRevision(t *testing.T) {
+	const (
+		stable = "12345"
+		hash   = "67890"
+	)
+	nodeset := newNodeSet("foo", "slurm", 2)
+	nodeset.Status.StableRevision = stable
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+		hash    string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "all pods updated and registered",
+			args: args{
+				nodeset: nodeset,
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+					newRevisionPod(nodeset, 1, hash, time.Minute, slurmconditions.PodConditionAllocated),
+				},
+				hash: hash,
+			},
+			want: hash,
+		},
+		{
+			name: "pod not updated",
+			args: args{
+				nodeset: nodeset,
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+					newRevisionPod(nodeset, 1, stable, time.Hour, slurmconditions.PodConditionIdle),
+				},
+				hash: hash,
+			},
+			want: stable,
+		},
+		{
+			name: "pod not registered",
+			args: args{
+				nodeset: nodeset,
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+					newRevisionPod(nodeset, 1, hash, time.Minute),
+				},
+				hash: hash,
+			},
+			want: stable,
+		},
+		{
+			name: "not all replicas created",
+			args: args{
+				nodeset: nodeset,
+				pods: []*corev1.Pod{
+					newRevisionPod(nodeset, 0, hash, time.Minute, slurmconditions.PodConditionIdle),
+				},
+				hash: hash,
+			},
+			want: stable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateStableRevision(tt.args.nodeset, tt.args.pods, tt.args.hash); got != tt.want {
+				t.Errorf("calculateStableRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
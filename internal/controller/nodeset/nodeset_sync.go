@@ -5,7 +5,9 @@ package nodeset
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
@@ -14,10 +16,12 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
@@ -32,8 +36,10 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
 	"github.com/SlinkyProject/slurm-operator/internal/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
@@ -43,6 +49,8 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
 
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topology"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 const (
@@ -63,6 +71,7 @@ func (r *NodeSetReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		if apierrors.IsNotFound(err) {
 			logger.V(3).Info("NodeSet has been deleted.", "request", req)
 			r.expectations.DeleteExpectations(logger, req.String())
+			metrics.DeleteNodeSetState(req.NamespacedName)
 			return nil
 		}
 		return err
@@ -108,6 +117,10 @@ func (r *NodeSetReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash)
 	}
 
+	if updated, err := r.syncRollback(ctx, nodeset, revisions, updateRevision, nodesetPods); err != nil || updated {
+		return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash, err)
+	}
+
 	if err := r.sync(ctx, nodeset, nodesetPods, hash); err != nil {
 		return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash, err)
 	}
@@ -387,6 +400,18 @@ func (r *NodeSetReconciler) sync(
 				return r.syncSlurmDeadline(ctx, nodeset, pods)
 			},
 		},
+		{
+			Name: "Autoscale",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncAutoscale(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "Eviction",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncEviction(ctx, nodeset, pods)
+			},
+		},
 		{
 			Name: "Cordon",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -399,6 +424,18 @@ func (r *NodeSetReconciler) sync(
 				return r.syncNodeSetPods(ctx, nodeset, pods, hash)
 			},
 		},
+		{
+			Name: "Remediation",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncRemediation(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "Termination",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncTermination(ctx, nodeset, pods)
+			},
+		},
 		{
 			Name: "SlurmNodeRecords",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -417,6 +454,12 @@ func (r *NodeSetReconciler) sync(
 				return r.syncSlurmTopology(ctx, nodeset, pods)
 			},
 		},
+		{
+			Name: "SlurmFeatures",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncSlurmFeatures(ctx, nodeset, pods)
+			},
+		},
 		{
 			Name: "SlurmReservation",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -562,6 +605,436 @@ func (r *NodeSetReconciler) syncCordon(
 	return nil
 }
 
+// syncEviction handles NodeSet pods which were cordoned by the eviction webhook.
+//
+// The webhook refreshes the eviction annotation on each denied eviction. When no eviction has followed within the
+// PodEvictionTimeout (e.g. `kubectl drain` was aborted), the annotation is cleared, then the pod is uncordoned and its
+// Slurm node undrained, unless it must stay drained for another reason.
+func (r *NodeSetReconciler) syncEviction(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	now := time.Now()
+
+	syncEvictionFn := func(i int) error {
+		pod := pods[i]
+		if !podutils.IsPodEviction(pod) || podutils.IsTerminating(pod) {
+			return nil
+		}
+
+		evictionTime, _ := structutils.GetTimeFromAnnotations(pod.Annotations, slinkyv1beta1.AnnotationPodEviction)
+		if expiry := evictionTime.Add(PodEvictionTimeout); expiry.After(now) {
+			durationStore.Push(key, expiry.Sub(now))
+			return nil
+		}
+
+		logger.Info("No eviction followed within the timeout, uncordoning pod",
+			"pod", klog.KObj(pod), "evictionTime", evictionTime)
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeNormal, EvictionAbandonedReason, "Uncordon",
+			"Eviction of Pod %s was abandoned: no eviction followed within %s", klog.KObj(pod), PodEvictionTimeout)
+		mutateFn := func(pod *corev1.Pod) error {
+			delete(pod.Annotations, slinkyv1beta1.AnnotationPodEviction)
+			return nil
+		}
+		if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+			return err
+		}
+
+		return r.syncPodUncordon(ctx, nodeset, pod)
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncEvictionFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncRemediation handles the remediation of NodeSet pods whose Slurm node is unhealthy.
+//
+// A pod which has had one of the remediation conditions for the unhealthy duration is annotated for remediation, and
+// its Slurm node is drained. Once its running jobs have completed, or exceeded their time limit, the pod is deleted to
+// be recreated. At most maxRemediations pods begin remediation within the window, and remediation is paused while more
+// pods are unhealthy than maxUnhealthy. Pods already being remediated are always processed to completion.
+func (r *NodeSetReconciler) syncRemediation(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	remediation := nodeset.Spec.Remediation
+	now := time.Now()
+
+	// Slurm nodes can be unresponsive while slurmctld fails over, so they are
+	// only unhealthy for the time since.
+	var failoverTime time.Time
+	if remediation.Enabled {
+		var err error
+		failoverTime, err = r.getFailoverTime(ctx, nodeset)
+		if err != nil {
+			return err
+		}
+	}
+
+	var remediating, unhealthy, candidates []*corev1.Pod
+	for _, pod := range pods {
+		if podutils.IsTerminating(pod) {
+			continue
+		}
+		if podutils.IsPodRemediation(pod) {
+			remediating = append(remediating, pod)
+			continue
+		}
+		if !remediation.Enabled {
+			continue
+		}
+		_, since, ok := unhealthySince(pod, remediation.Conditions)
+		if !ok {
+			continue
+		}
+		unhealthy = append(unhealthy, pod)
+		if since.Before(failoverTime) {
+			since = failoverTime
+		}
+		if wait := remediation.UnhealthyDuration.Duration - now.Sub(since); wait > 0 {
+			durationStore.Push(key, wait)
+			continue
+		}
+		candidates = append(candidates, pod)
+	}
+
+	window := remediation.Window.Duration
+	if !remediation.Enabled {
+		window = 0
+	}
+	nodeset.Status.Remediations = pruneRemediations(nodeset.Status.Remediations, remediating, window, now)
+
+	if len(candidates) > 0 {
+		maxUnhealthy, err := intstr.GetScaledValueFromIntOrPercent(
+			ptr.To(ptr.Deref(remediation.MaxUnhealthy, defaults.DefaultNodeSetRemediationMaxUnhealthy)), len(pods), true)
+		if err != nil {
+			return err
+		}
+		if numUnhealthy := len(unhealthy) + len(remediating); numUnhealthy > maxUnhealthy {
+			logger.Info("Pausing remediation, too many NodeSet pods are unhealthy",
+				"unhealthy", numUnhealthy, "maxUnhealthy", maxUnhealthy)
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, RemediationPausedReason, "Remediate",
+				"Remediation paused: %d of %d pods are unhealthy, more than maxUnhealthy (%d)",
+				numUnhealthy, len(pods), maxUnhealthy)
+			candidates = nil
+		}
+	}
+
+	if budget := int(remediation.MaxRemediations) - len(nodeset.Status.Remediations); len(candidates) > budget {
+		logger.V(1).Info("Remediation rate limited, deferring unhealthy NodeSet pods",
+			"deferred", len(candidates)-max(budget, 0), "maxRemediations", remediation.MaxRemediations)
+		if next := nextRemediationTime(nodeset.Status.Remediations, window); !next.IsZero() {
+			durationStore.Push(key, next.Sub(now))
+		}
+		candidates = candidates[:max(budget, 0)]
+	}
+
+	for _, pod := range candidates {
+		condType, _, _ := unhealthySince(pod, remediation.Conditions)
+		logger.Info("Remediating NodeSet pod, Slurm node is unhealthy",
+			"pod", klog.KObj(pod), "condition", condType)
+		mutateFn := func(pod *corev1.Pod) error {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[slinkyv1beta1.AnnotationPodRemediation] = now.Format(time.RFC3339)
+			return nil
+		}
+		if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, RemediationReason, "Remediate",
+			"Remediating Pod %s: %s for at least %s", klog.KObj(pod), condType, remediation.UnhealthyDuration.Duration)
+		nodeset.Status.Remediations = setRemediation(nodeset.Status.Remediations, slinkyv1beta1.NodeSetRemediationStatus{
+			Pod:       pod.Name,
+			Node:      pod.Spec.NodeName,
+			StartTime: metav1.NewTime(now),
+		})
+		remediating = append(remediating, pod)
+	}
+
+	if len(remediating) == 0 {
+		return nil
+	}
+	nodeDeadlines, err := r.slurmControl.GetNodeDeadlines(ctx, nodeset, remediating)
+	if err != nil {
+		return err
+	}
+
+	remediateFn := func(i int) error {
+		pod := remediating[i]
+		reason := fmt.Sprintf("Pod (%s) is pending termination for remediation", klog.KObj(pod))
+		if err := r.makePodCordonAndDrain(ctx, nodeset, pod, reason, true); err != nil {
+			return err
+		}
+
+		// Wait for the running jobs to complete, or exceed their time limit.
+		if deadline := nodeDeadlines.Peek(nodesetutils.GetSlurmNodeName(pod)); deadline.After(now) {
+			logger.V(2).Info("NodeSet Pod is draining, pending termination for remediation",
+				"pod", klog.KObj(pod), "deadline", deadline)
+			durationStore.Push(key, deadline.Sub(now))
+			return nil
+		}
+
+		logger.Info("Deleting NodeSet pod for remediation", "pod", klog.KObj(pod))
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeNormal, RemediationReason, "Delete",
+			"Deleting Pod %s for remediation", klog.KObj(pod))
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(remediating), utils.SlowStartInitialBatchSize, remediateFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncTermination handles NodeSet pods on Kubernetes nodes which are about to be terminated (e.g. a spot instance
+// interruption), as signaled by the configured node taints and conditions.
+//
+// The Slurm node of such a pod is drained, its running jobs are made requeueable, and it is set DOWN, so that
+// slurmctld requeues them, then the pod is deleted to be replaced elsewhere. Once a replacement pod is on a Kubernetes
+// node which is not terminating, its Slurm node is resumed if it is still DOWN for the termination.
+func (r *NodeSetReconciler) syncTermination(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	if len(r.terminationSignals.Taints) == 0 && len(r.terminationSignals.Conditions) == 0 {
+		return nil
+	}
+
+	syncTerminationFn := func(i int) error {
+		pod := pods[i]
+		if podutils.IsTerminating(pod) || pod.Spec.NodeName == "" {
+			return nil
+		}
+
+		node := &corev1.Node{}
+		nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+		if err := r.Get(ctx, nodeKey, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		signal := terminationSignal(node, r.terminationSignals)
+		if signal == "" {
+			if !isNodeDownForTermination(pod) {
+				return nil
+			}
+			return r.slurmControl.MakeNodeResume(ctx, nodeset, pod)
+		}
+
+		logger.Info("Kubernetes node is terminating, replacing NodeSet pod",
+			"pod", klog.KObj(pod), "node", node.Name, "signal", signal)
+		reason := fmt.Sprintf("Node (%s) %s (%s), Pod (%s) must be replaced",
+			node.Name, nodeTerminatingReason, signal, klog.KObj(pod))
+		if err := r.makePodCordonAndDrain(ctx, nodeset, pod, reason, true); err != nil {
+			return err
+		}
+		if err := r.slurmControl.RequeueNodeJobs(ctx, nodeset, pod); err != nil {
+			return err
+		}
+		if err := r.slurmControl.MakeNodeDown(ctx, nodeset, pod, reason); err != nil {
+			return err
+		}
+
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, NodeTerminationReason, "Delete",
+			"Deleting Pod %s: Kubernetes node %s is terminating (%s)", klog.KObj(pod), node.Name, signal)
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncTerminationFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// nodeTerminatingReason marks the Slurm node reasons set by syncTermination.
+const nodeTerminatingReason = "is terminating"
+
+// isNodeDownForTermination returns true if the Slurm node of the pod is DOWN because its Kubernetes node was
+// terminating.
+func isNodeDownForTermination(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != slurmconditions.PodConditionDown || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		return slurmcontrol.IsNodeReasonFormatted(cond.Message) && strings.Contains(cond.Message, nodeTerminatingReason)
+	}
+	return false
+}
+
+// terminationSignal returns the first of the signals that the Kubernetes node is about to be terminated, or empty if
+// there is none.
+func terminationSignal(node *corev1.Node, signals TerminationSignals) string {
+	for _, taint := range node.Spec.Taints {
+		if slices.Contains(signals.Taints, taint.Key) {
+			return fmt.Sprintf("taint %s", taint.Key)
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue && slices.Contains(signals.Conditions, cond.Type) {
+			return fmt.Sprintf("condition %s", cond.Type)
+		}
+	}
+	return ""
+}
+
+// getFailoverTime returns the last time that the Controller of the NodeSet
+// failed over between its primary and backup slurmctld, if ever.
+func (r *NodeSetReconciler) getFailoverTime(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (time.Time, error) {
+	controller := &slinkyv1beta1.Controller{}
+	key := types.NamespacedName{
+		Namespace: nodeset.Namespace,
+		Name:      nodeset.Spec.ControllerRef.Name,
+	}
+	if err := r.Get(ctx, key, controller); err != nil {
+		if apierrors.IsNotFound(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	cond := meta.FindStatusCondition(controller.Status.Conditions, slurmconditions.ControllerConditionFailover)
+	if cond == nil {
+		return time.Time{}, nil
+	}
+	return cond.LastTransitionTime.Time, nil
+}
+
+// unhealthySince returns the first of the conditions which is true for the pod, and the earliest time since which any
+// of them has been true.
+func unhealthySince(pod *corev1.Pod, conditions []corev1.PodConditionType) (corev1.PodConditionType, time.Time, bool) {
+	var condType corev1.PodConditionType
+	var since time.Time
+	for _, ct := range conditions {
+		_, cond := podutil.GetPodCondition(&pod.Status, ct)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		t := cond.LastTransitionTime.Time
+		if t.IsZero() {
+			t = pod.CreationTimestamp.Time
+		}
+		if condType == "" {
+			condType = ct
+		}
+		if since.IsZero() || t.Before(since) {
+			since = t
+		}
+	}
+	return condType, since, condType != ""
+}
+
+// pruneRemediations returns the remediations which began within the window, or whose pod is still being remediated.
+func pruneRemediations(
+	remediations []slinkyv1beta1.NodeSetRemediationStatus,
+	remediating []*corev1.Pod,
+	window time.Duration,
+	now time.Time,
+) []slinkyv1beta1.NodeSetRemediationStatus {
+	remediatingPods := set.New[string]()
+	for _, pod := range remediating {
+		remediatingPods.Insert(pod.Name)
+	}
+	var pruned []slinkyv1beta1.NodeSetRemediationStatus
+	for _, remediation := range remediations {
+		if remediatingPods.Has(remediation.Pod) || now.Sub(remediation.StartTime.Time) < window {
+			pruned = append(pruned, remediation)
+		}
+	}
+	return pruned
+}
+
+// setRemediation adds the remediation, replacing any previous remediation of the same pod.
+func setRemediation(
+	remediations []slinkyv1beta1.NodeSetRemediationStatus,
+	remediation slinkyv1beta1.NodeSetRemediationStatus,
+) []slinkyv1beta1.NodeSetRemediationStatus {
+	idx := slices.IndexFunc(remediations, func(r slinkyv1beta1.NodeSetRemediationStatus) bool {
+		return r.Pod == remediation.Pod
+	})
+	if idx < 0 {
+		return append(remediations, remediation)
+	}
+	remediations[idx] = remediation
+	return remediations
+}
+
+// nextRemediationTime returns when the earliest remediation leaves the window, or zero if there are none.
+func nextRemediationTime(remediations []slinkyv1beta1.NodeSetRemediationStatus, window time.Duration) time.Time {
+	var next time.Time
+	for _, remediation := range remediations {
+		t := remediation.StartTime.Add(window)
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// excludeRemediatedNode has a remediated pod avoid the Kubernetes node it was remediated from, when configured.
+func excludeRemediatedNode(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) {
+	if !nodeset.Spec.Remediation.Enabled || !nodeset.Spec.Remediation.RecreateOnDifferentNode {
+		return
+	}
+	idx := slices.IndexFunc(nodeset.Status.Remediations, func(r slinkyv1beta1.NodeSetRemediationStatus) bool {
+		return r.Pod == pod.Name && r.Node != ""
+	})
+	if idx < 0 {
+		return
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      metav1.ObjectNameField,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{nodeset.Status.Remediations[idx].Node},
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	nodeSelector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// Node selector terms are ORed, so each term must exclude the node.
+	for i := range nodeSelector.NodeSelectorTerms {
+		term := &nodeSelector.NodeSelectorTerms[i]
+		term.MatchFields = append(term.MatchFields, requirement)
+	}
+}
+
 // syncSlurmNodeRecords prunes Slurm node records under certain conditions.
 func (r *NodeSetReconciler) syncSlurmNodeRecords(
 	ctx context.Context,
@@ -738,12 +1211,104 @@ func (r *NodeSetReconciler) syncSlurmDeadline(
 	return nil
 }
 
+// syncAutoscale handles queue-driven scaling of the NodeSet replicas.
+//
+// The wanted replica count is the number of busy pods (those with a workload
+// deadline) plus enough pods to satisfy the pending jobs, bounded by the
+// autoscaling min and max replicas. Busy pods are never scaled in, and idle
+// pods are condemned first (see ActivePods) then drained by processCondemned.
+func (r *NodeSetReconciler) syncAutoscale(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	autoscaling := nodeset.Spec.Autoscaling
+	if !autoscaling.Enabled || nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+		nodeset.Status.SlurmPending = 0
+		nodeset.Status.LastScaleTime = nil
+		return nil
+	}
+
+	pendingJobs, err := r.slurmControl.GetPendingJobCount(ctx, nodeset)
+	if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		// Without a view of the queue, scaling would act on zero pending jobs.
+		logger.V(1).Info("Skipping NodeSet autoscaling, Slurm is not reachable")
+		durationStore.Push(objectutils.KeyFunc(nodeset), 30*time.Second)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	nodeset.Status.SlurmPending = pendingJobs
+
+	busyPods := int32(0)
+	for _, pod := range pods {
+		deadline, _ := structutils.GetTimeFromAnnotations(pod.Annotations, slinkyv1beta1.AnnotationPodDeadline)
+		if !deadline.IsZero() {
+			busyPods++
+		}
+	}
+
+	target := max(autoscaling.TargetPendingJobsPerNode, 1)
+	replicasWant := busyPods + (pendingJobs+target-1)/target
+	replicasWant = mathutils.Clamp(replicasWant, autoscaling.MinReplicas, autoscaling.MaxReplicas)
+	replicasWant = max(replicasWant, busyPods)
+
+	replicas := ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas)
+	if replicasWant == replicas {
+		return nil
+	}
+
+	cooldown := autoscaling.ScaleUpCooldown.Duration
+	if replicasWant < replicas {
+		cooldown = autoscaling.ScaleDownCooldown.Duration
+	}
+	if lastScaleTime := nodeset.Status.LastScaleTime; lastScaleTime != nil {
+		if remaining := cooldown - time.Since(lastScaleTime.Time); remaining > 0 {
+			logger.V(1).Info("NodeSet autoscaling is cooling down",
+				"replicas", replicas, "replicasWant", replicasWant, "remaining", remaining)
+			durationStore.Push(objectutils.KeyFunc(nodeset), remaining)
+			return nil
+		}
+	}
+
+	// Patch a copy so the defaulted in-memory NodeSet is not clobbered.
+	toPatch := nodeset.DeepCopy()
+	mutateFn := func(nodeset *slinkyv1beta1.NodeSet) error {
+		nodeset.Spec.Replicas = ptr.To(replicasWant)
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, toPatch, mutateFn); err != nil {
+		return err
+	}
+	nodeset.Spec.Replicas = ptr.To(replicasWant)
+	now := metav1.Now()
+	nodeset.Status.LastScaleTime = &now
+
+	r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, AutoscalingReason, "Autoscale",
+		"Scaling replicas from %d to %d for %d pending job(s) and %d busy Pod(s)",
+		replicas, replicasWant, pendingJobs, busyPods)
+
+	return nil
+}
+
 // syncSlurmTopology handles the Slurm Node's topology.
 func (r *NodeSetReconciler) syncSlurmTopology(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
 ) error {
+	var topologies []slinkyv1beta1.ControllerTopology
+	controller, err := r.refResolver.GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		topologies = controller.Spec.Topology
+	}
+
 	syncSlurmTopologyFn := func(i int) error {
 		pod := pods[i]
 
@@ -761,7 +1326,7 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 			return err
 		}
 
-		topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+		topologySpec := topology.NodeSpec(node, topologies)
 		mutateFn := func(pod *corev1.Pod) error {
 			pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
 			return nil
@@ -785,6 +1350,65 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 	return nil
 }
 
+// syncSlurmFeatures handles publishing the Kubernetes node labels, by nodeFeatures.labelKeys, as Slurm node features.
+func (r *NodeSetReconciler) syncSlurmFeatures(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	labelKeys := nodeset.Spec.NodeFeatures.LabelKeys
+	if len(labelKeys) == 0 {
+		return nil
+	}
+
+	syncSlurmFeaturesFn := func(i int) error {
+		pod := pods[i]
+
+		if pod.Spec.NodeName == "" {
+			// Skip if Pod has not been allocated to a Node.
+			return nil
+		}
+
+		node := &corev1.Node{}
+		nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+		if err := r.Get(ctx, nodeKey, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		features := nodeLabelFeatures(node, labelKeys)
+		if err := r.slurmControl.UpdateNodeFeatures(ctx, nodeset, pod, features); err != nil {
+			return fmt.Errorf("failed to update Slurm node features: %w", err)
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncSlurmFeaturesFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// invalidFeatureChars matches the characters which are not allowed in a Slurm node feature, as they are operators in
+// job constraints (e.g. `&`, `|`, `[`) or separators.
+var invalidFeatureChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// nodeLabelFeatures returns the values of the Kubernetes node labels, by key, as Slurm node features.
+func nodeLabelFeatures(node *corev1.Node, labelKeys []string) []string {
+	features := []string{}
+	for _, key := range labelKeys {
+		value := node.Labels[key]
+		if value == "" {
+			continue
+		}
+		features = append(features, invalidFeatureChars.ReplaceAllString(value, "_"))
+	}
+	return features
+}
+
 // EnqueueNodeSetAfter schedules a reconcile of the NodeSet after the given delay.
 // It uses the shared durationStore so that the next Reconcile result will have RequeueAfter set.
 func (r *NodeSetReconciler) EnqueueNodeSetAfter(nodeset *slinkyv1beta1.NodeSet, after time.Duration) {
@@ -852,6 +1476,10 @@ func (r *NodeSetReconciler) podsShouldBeOnNode(
 
 	logger := log.FromContext(ctx)
 	shouldRun, shouldContinueRunning := r.NodeShouldRunDaemonPod(ctx, node, nodeset)
+	// Do not replace a pod on a node which is about to be terminated.
+	if terminationSignal(node, r.terminationSignals) != "" {
+		shouldRun = false
+	}
 	daemonPods, exists := nodeToDaemonPods[node.Name]
 
 	switch {
@@ -996,6 +1624,28 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 			}
 			return r.doPodScale(ctx, nodeset, podsNewScaling, podsToDelete, podsToCreate)
 		}
+	} else if nodeset.Spec.PowerSave.Enabled {
+		logger.V(2).Info("Processing NodeSet pods in StatefulSet mode with power saving")
+		podsToCreate, podsToSuspend, podsToDelete, ok, err := r.splitPowerSavePods(ctx, nodeset, podsNewScaling, hash)
+		if err != nil {
+			return err
+		}
+		if ok && len(podsToSuspend) > 0 {
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, PowerSaveSuspendReason, "ScaleDown",
+				"Deleting %d Pod(s) for Slurm nodes suspended by slurmctld", len(podsToSuspend))
+			return r.doPodSuspend(ctx, nodeset, podsToSuspend)
+		}
+		if ok && (len(podsToCreate) > 0 || len(podsToDelete) > 0) {
+			if len(podsToCreate) > 0 {
+				r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, PowerSaveResumeReason, "ScaleUp",
+					"Creating %d Pod(s) for Slurm nodes resumed by slurmctld", len(podsToCreate))
+			}
+			if len(podsToDelete) > 0 {
+				r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
+					"Deleting %d Pod(s) to stabilize at %d power save nodes", len(podsToDelete), nodeset.Spec.PowerSave.MaxNodes)
+			}
+			return r.doPodScale(ctx, nodeset, podsNewScaling, podsToDelete, podsToCreate)
+		}
 	} else {
 		logger.V(2).Info("Processing NodeSet pods in StatefulSet mode")
 
@@ -1032,7 +1682,10 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 			logger.V(2).Info("Too many NodeSet pods", "need", replicaCount, "deleting", diff)
 			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
 				"Deleting %d Pod(s) to stabilize at %d replicas", diff, replicaCount)
-			podsToDelete, podsToKeep := nodesetutils.SplitActivePods(podsNewScaling, diff)
+			podsToDelete, podsToKeep, err := r.splitScaleInPods(ctx, nodeset, podsNewScaling, diff)
+			if err != nil {
+				return err
+			}
 			return r.doPodScale(ctx, nodeset, podsToKeep, podsToDelete, nil)
 		}
 	}
@@ -1041,6 +1694,161 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 	return r.doPodProcessing(ctx, nodeset, podsNewScaling, podsOldScaling, hash)
 }
 
+// splitPowerSavePods compares the NodeSet pods against the power state of their pre-registered Slurm nodes.
+// It returns the pods to create for resumed Slurm nodes, the pods to suspend for suspended Slurm nodes,
+// and the pods to delete because they exceed the number of power save nodes. The results cannot be used
+// when ok is false.
+func (r *NodeSetReconciler) splitPowerSavePods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) (podsToCreate, podsToSuspend, podsToDelete []*corev1.Pod, ok bool, err error) {
+	maxNodes := int(nodeset.Spec.PowerSave.MaxNodes)
+	slurmNodeNames := make([]string, maxNodes)
+	for ordinal := range maxNodes {
+		slurmNodeNames[ordinal] = nodesetutils.GetOrdinalSlurmNodeName(nodeset, ordinal)
+	}
+
+	if err := r.slurmControl.CreatePowerSaveNodes(ctx, nodeset, slurmNodeNames); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	poweredUpNodes, ok, err := r.slurmControl.GetPoweredUpNodes(ctx, nodeset, slurmNodeNames)
+	if err != nil || !ok {
+		return nil, nil, nil, false, err
+	}
+	poweredUpNodeSet := set.New(poweredUpNodes...)
+
+	usedOrdinals := set.New[int]()
+	for _, pod := range pods {
+		ordinal := nodesetutils.GetOrdinal(pod)
+		usedOrdinals.Insert(ordinal)
+		switch {
+		case ordinal < 0 || ordinal >= maxNodes:
+			podsToDelete = append(podsToDelete, pod)
+		case podutils.IsTerminating(pod):
+			continue
+		case !poweredUpNodeSet.Has(nodesetutils.GetSlurmNodeName(pod)):
+			podsToSuspend = append(podsToSuspend, pod)
+		}
+	}
+
+	for ordinal, slurmNodeName := range slurmNodeNames {
+		if usedOrdinals.Has(ordinal) || !poweredUpNodeSet.Has(slurmNodeName) {
+			continue
+		}
+		pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, hash)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		podsToCreate = append(podsToCreate, pod)
+	}
+
+	return podsToCreate, podsToSuspend, podsToDelete, true, nil
+}
+
+// doPodSuspend deletes NodeSet pods whose Slurm node was suspended by slurmctld.
+// Unlike a scale-in, the Slurm node is not drained because slurmctld only suspends
+// idle nodes, and a drained node could not be resumed.
+func (r *NodeSetReconciler) doPodSuspend(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	podsToSuspend []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	numDelete := mathutils.Clamp(len(podsToSuspend), 0, burstReplicas)
+
+	if err := r.expectations.ExpectDeletions(logger, key, getPodKeys(podsToSuspend[:numDelete])); err != nil {
+		return err
+	}
+
+	deletePodFn := func(index int) error {
+		pod := podsToSuspend[index]
+		logger.V(2).Info("NodeSet Pod is terminating for power save suspend",
+			"pod", klog.KObj(pod))
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			// Decrement the expected number of deletes because the informer won't observe this deletion
+			r.expectations.DeletionObserved(logger, key, kubecontroller.PodKey(pod))
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(numDelete, utils.SlowStartInitialBatchSize, deletePodFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// splitScaleInPods returns the pods to delete and the pods to keep when scaling in by diff,
+// according to the NodeSet ScaleInStrategy.
+func (r *NodeSetReconciler) splitScaleInPods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	diff int,
+) (podsToDelete, podsToKeep []*corev1.Pod, err error) {
+	if nodeset.Spec.ScaleInStrategy == slinkyv1beta1.ScaleInStrategyDefault {
+		podsToDelete, podsToKeep = nodesetutils.SplitActivePods(pods, diff)
+		return podsToDelete, podsToKeep, nil
+	}
+
+	workloads, err := r.slurmControl.GetNodeWorkloads(ctx, nodeset, pods)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pivot := mathutils.Clamp(diff, 0, len(pods))
+	sort.Sort(workloadPods{pods: pods, workloads: workloads})
+	podsToDelete = slices.Clone(pods[:pivot])
+	podsToKeep = slices.Clone(pods[pivot:])
+
+	return podsToDelete, podsToKeep, nil
+}
+
+// workloadPods allows sorting of pods by the Slurm workload on their node, so
+// pods whose Slurm node is doing the least work are preferred for deletion.
+type workloadPods struct {
+	pods      []*corev1.Pod
+	workloads map[string]slurmcontrol.NodeWorkload
+}
+
+func (o workloadPods) Len() int {
+	return len(o.pods)
+}
+
+func (o workloadPods) Swap(i, j int) {
+	o.pods[i], o.pods[j] = o.pods[j], o.pods[i]
+}
+
+// Less compares two pods and returns true if the first one should be preferred for deletion.
+func (o workloadPods) Less(i, j int) bool {
+	workload1 := o.workloads[nodesetutils.GetSlurmNodeName(o.pods[i])]
+	workload2 := o.workloads[nodesetutils.GetSlurmNodeName(o.pods[j])]
+
+	// Step: IDLE < MIXED < ALLOCATED
+	if workload1.Allocation != workload2.Allocation {
+		return workload1.Allocation < workload2.Allocation
+	}
+
+	// Step: fewer running jobs < more running jobs
+	if workload1.RunningJobs != workload2.RunningJobs {
+		return workload1.RunningJobs < workload2.RunningJobs
+	}
+
+	// Step: earlier deadline < later deadline
+	if !workload1.Deadline.Equal(workload2.Deadline) {
+		return workload1.Deadline.Before(workload2.Deadline)
+	}
+
+	return nodesetutils.ActivePods(o.pods).Less(i, j)
+}
+
 // doPodScale manages NodeSet pod creation and deletion
 // podsToKeep - should be uncordoned and undrained.
 // podsToDelete - should be cordoned and drained, then deleted.
@@ -1234,6 +2042,7 @@ func (r *NodeSetReconciler) newNodeSetPodOrdinal(
 	}
 
 	pod := nodesetutils.NewNodeSetStatefulSetPod(client, nodeset, controller, ordinal, revisionHash)
+	excludeRemediatedNode(nodeset, pod)
 
 	return pod, nil
 }
@@ -1466,6 +2275,20 @@ func (r *NodeSetReconciler) makePodUncordon(ctx context.Context, pod *corev1.Pod
 func (r *NodeSetReconciler) syncPodUncordon(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
 	logger := log.FromContext(ctx)
 
+	// The pod is being remediated, its Slurm node must stay drained
+	if podutils.IsPodRemediation(pod) {
+		logger.V(1).Info("Skipping uncordon for pod pending remediation",
+			"pod", klog.KObj(pod))
+		return nil // Skip
+	}
+
+	// The pod is pending eviction, its Slurm node must stay drained
+	if podutils.IsPodEviction(pod) {
+		logger.V(1).Info("Skipping uncordon for pod pending eviction",
+			"pod", klog.KObj(pod))
+		return nil // Skip
+	}
+
 	// The Kubernetes nodes which the pod is on may have been cordoned
 	if r.isNodeCordoned(ctx, pod) {
 		logger.V(1).Info("Skipping uncordon for pod on externally cordoned node",
@@ -1525,9 +2348,16 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 ) error {
 	logger := log.FromContext(ctx)
 
-	_, oldPods := findUpdatedPods(pods, hash)
+	r.syncRollingUpdateCanary(ctx, nodeset, pods, hash)
+
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	oldPods, _ = splitPartitionPods(nodeset, oldPods)
 
 	unhealthyPods, healthyPods := nodesetutils.SplitUnhealthyPods(oldPods)
+	limit, canaryLimited := canaryUpdateLimit(nodeset, hash, len(newPods))
+	if canaryLimited && len(unhealthyPods) > limit {
+		unhealthyPods = unhealthyPods[:limit]
+	}
 	if len(unhealthyPods) > 0 {
 		logger.Info("Delete unhealthy pods for Rolling Update",
 			"unhealthyPods", len(unhealthyPods))
@@ -1538,7 +2368,14 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 		}
 	}
 
-	podsToDelete, _ := r.splitUpdatePods(ctx, nodeset, healthyPods, hash)
+	updatePods := make([]*corev1.Pod, len(newPods))
+	copy(updatePods, newPods)
+	updatePods = append(updatePods, healthyPods...)
+	podsToDelete, _ := r.splitUpdatePods(ctx, nodeset, updatePods, hash)
+	if canaryLimited {
+		// The canary limit is only applied here. Unhealthy pods are replaced by canary pods too.
+		podsToDelete = podsToDelete[:mathutils.Clamp(limit-len(unhealthyPods), 0, len(podsToDelete))]
+	}
 	if len(podsToDelete) > 0 {
 		logger.Info("Scale-in pods for Rolling Update",
 			"delete", len(podsToDelete))
@@ -1552,6 +2389,123 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 	return nil
 }
 
+// syncRollingUpdateCanary advances the canary stage of the rollout to the
+// update revision, recording its progress in the NodeSet status.
+//
+// The canary pods are the pods of the update revision. Once they are available
+// and IDLE in Slurm (or running jobs), the soak starts; any Slurm node failure
+// of a canary pod restarts it. The canary is promoted after it has soaked for
+// SoakTime, which lifts the canary limit off the rollout.
+func (r *NodeSetReconciler) syncRollingUpdateCanary(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) {
+	logger := log.FromContext(ctx)
+	canary := nodeset.Spec.UpdateStrategy.RollingUpdate.Canary
+
+	if !canary.Enabled {
+		nodeset.Status.Canary = nil
+		return
+	}
+
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	oldPods, _ = splitPartitionPods(nodeset, oldPods)
+
+	status := nodeset.Status.Canary
+	if status == nil || status.Revision != hash {
+		status = &slinkyv1beta1.NodeSetCanaryStatus{
+			Revision: hash,
+		}
+		if len(oldPods) == 0 {
+			// Nothing to roll out, there is no older revision to protect.
+			status.Promoted = true
+		} else {
+			logger.Info("Rolling update canary started",
+				"revision", hash, "canaryReplicas", canary.Replicas)
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateCanaryReason, "RollingUpdate",
+				"Rolling update: updating %d canary pod(s) to revision %s", canary.Replicas, hash)
+		}
+	}
+	nodeset.Status.Canary = status
+	if status.Promoted {
+		return
+	}
+
+	wantCanary := mathutils.Clamp(int(canary.Replicas), 0, len(newPods)+len(oldPods))
+	var canaryReady, canaryFailed int
+	for _, pod := range newPods {
+		switch {
+		case slurmconditions.IsNodeFailed(&pod.Status):
+			canaryFailed++
+		case podutils.IsRunningAndAvailable(pod, nodeset.Spec.MinReadySeconds) &&
+			(slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionIdle) ||
+				slurmconditions.IsNodeBusy(&pod.Status)):
+			canaryReady++
+		}
+	}
+
+	key := objectutils.KeyFunc(nodeset)
+	now := metav1.Now()
+	switch {
+	case canaryFailed > 0:
+		if status.SoakStartTime != nil {
+			logger.Info("Rolling update canary failed in Slurm, restarting soak",
+				"revision", hash, "failed", canaryFailed)
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, RollingUpdateCanaryFailedReason, "RollingUpdate",
+				"Rolling update: %d canary pod(s) of revision %s failed in Slurm, restarting soak", canaryFailed, hash)
+		}
+		status.SoakStartTime = nil
+	case len(newPods) < wantCanary || canaryReady < len(newPods):
+		status.SoakStartTime = nil
+	case status.SoakStartTime == nil:
+		logger.Info("Rolling update canary soaking",
+			"revision", hash, "soakTime", canary.SoakTime.Duration)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateCanaryReason, "RollingUpdate",
+			"Rolling update: soaking %d canary pod(s) of revision %s for %s", canaryReady, hash, canary.SoakTime.Duration)
+		status.SoakStartTime = &now
+		durationStore.Push(key, canary.SoakTime.Duration)
+	case now.Sub(status.SoakStartTime.Time) >= canary.SoakTime.Duration:
+		logger.Info("Rolling update canary promoted", "revision", hash)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateCanaryReason, "RollingUpdate",
+			"Rolling update: canary of revision %s soaked for %s, continuing rollout", hash, canary.SoakTime.Duration)
+		status.Promoted = true
+	default:
+		durationStore.Push(key, canary.SoakTime.Duration-now.Sub(status.SoakStartTime.Time))
+	}
+}
+
+// canaryUpdateLimit returns the number of old pods which may be updated before
+// the canary stage pauses the rollout, and whether the canary limits it.
+func canaryUpdateLimit(nodeset *slinkyv1beta1.NodeSet, hash string, updated int) (int, bool) {
+	canary := nodeset.Spec.UpdateStrategy.RollingUpdate.Canary
+	if !canary.Enabled {
+		return 0, false
+	}
+	if status := nodeset.Status.Canary; status != nil && status.Revision == hash && status.Promoted {
+		return 0, false
+	}
+	return max(int(canary.Replicas)-updated, 0), true
+}
+
+// splitPartitionPods returns the pods which may be updated, and the pods held
+// on their revision by the RollingUpdate partition.
+func splitPartitionPods(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (podsToUpdate, podsToHold []*corev1.Pod) {
+	partition := int(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+	if partition <= 0 || nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+		return pods, nil
+	}
+	for _, pod := range pods {
+		if nodesetutils.GetOrdinal(pod) >= partition {
+			podsToUpdate = append(podsToUpdate, pod)
+		} else {
+			podsToHold = append(podsToHold, pod)
+		}
+	}
+	return podsToUpdate, podsToHold
+}
+
 // splitUpdatePods returns two pod lists based on UpdateStrategy type.
 func (r *NodeSetReconciler) splitUpdatePods(
 	ctx context.Context,
@@ -1566,6 +2520,7 @@ func (r *NodeSetReconciler) splitUpdatePods(
 		fallthrough
 	case slinkyv1beta1.RollingUpdateNodeSetStrategyType:
 		newPods, oldPods := findUpdatedPods(pods, hash)
+		oldPods, heldPods := splitPartitionPods(nodeset, oldPods)
 
 		var numUnavailable int
 		now := metav1.Now()
@@ -1586,10 +2541,12 @@ func (r *NodeSetReconciler) splitUpdatePods(
 		remainingPods := make([]*corev1.Pod, len(newPods))
 		copy(remainingPods, newPods)
 		remainingPods = append(remainingPods, remainingOldPods...)
+		remainingPods = append(remainingPods, heldPods...)
 
 		logger.V(1).Info("calculated pod lists for update",
 			"maxUnavailable", maxUnavailable,
 			"updatePods", len(podsToDelete),
+			"heldPods", len(heldPods),
 			"remainingPods", len(remainingPods))
 		return podsToDelete, remainingPods
 	case slinkyv1beta1.ScheduledUpdateNodeSetStrategyType:
@@ -6,6 +6,7 @@ package nodeset
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +25,7 @@ import (
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -31,6 +33,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 	"github.com/SlinkyProject/slurm-operator/internal/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
@@ -97,8 +100,6 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	collisionCount int32,
 	hash string,
 ) error {
-	logger := log.FromContext(ctx)
-
 	selectorLabels := labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build()
 	selector := k8slabels.SelectorFromSet(k8slabels.Set(selectorLabels))
 
@@ -126,6 +127,12 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 		SlurmAllocated:      slurmNodeStatus.Allocated + slurmNodeStatus.Mixed,
 		SlurmDown:           slurmNodeStatus.Down,
 		SlurmDrain:          slurmNodeStatus.Drain,
+		SlurmPending:        nodeset.Status.SlurmPending,
+		LastScaleTime:       nodeset.Status.LastScaleTime,
+		Revisions:           calculateRevisionStatus(nodeset, pods),
+		StableRevision:      calculateStableRevision(nodeset, pods, hash),
+		Canary:              nodeset.Status.Canary,
+		Remediations:        nodeset.Status.Remediations,
 		ObservedGeneration:  nodeset.Generation,
 		NodeSetHash:         hash,
 		CollisionCount:      &collisionCount,
@@ -134,15 +141,19 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 		Conditions:          []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, nodeset.Status.Conditions...)
+	if nodeset.Spec.UpdateStrategy.Type != slinkyv1beta1.RollingUpdateNodeSetStrategyType ||
+		!nodeset.Spec.UpdateStrategy.RollingUpdate.Canary.Enabled {
+		newStatus.Canary = nil
+	}
+
+	syncRolledBackCondition(nodeset, &newStatus.Conditions, currentRevision, updateRevision)
 
 	if err := r.applyReservationCondition(ctx, nodeset, &newStatus.Conditions); err != nil {
 		return err
 	}
 
-	if apiequality.Semantic.DeepEqual(nodeset.Status, newStatus) {
-		logger.V(2).Info("NodeSet Status has not changed, skipping status update", "status", nodeset.Status)
-		return nil
-	}
+	metrics.SetNodeSetState(client.ObjectKeyFromObject(nodeset),
+		calculateMetricsState(nodeset, pods, slurmNodeStatus, newStatus.Conditions))
 
 	if err := r.updateNodeSetStatus(ctx, nodeset, &newStatus); err != nil {
 		return err
@@ -160,6 +171,62 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	return nil
 }
 
+// calculateMetricsState returns the state of the NodeSet for its metrics.
+func calculateMetricsState(
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	slurmNodeStatus slurmcontrol.SlurmNodeStatus,
+	conditions []metav1.Condition,
+) metrics.NodeSetState {
+	state := metrics.NodeSetState{
+		Controller: nodeset.Spec.ControllerRef.Name,
+		Nodes: map[string]int32{
+			"allocated":      slurmNodeStatus.Allocated,
+			"down":           slurmNodeStatus.Down,
+			"error":          slurmNodeStatus.Error,
+			"future":         slurmNodeStatus.Future,
+			"idle":           slurmNodeStatus.Idle,
+			"mixed":          slurmNodeStatus.Mixed,
+			"unknown":        slurmNodeStatus.Unknown,
+			"completing":     slurmNodeStatus.Completing,
+			"drain":          slurmNodeStatus.Drain,
+			"fail":           slurmNodeStatus.Fail,
+			"invalid":        slurmNodeStatus.Invalid,
+			"invalid_reg":    slurmNodeStatus.InvalidReg,
+			"maintenance":    slurmNodeStatus.Maintenance,
+			"not_responding": slurmNodeStatus.NotResponding,
+			"undrain":        slurmNodeStatus.Undrain,
+		},
+		DrainReasons: map[string]int32{},
+	}
+
+	for _, nodeConditions := range slurmNodeStatus.NodeStates {
+		for _, cond := range nodeConditions {
+			if cond.Type != slurmconditions.PodConditionDrain {
+				continue
+			}
+			if slurmcontrol.IsNodeReasonFormatted(cond.Message) {
+				state.DrainReasons[metrics.DrainReasonOperator]++
+			} else {
+				state.DrainReasons[metrics.DrainReasonOther]++
+			}
+		}
+	}
+
+	// The reservation covers every Slurm node of the NodeSet.
+	if meta.IsStatusConditionTrue(conditions, slurmconditions.NodeSetConditionReservationCreated) {
+		state.ReservedPods = slurmNodeStatus.Total
+	}
+
+	for _, pod := range pods {
+		if podutils.IsPodCordon(pod) && pod.Annotations[slinkyv1beta1.AnnotationPodDeadline] != "" {
+			state.ScaleInPendingPods++
+		}
+	}
+
+	return state
+}
+
 type replicaStatus struct {
 	Replicas    int32
 	Ready       int32
@@ -219,6 +286,40 @@ func (r *NodeSetReconciler) calculateReplicaStatus(
 	return status, nil
 }
 
+// calculateRevisionStatus will calculate the status of the given pods per revision.
+func calculateRevisionStatus(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) []slinkyv1beta1.NodeSetRevisionStatus {
+	revisionStatus := make(map[string]*slinkyv1beta1.NodeSetRevisionStatus)
+
+	now := metav1.Now()
+	for _, pod := range pods {
+		if !podutils.IsCreated(pod) || podutils.IsTerminating(pod) {
+			continue
+		}
+		podHash := historycontrol.GetRevision(pod.GetLabels())
+		status, ok := revisionStatus[podHash]
+		if !ok {
+			status = &slinkyv1beta1.NodeSetRevisionStatus{Revision: podHash}
+			revisionStatus[podHash] = status
+		}
+		status.Replicas++
+		if podutils.IsRunningAndReady(pod) {
+			status.ReadyReplicas++
+			if podutil.IsPodAvailable(pod, nodeset.Spec.MinReadySeconds, now) {
+				status.AvailableReplicas++
+			}
+		}
+	}
+
+	var revisions []slinkyv1beta1.NodeSetRevisionStatus
+	for _, status := range revisionStatus {
+		revisions = append(revisions, *status)
+	}
+	slices.SortFunc(revisions, func(a, b slinkyv1beta1.NodeSetRevisionStatus) int {
+		return strings.Compare(a.Revision, b.Revision)
+	})
+	return revisions
+}
+
 // calculateReservationCondition returns a condition that indicates the status of the reservation
 // associated with the NodeSet.
 //
@@ -476,6 +577,11 @@ func (r *NodeSetReconciler) updateNodeSetStatus(
 			}
 			return err
 		}
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("NodeSet Status has not changed, skipping status update",
+				"nodeset", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
@@ -328,9 +328,12 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 					UpdatedReplicas:   2,
 					Desired:           2,
 					SlurmIdle:         2,
-					NodeSetHash:       "12345",
-					CollisionCount:    ptr.To[int32](0),
-					Selector:          "app.kubernetes.io/instance=foo,app.kubernetes.io/name=slurmd",
+					Revisions: []slinkyv1beta1.NodeSetRevisionStatus{
+						{Revision: hash, Replicas: 2, ReadyReplicas: 2, AvailableReplicas: 2},
+					},
+					NodeSetHash:    "12345",
+					CollisionCount: ptr.To[int32](0),
+					Selector:       "app.kubernetes.io/instance=foo,app.kubernetes.io/name=slurmd",
 				},
 				wantErr: false,
 			}
@@ -386,9 +389,12 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 					Replicas:            2,
 					UnavailableReplicas: 2,
 					Desired:             2,
-					NodeSetHash:         "12345",
-					CollisionCount:      ptr.To[int32](0),
-					Selector:            "app.kubernetes.io/instance=foo,app.kubernetes.io/name=slurmd",
+					Revisions: []slinkyv1beta1.NodeSetRevisionStatus{
+						{Revision: hash, Replicas: 2},
+					},
+					NodeSetHash:    "12345",
+					CollisionCount: ptr.To[int32](0),
+					Selector:       "app.kubernetes.io/instance=foo,app.kubernetes.io/name=slurmd",
 				},
 				wantErr: false,
 			}
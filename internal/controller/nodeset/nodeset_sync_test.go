@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -51,6 +52,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 func newNodeSetController(client client.Client, clientMap *clientmap.ClientMap) *NodeSetReconciler {
@@ -938,6 +940,197 @@ func TestNodeSetReconciler_syncNodeSetPods(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_splitScaleInPods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 3)
+	now := time.Now()
+	pods := []*corev1.Pod{
+		newNodeSetPodWithStatus(nodeset, controller, 0, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+		newNodeSetPodWithStatus(nodeset, controller, 1, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+		newNodeSetPodWithStatus(nodeset, controller, 2, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+		newNodeSetPodWithStatus(nodeset, controller, 3, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+	}
+	newSlurmNode := func(pod *corev1.Pod, state slurmapi.V0044NodeState) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				State: ptr.To([]slurmapi.V0044NodeState{state}),
+			},
+		}
+	}
+	newRunningJob := func(id int32, timeLimit time.Duration, pods ...*corev1.Pod) slurmtypes.V0044JobInfo {
+		slurmNodeNames := []string{}
+		for _, pod := range pods {
+			slurmNodeNames = append(slurmNodeNames, nodesetutils.GetSlurmNodeName(pod))
+		}
+		return slurmtypes.V0044JobInfo{
+			V0044JobInfo: slurmapi.V0044JobInfo{
+				JobId:     ptr.To(id),
+				JobState:  ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+				StartTime: ptr.To(slurmapi.V0044Uint64NoValStruct{Number: ptr.To(now.Unix())}),
+				TimeLimit: ptr.To(slurmapi.V0044Uint32NoValStruct{Number: ptr.To(int32(timeLimit.Minutes()))}),
+				Nodes:     ptr.To(strings.Join(slurmNodeNames, ",")),
+			},
+		}
+	}
+	// pod0: ALLOCATED, running a 3 day job
+	// pod1: IDLE
+	// pod2: MIXED, running a 1 hour job
+	// pod3: MIXED, running a 3 day job
+	nodeList := &slurmtypes.V0044NodeList{
+		Items: []slurmtypes.V0044Node{
+			newSlurmNode(pods[0], slurmapi.V0044NodeStateALLOCATED),
+			newSlurmNode(pods[1], slurmapi.V0044NodeStateIDLE),
+			newSlurmNode(pods[2], slurmapi.V0044NodeStateMIXED),
+			newSlurmNode(pods[3], slurmapi.V0044NodeStateMIXED),
+		},
+	}
+	jobList := &slurmtypes.V0044JobInfoList{
+		Items: []slurmtypes.V0044JobInfo{
+			newRunningJob(1, 72*time.Hour, pods[0]),
+			newRunningJob(2, time.Hour, pods[2]),
+			newRunningJob(3, 72*time.Hour, pods[3]),
+		},
+	}
+	tests := []struct {
+		name             string
+		scaleInStrategy  slinkyv1beta1.NodeSetScaleInStrategyType
+		diff             int
+		wantPodsToDelete []string
+	}{
+		{
+			name:             "Default",
+			scaleInStrategy:  slinkyv1beta1.ScaleInStrategyDefault,
+			diff:             1,
+			wantPodsToDelete: []string{pods[3].Name},
+		},
+		{
+			name:             "JobAware, idle first",
+			scaleInStrategy:  slinkyv1beta1.ScaleInStrategyJobAware,
+			diff:             1,
+			wantPodsToDelete: []string{pods[1].Name},
+		},
+		{
+			name:             "JobAware, earlier deadline first",
+			scaleInStrategy:  slinkyv1beta1.ScaleInStrategyJobAware,
+			diff:             2,
+			wantPodsToDelete: []string{pods[1].Name, pods[2].Name},
+		},
+		{
+			name:             "JobAware, allocated last",
+			scaleInStrategy:  slinkyv1beta1.ScaleInStrategyJobAware,
+			diff:             3,
+			wantPodsToDelete: []string{pods[1].Name, pods[2].Name, pods[3].Name},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := nodeset.DeepCopy()
+			nodeset.Spec.ScaleInStrategy = tt.scaleInStrategy
+			sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList, jobList)
+			r := newNodeSetController(fake.NewFakeClient(), newClientMap(controller.Name, sclient))
+			podsToDelete, podsToKeep, err := r.splitScaleInPods(context.Background(), nodeset, slices.Clone(pods), tt.diff)
+			if err != nil {
+				t.Fatalf("splitScaleInPods() error = %v", err)
+			}
+			got := []string{}
+			for _, pod := range podsToDelete {
+				got = append(got, pod.Name)
+			}
+			if diff := cmp.Diff(tt.wantPodsToDelete, got); diff != "" {
+				t.Errorf("splitScaleInPods() podsToDelete (-want,+got):\n%s", diff)
+			}
+			if len(podsToDelete)+len(podsToKeep) != len(pods) {
+				t.Errorf("splitScaleInPods() got %d pods, want %d", len(podsToDelete)+len(podsToKeep), len(pods))
+			}
+		})
+	}
+}
+func TestNodeSetReconciler_splitPowerSavePods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 0)
+	nodeset.Spec.PowerSave = slinkyv1beta1.NodeSetPowerSave{
+		Enabled:  true,
+		MaxNodes: 4,
+	}
+	pods := []*corev1.Pod{
+		newNodeSetPodWithStatus(nodeset, controller, 0, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+		newNodeSetPodWithStatus(nodeset, controller, 1, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+		newNodeSetPodWithStatus(nodeset, controller, 5, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady}),
+	}
+	newSlurmNode := func(ordinal int, states ...slurmapi.V0044NodeState) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To(nodesetutils.GetOrdinalSlurmNodeName(nodeset, ordinal)),
+				State: ptr.To(states),
+			},
+		}
+	}
+	// foo-0: running a job
+	// foo-1: suspended by slurmctld
+	// foo-2: resumed by slurmctld
+	// foo-3: powered down
+	nodeList := &slurmtypes.V0044NodeList{
+		Items: []slurmtypes.V0044Node{
+			newSlurmNode(0, slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateCLOUD),
+			newSlurmNode(1, slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateCLOUD, slurmapi.V0044NodeStatePOWERINGDOWN),
+			newSlurmNode(2, slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateCLOUD, slurmapi.V0044NodeStatePOWERINGUP),
+			newSlurmNode(3, slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateCLOUD, slurmapi.V0044NodeStatePOWEREDDOWN),
+		},
+	}
+	getPodNames := func(pods []*corev1.Pod) []string {
+		names := []string{}
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	t.Run("power states", func(t *testing.T) {
+		sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+		r := newNodeSetController(fake.NewFakeClient(controller.DeepCopy()), newClientMap(controller.Name, sclient))
+		podsToCreate, podsToSuspend, podsToDelete, ok, err := r.splitPowerSavePods(context.Background(), nodeset, pods, "")
+		if err != nil {
+			t.Fatalf("splitPowerSavePods() error = %v", err)
+		}
+		if !ok {
+			t.Fatalf("splitPowerSavePods() ok = %v, want true", ok)
+		}
+		if diff := cmp.Diff([]string{"foo-2"}, getPodNames(podsToCreate)); diff != "" {
+			t.Errorf("splitPowerSavePods() podsToCreate (-want,+got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"foo-1"}, getPodNames(podsToSuspend)); diff != "" {
+			t.Errorf("splitPowerSavePods() podsToSuspend (-want,+got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"foo-5"}, getPodNames(podsToDelete)); diff != "" {
+			t.Errorf("splitPowerSavePods() podsToDelete (-want,+got):\n%s", diff)
+		}
+	})
+
+	t.Run("no client", func(t *testing.T) {
+		r := newNodeSetController(fake.NewFakeClient(controller.DeepCopy()), clientmap.NewClientMap())
+		_, podsToSuspend, _, ok, err := r.splitPowerSavePods(context.Background(), nodeset, pods, "")
+		if err != nil {
+			t.Fatalf("splitPowerSavePods() error = %v", err)
+		}
+		if ok {
+			t.Errorf("splitPowerSavePods() ok = %v, want false", ok)
+		}
+		if len(podsToSuspend) != 0 {
+			t.Errorf("splitPowerSavePods() podsToSuspend = %v, want none", getPodNames(podsToSuspend))
+		}
+	})
+}
+
 func TestNodeSetReconciler_processCondemned(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1767,6 +1960,488 @@ func TestNodeSetReconciler_syncCordon(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncTermination(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+	}
+	nodeset := newNodeSet("nodeset-a", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	pod.Spec.NodeName = "kube-node-1"
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+
+	newKubeNode := func(taints []corev1.Taint, conditions []corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-node-1"},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status:     corev1.NodeStatus{Conditions: conditions},
+		}
+	}
+	newSlurmNodeList := func(reason string, states ...slurmapi.V0044NodeState) *slurmtypes.V0044NodeList {
+		return &slurmtypes.V0044NodeList{
+			Items: []slurmtypes.V0044Node{
+				{
+					V0044Node: slurmapi.V0044Node{
+						Name:   ptr.To(slurmNodeName),
+						State:  ptr.To(states),
+						Reason: ptr.To(reason),
+					},
+				},
+			},
+		}
+	}
+	downCondition := func(reason string) []corev1.PodCondition {
+		return []corev1.PodCondition{
+			{Type: slurmconditions.PodConditionDown, Status: corev1.ConditionTrue, Message: reason},
+		}
+	}
+	signals := TerminationSignals{
+		Taints:     []string{"aws-node-termination-handler/spot-itn"},
+		Conditions: []corev1.NodeConditionType{"TerminationNotice"},
+	}
+
+	tests := []struct {
+		name           string
+		kubeNode       *corev1.Node
+		slurmNodeList  *slurmtypes.V0044NodeList
+		podConditions  []corev1.PodCondition
+		signals        TerminationSignals
+		wantPodDeleted bool
+		wantSlurmDrain bool
+		wantSlurmDown  bool
+		wantReasonSub  string
+	}{
+		{
+			name:          "No termination signals configured",
+			kubeNode:      newKubeNode([]corev1.Taint{{Key: "aws-node-termination-handler/spot-itn"}}, nil),
+			slurmNodeList: newSlurmNodeList("", slurmapi.V0044NodeStateALLOCATED),
+		},
+		{
+			name:          "Kubernetes node is not terminating",
+			kubeNode:      newKubeNode([]corev1.Taint{{Key: "example.com/other"}}, nil),
+			slurmNodeList: newSlurmNodeList("", slurmapi.V0044NodeStateALLOCATED),
+			signals:       signals,
+		},
+		{
+			name:           "Termination taint replaces pod",
+			kubeNode:       newKubeNode([]corev1.Taint{{Key: "aws-node-termination-handler/spot-itn"}}, nil),
+			slurmNodeList:  newSlurmNodeList("", slurmapi.V0044NodeStateALLOCATED),
+			signals:        signals,
+			wantPodDeleted: true,
+			wantSlurmDrain: true,
+			wantSlurmDown:  true,
+			wantReasonSub:  "taint aws-node-termination-handler/spot-itn",
+		},
+		{
+			name: "Termination condition replaces pod",
+			kubeNode: newKubeNode(nil, []corev1.NodeCondition{
+				{Type: "TerminationNotice", Status: corev1.ConditionTrue},
+			}),
+			slurmNodeList:  newSlurmNodeList("", slurmapi.V0044NodeStateALLOCATED),
+			signals:        signals,
+			wantPodDeleted: true,
+			wantSlurmDrain: true,
+			wantSlurmDown:  true,
+			wantReasonSub:  "condition TerminationNotice",
+		},
+		{
+			name: "False termination condition is ignored",
+			kubeNode: newKubeNode(nil, []corev1.NodeCondition{
+				{Type: "TerminationNotice", Status: corev1.ConditionFalse},
+			}),
+			slurmNodeList: newSlurmNodeList("", slurmapi.V0044NodeStateALLOCATED),
+			signals:       signals,
+		},
+		{
+			name:     "Replacement pod resumes Slurm node set down by the operator",
+			kubeNode: newKubeNode(nil, nil),
+			slurmNodeList: newSlurmNodeList(slurmcontrol.FormatNodeReason("Node (kube-node-0) is terminating"),
+				slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDOWN, slurmapi.V0044NodeStateDRAIN),
+			podConditions: downCondition(slurmcontrol.FormatNodeReason("Node (kube-node-0) is terminating")),
+			signals:       signals,
+		},
+		{
+			name:     "Slurm node set down by the operator for another reason is not resumed",
+			kubeNode: newKubeNode(nil, nil),
+			slurmNodeList: newSlurmNodeList(slurmcontrol.FormatNodeReason("remediation"),
+				slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDOWN),
+			podConditions: downCondition(slurmcontrol.FormatNodeReason("remediation")),
+			signals:       signals,
+			wantSlurmDown: true,
+			wantReasonSub: "remediation",
+		},
+		{
+			name:          "Slurm node set down externally is not resumed",
+			kubeNode:      newKubeNode(nil, nil),
+			slurmNodeList: newSlurmNodeList("admin", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDOWN),
+			podConditions: downCondition("admin"),
+			signals:       signals,
+			wantSlurmDown: true,
+			wantReasonSub: "admin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pod := pod.DeepCopy()
+			pod.Status.Conditions = tt.podConditions
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, tt.slurmNodeList)
+			clientMap := newClientMap(controller.Name, slurmClient)
+			k8sClient := fake.NewFakeClient(nodeset.DeepCopy(), pod.DeepCopy(), tt.kubeNode.DeepCopy())
+			r := newNodeSetController(k8sClient, clientMap)
+			r.terminationSignals = tt.signals
+
+			if err := r.syncTermination(ctx, nodeset.DeepCopy(), []*corev1.Pod{pod.DeepCopy()}); err != nil {
+				t.Fatalf("syncTermination() error = %v", err)
+			}
+
+			err := r.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			if got := apierrors.IsNotFound(err); got != tt.wantPodDeleted {
+				t.Errorf("pod deleted = %v, want %v", got, tt.wantPodDeleted)
+			}
+
+			gotNode := &slurmtypes.V0044Node{}
+			if err := slurmClient.Get(ctx, slurmclient.ObjectKey(slurmNodeName), gotNode); err != nil {
+				t.Fatalf("slurm Get node: %v", err)
+			}
+			if got := gotNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDRAIN); got != tt.wantSlurmDrain {
+				t.Errorf("Slurm node DRAIN = %v, want %v", got, tt.wantSlurmDrain)
+			}
+			if got := gotNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN); got != tt.wantSlurmDown {
+				t.Errorf("Slurm node DOWN = %v, want %v", got, tt.wantSlurmDown)
+			}
+			if reason := ptr.Deref(gotNode.Reason, ""); !strings.Contains(reason, tt.wantReasonSub) {
+				t.Errorf("Slurm node Reason = %q, want substring %q", reason, tt.wantReasonSub)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncRemediation(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	newRemediationNodeSet := func(enabled bool) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 2)
+		nodeset.Spec.Remediation = slinkyv1beta1.NodeSetRemediation{
+			Enabled:           enabled,
+			Conditions:        []corev1.PodConditionType{slurmconditions.PodConditionNotResponding},
+			UnhealthyDuration: metav1.Duration{Duration: 5 * time.Minute},
+			MaxUnhealthy:      ptr.To(intstr.FromString("50%")),
+			MaxRemediations:   1,
+			Window:            metav1.Duration{Duration: 10 * time.Minute},
+		}
+		return nodeset
+	}
+	newPod := func(nodeset *slinkyv1beta1.NodeSet, ordinal int, unhealthyFor time.Duration) *corev1.Pod {
+		pod := newNodeSetPodWithStatus(nodeset, controller, ordinal, corev1.PodRunning, []corev1.PodConditionType{corev1.PodReady})
+		pod.Spec.NodeName = "node-" + strconv.Itoa(ordinal)
+		if unhealthyFor > 0 {
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:               slurmconditions.PodConditionNotResponding,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-unhealthyFor)),
+			})
+		}
+		return pod
+	}
+	newSlurmNodeList := func(pods ...*corev1.Pod) *slurmtypes.V0044NodeList {
+		nodeList := &slurmtypes.V0044NodeList{}
+		for _, pod := range pods {
+			nodeList.Items = append(nodeList.Items, slurmtypes.V0044Node{
+				V0044Node: slurmapi.V0044Node{
+					Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+				},
+			})
+		}
+		return nodeList
+	}
+	newRunningJob := func(pod *corev1.Pod) *slurmtypes.V0044JobInfoList {
+		return &slurmtypes.V0044JobInfoList{
+			Items: []slurmtypes.V0044JobInfo{
+				{
+					V0044JobInfo: slurmapi.V0044JobInfo{
+						JobId:     ptr.To[int32](1),
+						JobState:  ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+						StartTime: ptr.To(slurmapi.V0044Uint64NoValStruct{Number: ptr.To(now.Unix())}),
+						TimeLimit: ptr.To(slurmapi.V0044Uint32NoValStruct{Number: ptr.To[int32](60)}),
+						Nodes:     ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					},
+				},
+			},
+		}
+	}
+	type args struct {
+		nodeset    *slinkyv1beta1.NodeSet
+		pods       []*corev1.Pod
+		jobs       *slurmtypes.V0044JobInfoList
+		controller *slinkyv1beta1.Controller
+	}
+	type testCaseFields struct {
+		name             string
+		args             args
+		wantRemediating  []string
+		wantDeleted      []string
+		wantRemediations int
+	}
+	tests := []testCaseFields{
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			return testCaseFields{
+				name: "healthy",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, 0), newPod(nodeset, 1, 0)},
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			return testCaseFields{
+				name: "unhealthy within duration",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, time.Minute), newPod(nodeset, 1, 0)},
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			failover := controller.DeepCopy()
+			failover.Status.Conditions = []metav1.Condition{
+				{
+					Type:               slurmconditions.ControllerConditionFailover,
+					Status:             metav1.ConditionTrue,
+					Reason:             "BackupActive",
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+				},
+			}
+			return testCaseFields{
+				name: "unhealthy before failover",
+				args: args{
+					nodeset:    nodeset,
+					pods:       []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, 0)},
+					controller: failover,
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(false)
+			return testCaseFields{
+				name: "disabled",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, 0)},
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			return testCaseFields{
+				name: "unhealthy without jobs",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, 0)},
+				},
+				wantDeleted:      []string{"foo-0"},
+				wantRemediations: 1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			pods := []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, 0)}
+			return testCaseFields{
+				name: "unhealthy with running job",
+				args: args{
+					nodeset: nodeset,
+					pods:    pods,
+					jobs:    newRunningJob(pods[0]),
+				},
+				wantRemediating:  []string{"foo-0"},
+				wantRemediations: 1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			nodeset.Spec.Remediation.MaxUnhealthy = ptr.To(intstr.FromInt32(2))
+			nodeset.Status.Remediations = []slinkyv1beta1.NodeSetRemediationStatus{
+				{Pod: "foo-2", Node: "node-2", StartTime: metav1.NewTime(now.Add(-time.Minute))},
+				{Pod: "foo-3", Node: "node-3", StartTime: metav1.NewTime(now.Add(-time.Hour))},
+			}
+			return testCaseFields{
+				name: "rate limited",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, 0)},
+				},
+				wantRemediations: 1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(true)
+			nodeset.Spec.Remediation.MaxRemediations = 2
+			return testCaseFields{
+				name: "paused, too many unhealthy",
+				args: args{
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{newPod(nodeset, 0, time.Hour), newPod(nodeset, 1, time.Hour)},
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newRemediationNodeSet(false)
+			pods := []*corev1.Pod{newPod(nodeset, 0, 0), newPod(nodeset, 1, 0)}
+			pods[0].Annotations[slinkyv1beta1.AnnotationPodRemediation] = now.Format(time.RFC3339)
+			nodeset.Status.Remediations = []slinkyv1beta1.NodeSetRemediationStatus{
+				{Pod: "foo-0", Node: "node-0", StartTime: metav1.NewTime(now.Add(-time.Hour))},
+			}
+			return testCaseFields{
+				name: "remediation in progress completes",
+				args: args{
+					nodeset: nodeset,
+					pods:    pods,
+				},
+				wantDeleted:      []string{"foo-0"},
+				wantRemediations: 1,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podList := &corev1.PodList{
+				Items: structutils.DereferenceList(tt.args.pods),
+			}
+			slurmObjects := []slurmobject.ObjectList{newSlurmNodeList(tt.args.pods...)}
+			if tt.args.jobs != nil {
+				slurmObjects = append(slurmObjects, tt.args.jobs)
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmObjects...)
+			kubeObjects := []runtime.Object{tt.args.nodeset, podList}
+			if tt.args.controller != nil {
+				kubeObjects = append(kubeObjects, tt.args.controller)
+			}
+			r := newNodeSetController(fake.NewFakeClient(kubeObjects...), newClientMap(controller.Name, slurmClient))
+			if err := r.syncRemediation(context.TODO(), tt.args.nodeset, tt.args.pods); err != nil {
+				t.Fatalf("NodeSetReconciler.syncRemediation() error = %v", err)
+			}
+			for _, pod := range tt.args.pods {
+				wantDeleted := slices.Contains(tt.wantDeleted, pod.Name)
+				wantRemediating := slices.Contains(tt.wantRemediating, pod.Name)
+				got := &corev1.Pod{}
+				err := r.Get(context.TODO(), client.ObjectKeyFromObject(pod), got)
+				if gotDeleted := apierrors.IsNotFound(err); gotDeleted != wantDeleted {
+					t.Errorf("pod %s deleted = %v, want %v", pod.Name, gotDeleted, wantDeleted)
+				}
+				if err != nil {
+					continue
+				}
+				if gotRemediating := podutils.IsPodRemediation(got); gotRemediating != wantRemediating {
+					t.Errorf("pod %s remediating = %v, want %v", pod.Name, gotRemediating, wantRemediating)
+				}
+				if wantRemediating {
+					if isDrain, err := r.slurmControl.IsNodeDrain(context.TODO(), tt.args.nodeset, got); err != nil {
+						t.Errorf("slurmControl.IsNodeDrain() error = %v", err)
+					} else if !isDrain {
+						t.Errorf("pod %s Slurm node is not drained", pod.Name)
+					}
+				}
+			}
+			if got := len(tt.args.nodeset.Status.Remediations); got != tt.wantRemediations {
+				t.Errorf("NodeSet remediations = %v, want %v", tt.args.nodeset.Status.Remediations, tt.wantRemediations)
+			}
+		})
+	}
+}
+
+func Test_excludeRemediatedNode(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	excluded := corev1.NodeSelectorRequirement{
+		Key:      metav1.ObjectNameField,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"node-0"},
+	}
+	tests := []struct {
+		name     string
+		enabled  bool
+		affinity *corev1.Affinity
+		want     *corev1.Affinity
+	}{
+		{
+			name:    "disabled",
+			enabled: false,
+		},
+		{
+			name:    "without affinity",
+			enabled: true,
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchFields: []corev1.NodeSelectorRequirement{excluded}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "with node affinity",
+			enabled: true,
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "a", Operator: corev1.NodeSelectorOpExists}}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "b", Operator: corev1.NodeSelectorOpExists}}},
+						},
+					},
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "a", Operator: corev1.NodeSelectorOpExists}},
+								MatchFields:      []corev1.NodeSelectorRequirement{excluded},
+							},
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "b", Operator: corev1.NodeSelectorOpExists}},
+								MatchFields:      []corev1.NodeSelectorRequirement{excluded},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.Remediation.Enabled = true
+			nodeset.Spec.Remediation.RecreateOnDifferentNode = tt.enabled
+			nodeset.Status.Remediations = []slinkyv1beta1.NodeSetRemediationStatus{
+				{Pod: "foo-0", Node: "node-0"},
+			}
+			pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+			pod.Spec.Affinity = tt.affinity
+			excludeRemediatedNode(nodeset, pod)
+			if !apiequality.Semantic.DeepEqual(pod.Spec.Affinity, tt.want) {
+				t.Errorf("excludeRemediatedNode() affinity = %v, want %v", pod.Spec.Affinity, tt.want)
+			}
+		})
+	}
+}
+
 func TestNodeSetReconciler_doPodProcessing(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2920,54 +3595,334 @@ func TestNodeSetReconciler_syncRollingUpdate(t *testing.T) {
 				args: args{
 					ctx:     context.TODO(),
 					nodeset: nodeset,
-					pods:    []*corev1.Pod{pod1, pod2},
-					hash:    hash,
+					pods:    []*corev1.Pod{pod1, pod2},
+					hash:    hash,
+				},
+				wantErr: false,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				MaxUnavailable: ptr.To(intstr.FromString("10%")),
+			}
+			pod1 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+			makePodHealthy(pod1)
+			pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
+			k8sclient := fake.NewFakeClient(nodeset, pod1, pod2)
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod1)),
+							State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+						},
+					},
+				},
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			return testCaseFields{
+				name: "update, with unhealthy",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pods:    []*corev1.Pod{pod1, pod2},
+					hash:    hash,
+				},
+				wantErr: false,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
+			if err := r.syncRollingUpdate(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash); (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncRollingUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncRollingUpdate_canaryLimit(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	const hash = "12345"
+	newCanaryNodeSet := func(status *slinkyv1beta1.NodeSetCanaryStatus) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 4)
+		nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+		nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+			MaxUnavailable: ptr.To(intstr.FromString("100%")),
+			Canary: slinkyv1beta1.NodeSetCanary{
+				Enabled:  true,
+				Replicas: 2,
+				SoakTime: metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}
+		nodeset.Status.Canary = status
+		return nodeset
+	}
+	tests := []struct {
+		name        string
+		nodeset     *slinkyv1beta1.NodeSet
+		newPods     int
+		unhealthy   int
+		wantUpdated int
+	}{
+		{
+			name:        "canary started",
+			nodeset:     newCanaryNodeSet(nil),
+			wantUpdated: 2,
+		},
+		{
+			name:        "canary partially updated",
+			nodeset:     newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash}),
+			newPods:     1,
+			wantUpdated: 1,
+		},
+		{
+			name:        "canary with unhealthy pod",
+			nodeset:     newCanaryNodeSet(nil),
+			unhealthy:   1,
+			wantUpdated: 2,
+		},
+		{
+			name:        "canary updated, soaking",
+			nodeset:     newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash}),
+			newPods:     2,
+			wantUpdated: 0,
+		},
+		{
+			name:        "canary promoted",
+			nodeset:     newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash, Promoted: true}),
+			newPods:     2,
+			wantUpdated: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := tt.nodeset
+			pods := make([]*corev1.Pod, 0, 4)
+			objs := []client.Object{nodeset}
+			slurmNodeList := &slurmtypes.V0044NodeList{}
+			for i := range 4 {
+				revision := ""
+				if i < tt.newPods {
+					revision = hash
+				}
+				pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, i, revision)
+				if i < tt.newPods || i >= tt.newPods+tt.unhealthy {
+					makePodHealthy(pod)
+				}
+				pods = append(pods, pod)
+				objs = append(objs, pod)
+				slurmNodeList.Items = append(slurmNodeList.Items, slurmtypes.V0044Node{
+					V0044Node: slurmapi.V0044Node{
+						Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+					},
+				})
+			}
+			k8sclient := fake.NewClientBuilder().WithObjects(objs...).Build()
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			r := newNodeSetController(k8sclient, newClientMap(controller.Name, slurmClient))
+			if err := r.syncRollingUpdate(context.TODO(), nodeset, pods, hash); err != nil {
+				t.Fatalf("NodeSetReconciler.syncRollingUpdate() error = %v", err)
+			}
+
+			// An updated pod is either deleted, or cordoned while its Slurm node drains.
+			var gotUpdated int
+			for _, pod := range pods {
+				got := &corev1.Pod{}
+				if err := k8sclient.Get(context.TODO(), client.ObjectKeyFromObject(pod), got); err != nil {
+					if apierrors.IsNotFound(err) {
+						gotUpdated++
+						continue
+					}
+					t.Fatalf("Get() error = %v", err)
+				}
+				if podutils.IsPodCordon(got) || podutils.IsTerminating(got) {
+					gotUpdated++
+				}
+			}
+			if gotUpdated != tt.wantUpdated {
+				t.Errorf("updated pods = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncRollingUpdateCanary(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	const hash = "12345"
+	newCanaryNodeSet := func(status *slinkyv1beta1.NodeSetCanaryStatus) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 2)
+		nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+		nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+			MaxUnavailable: ptr.To(intstr.FromString("100%")),
+			Canary: slinkyv1beta1.NodeSetCanary{
+				Enabled:  true,
+				Replicas: 1,
+				SoakTime: metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}
+		nodeset.Status.Canary = status
+		return nodeset
+	}
+	newCanaryPod := func(nodeset *slinkyv1beta1.NodeSet, ordinal int, hash string, state corev1.PodConditionType) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, ordinal, hash))
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:   state,
+			Status: corev1.ConditionTrue,
+		})
+		return pod
+	}
+	soakStart := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	soaked := metav1.NewTime(time.Now().Add(-15 * time.Minute))
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	type testCaseFields struct {
+		name        string
+		args        args
+		wantNil     bool
+		wantSoaking bool
+		wantPromote bool
+	}
+	tests := []testCaseFields{
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Status.Canary = &slinkyv1beta1.NodeSetCanaryStatus{Revision: hash}
+			return testCaseFields{
+				name: "Disabled",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, "", slurmconditions.PodConditionIdle),
+					},
+				},
+				wantNil: true,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newCanaryNodeSet(nil)
+			return testCaseFields{
+				name: "No old pods",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, hash, slurmconditions.PodConditionIdle),
+						newCanaryPod(nodeset, 1, hash, slurmconditions.PodConditionIdle),
+					},
+				},
+				wantPromote: true,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: "old", Promoted: true})
+			return testCaseFields{
+				name: "New revision",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, "old", slurmconditions.PodConditionIdle),
+						newCanaryPod(nodeset, 1, "old", slurmconditions.PodConditionIdle),
+					},
+				},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash})
+			return testCaseFields{
+				name: "Canary idle, start soak",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, hash, slurmconditions.PodConditionIdle),
+						newCanaryPod(nodeset, 1, "old", slurmconditions.PodConditionIdle),
+					},
+				},
+				wantSoaking: true,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash, SoakStartTime: &soakStart})
+			return testCaseFields{
+				name: "Canary allocated, still soaking",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, hash, slurmconditions.PodConditionAllocated),
+						newCanaryPod(nodeset, 1, "old", slurmconditions.PodConditionIdle),
+					},
 				},
-				wantErr: false,
+				wantSoaking: true,
 			}
 		}(),
 		func() testCaseFields {
-			nodeset := newNodeSet("foo", controller.Name, 2)
-			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
-			nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
-				MaxUnavailable: ptr.To(intstr.FromString("10%")),
-			}
-			pod1 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
-			makePodHealthy(pod1)
-			pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
-			k8sclient := fake.NewFakeClient(nodeset, pod1, pod2)
-			slurmNodeList := &slurmtypes.V0044NodeList{
-				Items: []slurmtypes.V0044Node{
-					{
-						V0044Node: slurmapi.V0044Node{
-							Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod1)),
-							State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
-						},
+			nodeset := newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash, SoakStartTime: &soakStart})
+			return testCaseFields{
+				name: "Canary down, restart soak",
+				args: args{
+					nodeset: nodeset,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, hash, slurmconditions.PodConditionDown),
+						newCanaryPod(nodeset, 1, "old", slurmconditions.PodConditionIdle),
 					},
 				},
 			}
-			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+		}(),
+		func() testCaseFields {
+			nodeset := newCanaryNodeSet(&slinkyv1beta1.NodeSetCanaryStatus{Revision: hash, SoakStartTime: &soaked})
 			return testCaseFields{
-				name: "update, with unhealthy",
-				fields: fields{
-					Client:    k8sclient,
-					ClientMap: newClientMap(controller.Name, slurmClient),
-				},
+				name: "Canary soaked, promote",
 				args: args{
-					ctx:     context.TODO(),
 					nodeset: nodeset,
-					pods:    []*corev1.Pod{pod1, pod2},
-					hash:    hash,
+					pods: []*corev1.Pod{
+						newCanaryPod(nodeset, 0, hash, slurmconditions.PodConditionIdle),
+						newCanaryPod(nodeset, 1, "old", slurmconditions.PodConditionIdle),
+					},
 				},
-				wantErr: false,
+				wantSoaking: true,
+				wantPromote: true,
 			}
 		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
-			if err := r.syncRollingUpdate(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash); (err != nil) != tt.wantErr {
-				t.Errorf("NodeSetReconciler.syncRollingUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			r := newNodeSetController(fake.NewFakeClient(), nil)
+			r.syncRollingUpdateCanary(context.TODO(), tt.args.nodeset, tt.args.pods, hash)
+
+			got := tt.args.nodeset.Status.Canary
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("Status.Canary = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("Status.Canary = nil, want non-nil")
+			}
+			if got.Revision != hash {
+				t.Errorf("Status.Canary.Revision = %v, want %v", got.Revision, hash)
+			}
+			if soaking := got.SoakStartTime != nil; soaking != tt.wantSoaking {
+				t.Errorf("Status.Canary.SoakStartTime = %v, want soaking %v", got.SoakStartTime, tt.wantSoaking)
+			}
+			if got.Promoted != tt.wantPromote {
+				t.Errorf("Status.Canary.Promoted = %v, want %v", got.Promoted, tt.wantPromote)
 			}
 		})
 	}
@@ -3187,13 +4142,14 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 		pods    []*corev1.Pod
 		hash    string
 	}
-	tests := []struct {
+	type testCaseFields struct {
 		name             string
 		fields           fields
 		args             args
 		wantPodsToDelete []string
 		wantPodsToKeep   []string
-	}{
+	}
+	tests := []testCaseFields{
 		{
 			name: "OnDelete",
 			fields: fields{
@@ -3293,6 +4249,32 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 			wantPodsToDelete: []string{},
 			wantPodsToKeep:   []string{"pod-0", "pod-1"},
 		},
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 4)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				MaxUnavailable: ptr.To(intstr.FromString("100%")),
+				Partition:      ptr.To[int32](2),
+			}
+			pods := make([]*corev1.Pod, 4)
+			for i := range pods {
+				pods[i] = makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, i, ""))
+			}
+			return testCaseFields{
+				name: "RollingUpdate, partition",
+				fields: fields{
+					Client: fake.NewFakeClient(),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pods:    pods,
+					hash:    hash,
+				},
+				wantPodsToDelete: []string{pods[2].Name, pods[3].Name},
+				wantPodsToKeep:   []string{pods[0].Name, pods[1].Name},
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3760,6 +4742,44 @@ func Test_syncPodUncordon(t *testing.T) {
 			wantPodCordoned:      true,
 			wantSlurmNodeDrained: true,
 		},
+		func() testCaseFields {
+			pod := pod.DeepCopy()
+			pod.Annotations[slinkyv1beta1.AnnotationPodEviction] = time.Now().Format(time.RFC3339)
+			return testCaseFields{
+				name: "skip - pod not uncordoned when pending eviction",
+				fields: fields{
+					Client: fake.NewFakeClient(
+						nodeset.DeepCopy(),
+						pod.DeepCopy(),
+					),
+					ClientMap: func() *clientmap.ClientMap {
+						nodeList := &slurmtypes.V0044NodeList{
+							Items: []slurmtypes.V0044Node{
+								{
+									V0044Node: slurmapi.V0044Node{
+										Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+										State: ptr.To([]slurmapi.V0044NodeState{
+											slurmapi.V0044NodeStateIDLE,
+											slurmapi.V0044NodeStateDRAIN,
+										}),
+									},
+								},
+							},
+						}
+						sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+						return newClientMap(controller.Name, sclient)
+					}(),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset.DeepCopy(),
+					pod:     pod.DeepCopy(),
+				},
+				wantErr:              false,
+				wantPodCordoned:      true,
+				wantSlurmNodeDrained: true,
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3787,6 +4807,134 @@ func Test_syncPodUncordon(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncEviction(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	newEvictionPod := func(evictionTime time.Time) *corev1.Pod {
+		pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+		pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+		pod.Annotations[slinkyv1beta1.AnnotationPodEviction] = evictionTime.Format(time.RFC3339)
+		return pod
+	}
+	tests := []struct {
+		name                 string
+		pod                  *corev1.Pod
+		wantPodEviction      bool
+		wantPodCordoned      bool
+		wantSlurmNodeDrained bool
+	}{
+		{
+			name:                 "recent eviction keeps the pod cordoned",
+			pod:                  newEvictionPod(time.Now()),
+			wantPodEviction:      true,
+			wantPodCordoned:      true,
+			wantSlurmNodeDrained: true,
+		},
+		{
+			name:                 "abandoned eviction uncordons the pod",
+			pod:                  newEvictionPod(time.Now().Add(-2 * PodEvictionTimeout)),
+			wantPodEviction:      false,
+			wantPodCordoned:      false,
+			wantSlurmNodeDrained: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			k8sclient := fake.NewFakeClient(nodeset.DeepCopy(), tt.pod.DeepCopy())
+			nodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name: ptr.To(nodesetutils.GetSlurmNodeName(tt.pod)),
+							State: ptr.To([]slurmapi.V0044NodeState{
+								slurmapi.V0044NodeStateIDLE,
+								slurmapi.V0044NodeStateDRAIN,
+							}),
+							Reason: ptr.To(slurmcontrol.FormatNodeReason("Pod (default/foo-0) is pending eviction")),
+						},
+					},
+				},
+			}
+			sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+			r := newNodeSetController(k8sclient, newClientMap(controller.Name, sclient))
+			if err := r.syncEviction(ctx, nodeset.DeepCopy(), []*corev1.Pod{tt.pod.DeepCopy()}); err != nil {
+				t.Fatalf("syncEviction() failed: %v", err)
+			}
+
+			gotPod := &corev1.Pod{}
+			if err := k8sclient.Get(ctx, client.ObjectKeyFromObject(tt.pod), gotPod); err != nil {
+				t.Fatalf("Get() pod failed: %v", err)
+			}
+			if got := podutils.IsPodEviction(gotPod); got != tt.wantPodEviction {
+				t.Errorf("pod eviction state after syncEviction() = %v, want %v", got, tt.wantPodEviction)
+			}
+			if got := podutils.IsPodCordon(gotPod); got != tt.wantPodCordoned {
+				t.Errorf("pod cordon state after syncEviction() = %v, want %v", got, tt.wantPodCordoned)
+			}
+
+			gotDrain, err := r.slurmControl.IsNodeDrain(ctx, nodeset, tt.pod)
+			if err != nil {
+				t.Fatalf("IsNodeDrain() failed: %v", err)
+			}
+			if gotDrain != tt.wantSlurmNodeDrained {
+				t.Errorf("slurm node DRAIN state after syncEviction() = %v, want %v", gotDrain, tt.wantSlurmNodeDrained)
+			}
+		})
+	}
+}
+
+func Test_nodeLabelFeatures(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-0",
+			Labels: map[string]string{
+				corev1.LabelInstanceTypeStable: "m5.xlarge",
+				corev1.LabelTopologyZone:       "us-east-1a",
+				"example.com/cpu":              "intel|icelake",
+				"example.com/empty":            "",
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		labelKeys []string
+		want      []string
+	}{
+		{
+			name:      "No label keys",
+			labelKeys: nil,
+			want:      []string{},
+		},
+		{
+			name:      "Label values in order",
+			labelKeys: []string{corev1.LabelTopologyZone, corev1.LabelInstanceTypeStable},
+			want:      []string{"us-east-1a", "m5.xlarge"},
+		},
+		{
+			name:      "Missing and empty labels are skipped",
+			labelKeys: []string{"example.com/missing", "example.com/empty"},
+			want:      []string{},
+		},
+		{
+			name:      "Invalid characters are replaced",
+			labelKeys: []string{"example.com/cpu"},
+			want:      []string{"intel_icelake"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeLabelFeatures(node, tt.labelKeys); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("nodeLabelFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3810,14 +4958,50 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
 	pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
 	pod2.Spec.NodeName = node2.Name
+	node3 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node2",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+			},
+		},
+	}
+	topologyController := controller.DeepCopy()
+	topologyController.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+		{
+			Name: "topo-block",
+			Block: &slinkyv1beta1.ControllerTopologyBlock{
+				LabelKey: corev1.LabelTopologyZone,
+			},
+		},
+	}
+	pod3 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
+	pod3.Spec.NodeName = node3.Name
+	newTopologyClientMap := func(pod *corev1.Pod) *clientmap.ClientMap {
+		nodeList := &slurmtypes.V0044NodeList{
+			Items: []slurmtypes.V0044Node{
+				{
+					V0044Node: slurmapi.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]slurmapi.V0044NodeState{
+							slurmapi.V0044NodeStateIDLE,
+						}),
+					},
+				},
+			},
+		}
+		sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+		return newClientMap(controller.Name, sclient)
+	}
 
 	tests := []struct {
-		name      string
-		client    client.Client
-		clientMap *clientmap.ClientMap
-		nodeset   *slinkyv1beta1.NodeSet
-		pods      []*corev1.Pod
-		wantErr   bool
+		name         string
+		client       client.Client
+		clientMap    *clientmap.ClientMap
+		nodeset      *slinkyv1beta1.NodeSet
+		pods         []*corev1.Pod
+		wantTopology string
+		wantErr      bool
 	}{
 		{
 			name:      "pending",
@@ -3845,8 +5029,25 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 				sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
 				return newClientMap(controller.Name, sclient)
 			}(),
-			nodeset: nodeset,
-			pods:    []*corev1.Pod{pod2.DeepCopy()},
+			nodeset:      nodeset,
+			pods:         []*corev1.Pod{pod2.DeepCopy()},
+			wantTopology: "topo-block:b0",
+		},
+		{
+			name:         "allocated, controller topology",
+			client:       fake.NewFakeClient(topologyController.DeepCopy(), node3.DeepCopy(), pod3.DeepCopy()),
+			clientMap:    newTopologyClientMap(pod3),
+			nodeset:      nodeset,
+			pods:         []*corev1.Pod{pod3.DeepCopy()},
+			wantTopology: "topo-block:zone-a",
+		},
+		{
+			name:         "allocated, controller topology and node annotation",
+			client:       fake.NewFakeClient(topologyController.DeepCopy(), node2.DeepCopy(), pod2.DeepCopy()),
+			clientMap:    newTopologyClientMap(pod2),
+			nodeset:      nodeset,
+			pods:         []*corev1.Pod{pod2.DeepCopy()},
+			wantTopology: "topo-block:b0",
 		},
 	}
 	for _, tt := range tests {
@@ -3871,12 +5072,7 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 				if pod.Spec.NodeName == "" {
 					continue
 				}
-				checkNode := &corev1.Node{}
-				checkNodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
-				if err := tt.client.Get(ctx, checkNodeKey, checkNode); err != nil {
-					t.Errorf("Get() failed: %v", err)
-				}
-				topologySpec := checkNode.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+				topologySpec := tt.wantTopology
 				if !apiequality.Semantic.DeepEqual(checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec], topologySpec) {
 					t.Errorf("pod and node topology are incongruent: node = '%v' ; pod = '%v'", topologySpec, checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec])
 				}
@@ -3902,6 +5098,159 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncAutoscale(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	newAutoscaledNodeSet := func(replicas, minReplicas, maxReplicas int32) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, replicas)
+		nodeset.Spec.Autoscaling = slinkyv1beta1.NodeSetAutoscaling{
+			Enabled:                  true,
+			MinReplicas:              minReplicas,
+			MaxReplicas:              maxReplicas,
+			TargetPendingJobsPerNode: 1,
+			ScaleUpCooldown:          metav1.Duration{Duration: 30 * time.Second},
+			ScaleDownCooldown:        metav1.Duration{Duration: 5 * time.Minute},
+		}
+		return nodeset
+	}
+	newPendingJobList := func(count int) *slurmtypes.V0044JobInfoList {
+		jobList := &slurmtypes.V0044JobInfoList{}
+		for i := range count {
+			job := slurmtypes.V0044JobInfo{
+				V0044JobInfo: slurmapi.V0044JobInfo{
+					JobId:     ptr.To(int32(i + 1)),
+					JobState:  ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStatePENDING}),
+					Partition: ptr.To("foo"),
+				},
+			}
+			jobList.Items = append(jobList.Items, job)
+		}
+		return jobList
+	}
+	newBusyPod := func(nodeset *slinkyv1beta1.NodeSet, ordinal int) *corev1.Pod {
+		pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, ordinal, "")
+		pod.Annotations[slinkyv1beta1.AnnotationPodDeadline] = time.Now().Add(time.Hour).Format(time.RFC3339)
+		return pod
+	}
+	tests := []struct {
+		name         string
+		nodeset      *slinkyv1beta1.NodeSet
+		pods         []*corev1.Pod
+		pendingJobs  int
+		noClient     bool
+		wantReplicas int32
+		wantPending  int32
+	}{
+		{
+			name:         "disabled",
+			nodeset:      newNodeSet("foo", controller.Name, 1),
+			pendingJobs:  3,
+			wantReplicas: 1,
+			wantPending:  0,
+		},
+		{
+			name:         "scale up for pending jobs",
+			nodeset:      newAutoscaledNodeSet(1, 0, 5),
+			pendingJobs:  3,
+			wantReplicas: 3,
+			wantPending:  3,
+		},
+		{
+			name:         "scale up bounded by maxReplicas",
+			nodeset:      newAutoscaledNodeSet(1, 0, 4),
+			pendingJobs:  10,
+			wantReplicas: 4,
+			wantPending:  10,
+		},
+		{
+			name:         "scale down bounded by minReplicas",
+			nodeset:      newAutoscaledNodeSet(3, 2, 4),
+			pendingJobs:  0,
+			wantReplicas: 2,
+			wantPending:  0,
+		},
+		{
+			name:    "scale down keeps busy pods",
+			nodeset: newAutoscaledNodeSet(3, 0, 4),
+			pods: func() []*corev1.Pod {
+				nodeset := newAutoscaledNodeSet(3, 0, 4)
+				return []*corev1.Pod{
+					nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""),
+					newBusyPod(nodeset, 1),
+					nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, ""),
+				}
+			}(),
+			pendingJobs:  0,
+			wantReplicas: 1,
+			wantPending:  0,
+		},
+		{
+			name: "scale up in cooldown",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newAutoscaledNodeSet(1, 0, 5)
+				nodeset.Status.LastScaleTime = ptr.To(metav1.Now())
+				return nodeset
+			}(),
+			pendingJobs:  3,
+			wantReplicas: 1,
+			wantPending:  3,
+		},
+		{
+			name: "scale up after cooldown",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newAutoscaledNodeSet(1, 0, 5)
+				nodeset.Status.LastScaleTime = ptr.To(metav1.NewTime(time.Now().Add(-time.Minute)))
+				return nodeset
+			}(),
+			pendingJobs:  3,
+			wantReplicas: 3,
+			wantPending:  3,
+		},
+		{
+			name: "no slurm client",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newAutoscaledNodeSet(3, 0, 5)
+				nodeset.Status.SlurmPending = 2
+				return nodeset
+			}(),
+			noClient:     true,
+			wantReplicas: 3,
+			wantPending:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sclient := fake.NewFakeClient(tt.nodeset.DeepCopy())
+			sclient := newFakeClientList(sinterceptor.Funcs{}, newPendingJobList(tt.pendingJobs))
+			clientMap := newClientMap(controller.Name, sclient)
+			if tt.noClient {
+				clientMap = clientmap.NewClientMap()
+			}
+			r := newNodeSetController(k8sclient, clientMap)
+			if err := r.syncAutoscale(ctx, tt.nodeset, tt.pods); err != nil {
+				t.Fatalf("syncAutoscale() failed: %v", err)
+			}
+			if got := ptr.Deref(tt.nodeset.Spec.Replicas, 0); got != tt.wantReplicas {
+				t.Errorf("syncAutoscale() replicas = %v, want %v", got, tt.wantReplicas)
+			}
+			if got := tt.nodeset.Status.SlurmPending; got != tt.wantPending {
+				t.Errorf("syncAutoscale() slurmPending = %v, want %v", got, tt.wantPending)
+			}
+			checkNodeSet := &slinkyv1beta1.NodeSet{}
+			if err := k8sclient.Get(ctx, client.ObjectKeyFromObject(tt.nodeset), checkNodeSet); err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			if got := ptr.Deref(checkNodeSet.Spec.Replicas, 0); got != tt.wantReplicas {
+				t.Errorf("stored replicas = %v, want %v", got, tt.wantReplicas)
+			}
+		})
+	}
+}
+
 func TestGetNodesToDaemonPods(t *testing.T) {
 	nodeset := newNodeSet("foo", "ctrl", 1)
 	nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
@@ -4394,7 +5743,7 @@ func TestNodeSetReconciler_syncSlurmNodes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			r := NewReconciler(tt.kclient, tt.clientMap, nil)
+			r := NewReconciler(tt.kclient, tt.clientMap, nil, TerminationSignals{})
 			gotErr := r.syncSlurmNodes(context.Background(), tt.nodeset, tt.pods)
 			if gotErr != nil {
 				if !tt.wantErr {
@@ -4628,7 +5977,7 @@ func TestNodeSetReconciler_syncSlurmNodeRecords(t *testing.T) {
 			kclient := fake.NewFakeClient(initObjs...)
 			sclient := newFakeClientList(tt.interceptor, &slurmtypes.V0044NodeList{Items: slurmNodes})
 			clientMap := newClientMap(controller.Name, sclient)
-			r := NewReconciler(kclient, clientMap, nil)
+			r := NewReconciler(kclient, clientMap, nil, TerminationSignals{})
 
 			if err := r.syncSlurmNodeRecords(context.Background(), nodeset); (err != nil) != tt.wantErr {
 				t.Fatalf("syncSlurmNodeRecords() error = %v", err)
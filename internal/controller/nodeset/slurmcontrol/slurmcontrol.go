@@ -5,9 +5,11 @@ package slurmcontrol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,6 +38,10 @@ import (
 	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
+// ErrNoSlurmClient is returned when Slurm cannot be queried for the NodeSet
+// because there is no client for its Controller (e.g. slurmrestd is not ready).
+var ErrNoSlurmClient = errors.New("no slurm client for nodeset")
+
 type SlurmControlInterface interface {
 	// RefreshNodeCache forces the Node cache to be refreshed
 	RefreshNodeCache(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error
@@ -43,10 +49,18 @@ type SlurmControlInterface interface {
 	UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	// UpdateNodeTopology handles updating the Node with its topologySpec.
 	UpdateNodeTopology(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, topologySpec string) error
+	// UpdateNodeFeatures handles replacing the Node features with the NodeSet features and the given features.
+	UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error
 	// MakeNodeDrain handles adding the DRAIN state to the slurm node.
 	MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string, overrideReason bool) error
 	// MakeNodeUndrain handles removing the DRAIN state from the slurm node.
 	MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// RequeueNodeJobs marks the running jobs of the slurm node as requeueable, so MakeNodeDown requeues them.
+	RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// MakeNodeDown handles setting the DOWN state on the slurm node, which requeues its running jobs.
+	MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// MakeNodeResume handles removing the DOWN state from the slurm node, when it was set by the operator.
+	MakeNodeResume(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	// IsNodeDrain checks if the slurm node has the DRAIN state.
 	IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeDrained checks if the slurm node is drained.
@@ -59,6 +73,14 @@ type SlurmControlInterface interface {
 	CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error)
 	// GetNodeDeadlines returns a map of node to its deadline time.Time calculated from running jobs.
 	GetNodeDeadlines(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (*timestore.TimeStore, error)
+	// GetNodeWorkloads returns a map of node to its workload calculated from its state and running jobs.
+	GetNodeWorkloads(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string]NodeWorkload, error)
+	// GetPendingJobCount returns the number of pending jobs which target the NodeSet partition.
+	GetPendingJobCount(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (int32, error)
+	// CreatePowerSaveNodes registers the missing Slurm nodes in the CLOUD state, so they are powered down until resumed.
+	CreatePowerSaveNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) error
+	// GetPoweredUpNodes returns the Slurm nodes which slurmctld has resumed, or is resuming, from power saving.
+	GetPoweredUpNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) ([]string, bool, error)
 	// GetNodesForPods returns a list of Slurm nodes associated with the NodeSet pods.
 	GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, bool, error)
 	// CheckReservationForNodeSet returns true when a reservation exists for a NodeSet
@@ -202,6 +224,75 @@ func (r *realSlurmControl) UpdateNodeTopology(ctx context.Context, nodeset *slin
 	return nil
 }
 
+// UpdateNodeFeatures implements SlurmControlInterface.
+func (r *realSlurmControl) UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do UpdateNodeFeatures()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	// The features are replaced, so any not given (e.g. set by `scontrol update`, or from a stale label) are removed.
+	// The features from slurmd must be kept, otherwise they are lost until slurmd registers again.
+	featureSet := set.New(nodeSetFeatures(nodeset)...)
+	featureSet.Insert(features...)
+	nodeFeatures := ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{})
+	nodeActiveFeatures := ptr.Deref(slurmNode.ActiveFeatures, slurmapi.V0044CsvString{})
+	if featureSet.Equal(set.New(nodeFeatures...)) && featureSet.Equal(set.New(nodeActiveFeatures...)) {
+		logger.V(3).Info("Node features are identical to request, skipping update request",
+			"node", slurmNode.GetKey(), "features", nodeFeatures)
+		return nil
+	}
+
+	newFeatures := slurmapi.V0044CsvString(featureSet.SortedList())
+	logger.Info("Update Slurm Node features", "Node", slurmNode.GetKey(), "features", newFeatures)
+	req := slurmapi.V0044UpdateNodeMsg{
+		Features:    ptr.To(newFeatures),
+		FeaturesAct: ptr.To(newFeatures),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// nodeSetFeatures returns the features which slurmd registers for the NodeSet: its name, and those in its ExtraConf.
+func nodeSetFeatures(nodeset *slinkyv1beta1.NodeSet) []string {
+	features := []string{common.GetSlurmNodeSetName(nodeset)}
+	for item := range strings.FieldsSeq(nodeset.Spec.ExtraConf) {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		// Slurm treats the trailing 's' as optional.
+		if !strings.EqualFold(key, "Features") && !strings.EqualFold(key, "Feature") {
+			continue
+		}
+		for feature := range strings.SplitSeq(val, ",") {
+			if feature != "" {
+				features = append(features, feature)
+			}
+		}
+	}
+	return features
+}
+
 const nodeReasonPrefix = "slurm-operator: "
 
 // MakeNodeDrain implements SlurmControlInterface.
@@ -257,6 +348,11 @@ func FormatNodeReason(reason string) string {
 	return nodeReasonPrefix + reason
 }
 
+// IsNodeReasonFormatted reports whether the reason was set by the operator.
+func IsNodeReasonFormatted(reason string) bool {
+	return strings.HasPrefix(reason, nodeReasonPrefix)
+}
+
 // MakeNodeUndrain implements SlurmControlInterface.
 func (r *realSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
 	logger := log.FromContext(ctx)
@@ -306,6 +402,145 @@ func (r *realSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv
 	return nil
 }
 
+// MakeNodeDown implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeDown()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN) {
+		logger.V(1).Info("Node is already down, skipping down request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State)
+		return nil
+	}
+
+	// Slurm requeues the running jobs of a node which is set DOWN, unless they cannot be requeued.
+	// https://slurm.schedmd.com/scontrol.html#OPT_State_1
+	logger.V(1).Info("make slurm node down",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateDOWN}),
+		Reason: ptr.To(FormatNodeReason(reason)),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RequeueNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do RequeueNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) || ptr.Deref(job.Requeue, false) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return err
+		}
+		if !slices.Contains(slurmNodeNames, slurmNodeName) {
+			continue
+		}
+		// Slurm kills, instead of requeues, the jobs of a DOWN node which cannot be requeued.
+		// https://slurm.schedmd.com/sbatch.html#OPT_requeue
+		logger.V(1).Info("make slurm job requeueable",
+			"pod", klog.KObj(pod), "job", ptr.Deref(job.JobId, 0))
+		req := slurmapi.V0044JobDescMsg{
+			Requeue: ptr.To(true),
+		}
+		if err := slurmClient.Update(ctx, &job, req); err != nil {
+			if tolerateError(err) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MakeNodeResume implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeResume(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeResume()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Only resume nodes which the operator has set DOWN, Slurm will resume unresponsive nodes when they register.
+	nodeReason := ptr.Deref(slurmNode.Reason, "")
+	isDown := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN)
+	isNotResponding := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateNOTRESPONDING)
+	if !isDown || isNotResponding || !IsNodeReasonFormatted(nodeReason) {
+		return nil
+	}
+
+	logger.V(1).Info("make slurm node resume",
+		"pod", klog.KObj(pod), "nodeReason", nodeReason)
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateRESUME}),
+		Reason: ptr.To(""),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 // IsNodeDrain implements SlurmControlInterface.
 func (r *realSlurmControl) IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -411,7 +646,7 @@ func (r *realSlurmControl) IsNodeReasonOurs(ctx context.Context, nodeset *slinky
 	// The operator will always prefix the node reason.
 	// External sources may not have a prefix or a different one.
 	nodeReason := ptr.Deref(slurmNode.Reason, "")
-	if nodeReason != "" && !strings.HasPrefix(nodeReason, nodeReasonPrefix) {
+	if nodeReason != "" && !IsNodeReasonFormatted(nodeReason) {
 		return false, nil
 	}
 
@@ -592,25 +827,268 @@ func (r *realSlurmControl) GetNodeDeadlines(ctx context.Context, nodeset *slinky
 			continue
 		}
 
-		// Get startTime, when the job was launched on the Slurm worker.
-		startTime_NoVal := ptr.Deref(job.StartTime, slurmapi.V0044Uint64NoValStruct{})
-		startTime := time.Unix(ptr.Deref(startTime_NoVal.Number, 0), 0)
-		// Get the timeLimit, the wall time of the job.
-		timeLimit_NoVal := ptr.Deref(job.TimeLimit, slurmapi.V0044Uint32NoValStruct{})
-		timeLimit := time.Duration(ptr.Deref(timeLimit_NoVal.Number, 0)) * time.Minute
-		if ptr.Deref(timeLimit_NoVal.Infinite, false) {
-			timeLimit = infiniteDuration
-		}
-
 		// Push time/duration into the fancy map for each node allocated to the job.
+		deadline := getJobDeadline(job)
 		for _, slurmNodeName := range slurmNodeNames {
-			ts.Push(slurmNodeName, startTime.Add(timeLimit))
+			ts.Push(slurmNodeName, deadline)
 		}
 	}
 
 	return ts, nil
 }
 
+// getJobDeadline returns the time by which the running job must complete.
+func getJobDeadline(job slurmtypes.V0044JobInfo) time.Time {
+	// Get startTime, when the job was launched on the Slurm worker.
+	startTime_NoVal := ptr.Deref(job.StartTime, slurmapi.V0044Uint64NoValStruct{})
+	startTime := time.Unix(ptr.Deref(startTime_NoVal.Number, 0), 0)
+	// Get the timeLimit, the wall time of the job.
+	timeLimit_NoVal := ptr.Deref(job.TimeLimit, slurmapi.V0044Uint32NoValStruct{})
+	timeLimit := time.Duration(ptr.Deref(timeLimit_NoVal.Number, 0)) * time.Minute
+	if ptr.Deref(timeLimit_NoVal.Infinite, false) {
+		timeLimit = infiniteDuration
+	}
+	return startTime.Add(timeLimit)
+}
+
+// NodeAllocation is the allocation state of a Slurm node, ordered from least to
+// most allocated.
+type NodeAllocation int
+
+const (
+	NodeAllocationIdle NodeAllocation = iota
+	NodeAllocationMixed
+	NodeAllocationAllocated
+)
+
+type NodeWorkload struct {
+	// Allocation is the allocation state of the node.
+	Allocation NodeAllocation
+	// RunningJobs is the number of running jobs allocated to the node.
+	RunningJobs int32
+	// Deadline is the time by which all running jobs on the node must complete.
+	Deadline time.Time
+}
+
+// GetNodeWorkloads implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeWorkloads(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string]NodeWorkload, error) {
+	logger := log.FromContext(ctx)
+	workloads := make(map[string]NodeWorkload)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeWorkloads()")
+		return workloads, nil
+	}
+
+	slurmNodeNamesSet := set.New[string]()
+	for _, pod := range pods {
+		slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+		slurmNodeNamesSet.Insert(slurmNodeName)
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if tolerateError(err) {
+			return workloads, nil
+		}
+		return nil, err
+	}
+
+	for _, node := range nodeList.Items {
+		slurmNodeName := ptr.Deref(node.Name, "")
+		if !slurmNodeNamesSet.Has(slurmNodeName) {
+			continue
+		}
+		workload := NodeWorkload{}
+		switch {
+		case node.GetStateAsSet().Has(slurmapi.V0044NodeStateALLOCATED):
+			workload.Allocation = NodeAllocationAllocated
+		case node.GetStateAsSet().Has(slurmapi.V0044NodeStateMIXED):
+			workload.Allocation = NodeAllocationMixed
+		}
+		workloads[slurmNodeName] = workload
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		if tolerateError(err) {
+			return workloads, nil
+		}
+		return nil, err
+	}
+
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return nil, err
+		}
+		deadline := getJobDeadline(job)
+		for _, slurmNodeName := range slurmNodeNames {
+			if !slurmNodeNamesSet.Has(slurmNodeName) {
+				continue
+			}
+			workload := workloads[slurmNodeName]
+			workload.RunningJobs++
+			if deadline.After(workload.Deadline) {
+				workload.Deadline = deadline
+			}
+			workloads[slurmNodeName] = workload
+		}
+	}
+
+	return workloads, nil
+}
+
+// GetPendingJobCount implements SlurmControlInterface.
+func (r *realSlurmControl) GetPendingJobCount(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (int32, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetPendingJobCount()")
+		return 0, ErrNoSlurmClient
+	}
+
+	partitionName := common.GetSlurmNodeSetName(nodeset)
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		return 0, err
+	}
+
+	var count int32
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING) {
+			continue
+		}
+		// A job may be submitted to multiple partitions (e.g. `--partition=foo,bar`).
+		partitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+		if !set.New(partitions...).Has(partitionName) {
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// CreatePowerSaveNodes implements SlurmControlInterface.
+func (r *realSlurmControl) CreatePowerSaveNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do CreatePowerSaveNodes()")
+		return nil
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	slurmNodeNamesSet := set.New[string]()
+	for _, node := range nodeList.Items {
+		slurmNodeNamesSet.Insert(ptr.Deref(node.Name, ""))
+	}
+
+	for _, nodeName := range nodeNames {
+		if slurmNodeNamesSet.Has(nodeName) {
+			continue
+		}
+		logger.V(1).Info("Creating power save Slurm node", "node", nodeName)
+		slurmNode := &slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name: ptr.To(nodeName),
+			},
+		}
+		req := slurmapi.V0044OpenapiCreateNodeReq{
+			NodeConf: powerSaveNodeConf(nodeset, nodeName),
+		}
+		if err := slurmClient.Create(ctx, slurmNode, req); err != nil {
+			if tolerateError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to create Slurm node %s: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
+
+// powerSaveNodeConf returns the node line used to pre-register a power save Slurm node.
+// The node gets the NodeSet feature, like the `--conf` of its slurmd, so it is part of the Slurm NodeSet.
+//
+// https://slurm.schedmd.com/dynamic_nodes.html
+func powerSaveNodeConf(nodeset *slinkyv1beta1.NodeSet, nodeName string) string {
+	conf := []string{
+		fmt.Sprintf("NodeName=%s", nodeName),
+		fmt.Sprintf("State=%s", slurmapi.V0044NodeStateCLOUD),
+		fmt.Sprintf("Features=%s", common.GetSlurmNodeSetName(nodeset)),
+	}
+	if nodeConf := nodeset.Spec.PowerSave.NodeConf; nodeConf != "" {
+		conf = append(conf, nodeConf)
+	}
+	return strings.Join(conf, " ")
+}
+
+// GetPoweredUpNodes implements SlurmControlInterface.
+func (r *realSlurmControl) GetPoweredUpNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) ([]string, bool, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetPoweredUpNodes()")
+		return nil, false, nil
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		return nil, true, err
+	}
+
+	slurmNodeNamesSet := set.New(nodeNames...)
+	poweredUpNodes := []string{}
+	for _, node := range nodeList.Items {
+		nodeName := ptr.Deref(node.Name, "")
+		if !slurmNodeNamesSet.Has(nodeName) {
+			continue
+		}
+		if !isNodePoweredUp(node) {
+			continue
+		}
+		poweredUpNodes = append(poweredUpNodes, nodeName)
+	}
+
+	return poweredUpNodes, true, nil
+}
+
+// isNodePoweredUp reports if slurmctld expects the Slurm node to be powered up.
+//
+// https://slurm.schedmd.com/power_save.html#node_states
+func isNodePoweredUp(node slurmtypes.V0044Node) bool {
+	state := node.GetStateAsSet()
+	switch {
+	case state.Has(slurmapi.V0044NodeStateFUTURE):
+		return false
+	case state.HasAny(slurmapi.V0044NodeStatePOWERUP, slurmapi.V0044NodeStatePOWERINGUP):
+		return true
+	case state.HasAny(slurmapi.V0044NodeStatePOWERDOWN, slurmapi.V0044NodeStatePOWERINGDOWN, slurmapi.V0044NodeStatePOWEREDDOWN):
+		return false
+	default:
+		return true
+	}
+}
+
 // GetNodesForPods implements SlurmControlInterface.
 func (r *realSlurmControl) GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, bool, error) {
 	logger := log.FromContext(ctx)
@@ -1147,8 +1625,10 @@ func (r *realSlurmControl) lookupClient(nodeset *slinkyv1beta1.NodeSet) slurmcli
 var _ SlurmControlInterface = &realSlurmControl{}
 
 func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
-	return &realSlurmControl{
-		clientMap: clientMap,
+	return &tracedSlurmControl{
+		SlurmControlInterface: &realSlurmControl{
+			clientMap: clientMap,
+		},
 	}
 }
 
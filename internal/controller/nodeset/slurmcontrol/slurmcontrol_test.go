@@ -46,6 +46,8 @@ func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client
 			switch stateReq {
 			case api.V0044UpdateNodeMsgStateUNDRAIN:
 				stateSet.Delete(api.V0044NodeStateDRAIN)
+			case api.V0044UpdateNodeMsgStateRESUME:
+				stateSet.Delete(api.V0044NodeStateDOWN, api.V0044NodeStateDRAIN)
 			default:
 				stateSet.Insert(api.V0044NodeState(stateReq))
 			}
@@ -54,6 +56,16 @@ func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client
 		o.Comment = r.Comment
 		o.Reason = r.Reason
 		o.Topology = r.TopologyStr
+		if r.Features != nil {
+			o.Features = r.Features
+			o.ActiveFeatures = r.FeaturesAct
+		}
+	case *types.V0044JobInfo:
+		r, ok := req.(api.V0044JobDescMsg)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.Requeue = r.Requeue
 	case *types.V0044ReservationInfo:
 		_, ok := req.(api.V0044ReservationDescMsg)
 		if !ok {
@@ -375,6 +387,296 @@ func Test_realSlurmControl_MakeNodeUndrain(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_MakeNodeDown(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		node *types.V0044Node
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		reason  string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name: "allocated",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateALLOCATED,
+						}),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "test",
+			},
+			wantReason: FormatNodeReason("test"),
+		},
+		{
+			name: "already down",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateDOWN,
+						}),
+						Reason: ptr.To("admin"),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "test",
+			},
+			wantReason: "admin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.MakeNodeDown(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.reason); (err != nil) != tt.wantErr {
+				t.Errorf("MakeNodeDown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNode := &types.V0044Node{}
+			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
+				t.Fatalf("client.Get() = %v", err)
+			}
+			if !checkNode.GetStateAsSet().Has(api.V0044NodeStateDOWN) {
+				t.Errorf("MakeNodeDown() state = %v", checkNode.State)
+			}
+			if got := ptr.Deref(checkNode.Reason, ""); got != tt.wantReason {
+				t.Errorf("MakeNodeDown() reason = %v, want %v", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_RequeueNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	kclient := kubefake.NewFakeClient()
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 1, "")
+	newJob := func(id int32, state api.V0044JobInfoJobState, nodes string) *types.V0044JobInfo {
+		return &types.V0044JobInfo{
+			V0044JobInfo: api.V0044JobInfo{
+				JobId:    ptr.To(id),
+				JobState: ptr.To([]api.V0044JobInfoJobState{state}),
+				Nodes:    ptr.To(nodes),
+				Requeue:  ptr.To(false),
+			},
+		}
+	}
+	type fields struct {
+		jobs []*types.V0044JobInfo
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantRequeue map[int32]bool
+		wantErr     bool
+	}{
+		{
+			name: "running jobs on the node",
+			fields: fields{
+				jobs: []*types.V0044JobInfo{
+					newJob(1, api.V0044JobInfoJobStateRUNNING, nodesetutils.GetSlurmNodeName(pod)),
+					newJob(2, api.V0044JobInfoJobStateRUNNING, nodesetutils.GetSlurmNodeName(pod2)),
+					newJob(3, api.V0044JobInfoJobStatePENDING, ""),
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantRequeue: map[int32]bool{1: true, 2: false, 3: false},
+		},
+		{
+			name: "no jobs",
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantRequeue: map[int32]bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := make([]object.Object, 0, len(tt.fields.jobs))
+			for _, job := range tt.fields.jobs {
+				objs = append(objs, job)
+			}
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(objs...).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.RequeueNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("RequeueNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, job := range tt.fields.jobs {
+				checkJob := &types.V0044JobInfo{}
+				if err := sclient.Get(ctx, job.GetKey(), checkJob); err != nil {
+					t.Fatalf("client.Get() = %v", err)
+				}
+				id := ptr.Deref(job.JobId, 0)
+				if got := ptr.Deref(checkJob.Requeue, false); got != tt.wantRequeue[id] {
+					t.Errorf("RequeueNodeJobs() job %v requeue = %v, want %v", id, got, tt.wantRequeue[id])
+				}
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_MakeNodeResume(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		node *types.V0044Node
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResume bool
+		wantErr    bool
+	}{
+		{
+			name: "down by operator",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+							api.V0044NodeStateDOWN,
+							api.V0044NodeStateDRAIN,
+						}),
+						Reason: ptr.To(FormatNodeReason("test")),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantResume: true,
+		},
+		{
+			name: "down externally",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+							api.V0044NodeStateDOWN,
+						}),
+						Reason: ptr.To("admin"),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantResume: false,
+		},
+		{
+			name: "down by operator, not responding",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+							api.V0044NodeStateDOWN,
+							api.V0044NodeStateNOTRESPONDING,
+						}),
+						Reason: ptr.To(FormatNodeReason("test")),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantResume: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.MakeNodeResume(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("MakeNodeResume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNode := &types.V0044Node{}
+			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
+				t.Fatalf("client.Get() = %v", err)
+			}
+			isResume := !checkNode.GetStateAsSet().Has(api.V0044NodeStateDOWN)
+			if isResume != tt.wantResume {
+				t.Errorf("MakeNodeResume() = %v, want %v", isResume, tt.wantResume)
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_UpdateNodeTopology(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -448,13 +750,100 @@ func Test_realSlurmControl_UpdateNodeTopology(t *testing.T) {
 			}
 			checkNode := &types.V0044Node{}
 			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
-				if !tolerateError(err) {
-					t.Fatalf("client.Get() = %v", err)
-				}
+				if !tolerateError(err) {
+					t.Fatalf("client.Get() = %v", err)
+				}
+			}
+			got := ptr.Deref(checkNode.Topology, "")
+			if !apiequality.Semantic.DeepEqual(got, tt.args.topologySpec) {
+				t.Fatalf("UpdateNodeTopology() topologySpec = %v", got)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_UpdateNodeFeatures(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	nodeset.Spec.ExtraConf = "Features=gpu,nvme Weight=10"
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		node *types.V0044Node
+	}
+	type args struct {
+		ctx      context.Context
+		nodeset  *slinkyv1beta1.NodeSet
+		pod      *corev1.Pod
+		features []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "registered features",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name:           ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						Features:       ptr.To(api.V0044CsvString{"foo", "gpu", "nvme"}),
+						ActiveFeatures: ptr.To(api.V0044CsvString{"foo", "gpu", "nvme"}),
+					},
+				},
+			},
+			args: args{
+				ctx:      ctx,
+				nodeset:  nodeset,
+				pod:      pod,
+				features: []string{"m5.xlarge", "us-east-1a"},
+			},
+			want: []string{"foo", "gpu", "m5.xlarge", "nvme", "us-east-1a"},
+		},
+		{
+			name: "stale label feature",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name:           ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						Features:       ptr.To(api.V0044CsvString{"foo", "gpu", "nvme", "us-east-1a"}),
+						ActiveFeatures: ptr.To(api.V0044CsvString{"foo", "gpu", "nvme", "us-east-1a"}),
+					},
+				},
+			},
+			args: args{
+				ctx:      ctx,
+				nodeset:  nodeset,
+				pod:      pod,
+				features: []string{"us-east-1b"},
+			},
+			want: []string{"foo", "gpu", "nvme", "us-east-1b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.UpdateNodeFeatures(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.features); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateNodeFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNode := &types.V0044Node{}
+			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
+				t.Fatalf("client.Get() = %v", err)
 			}
-			got := ptr.Deref(checkNode.Topology, "")
-			if !apiequality.Semantic.DeepEqual(got, tt.args.topologySpec) {
-				t.Fatalf("UpdateNodeTopology() topologySpec = %v", got)
+			if got := []string(ptr.Deref(checkNode.Features, nil)); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateNodeFeatures() Features = %v, want %v", got, tt.want)
+			}
+			if got := []string(ptr.Deref(checkNode.ActiveFeatures, nil)); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateNodeFeatures() ActiveFeatures = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -2214,6 +2603,448 @@ func Test_realSlurmControl_GetNodeDeadlines(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetNodeWorkloads(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 3)
+	kclient := kubefake.NewFakeClient()
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 1, "")
+	pod3 := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 2, "")
+	pods := []*corev1.Pod{pod, pod2, pod3}
+	now := time.Unix(time.Now().Unix(), 0)
+	nodeList := &types.V0044NodeList{
+		Items: []types.V0044Node{
+			{
+				V0044Node: api.V0044Node{
+					Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					State: ptr.To([]api.V0044NodeState{api.V0044NodeStateALLOCATED}),
+				},
+			},
+			{
+				V0044Node: api.V0044Node{
+					Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod2)),
+					State: ptr.To([]api.V0044NodeState{api.V0044NodeStateMIXED}),
+				},
+			},
+			{
+				V0044Node: api.V0044Node{
+					Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod3)),
+					State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+				},
+			},
+		},
+	}
+	jobList := &types.V0044JobInfoList{
+		Items: []types.V0044JobInfo{
+			{
+				V0044JobInfo: api.V0044JobInfo{
+					JobId:     ptr.To[int32](1),
+					JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+					StartTime: ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To(now.Unix())}),
+					TimeLimit: ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](60)}),
+					Nodes:     ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				},
+			},
+			{
+				V0044JobInfo: api.V0044JobInfo{
+					JobId:     ptr.To[int32](2),
+					JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+					StartTime: ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To(now.Unix())}),
+					TimeLimit: ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](30)}),
+					Nodes: func() *string {
+						hostlist, err := hostlist.Compress([]string{
+							nodesetutils.GetSlurmNodeName(pod),
+							nodesetutils.GetSlurmNodeName(pod2),
+						})
+						if err != nil {
+							panic(err)
+						}
+						return ptr.To(hostlist)
+					}(),
+				},
+			},
+			{
+				V0044JobInfo: api.V0044JobInfo{
+					JobId:    ptr.To[int32](3),
+					JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateCOMPLETED}),
+					Nodes:    ptr.To(nodesetutils.GetSlurmNodeName(pod3)),
+				},
+			},
+		},
+	}
+	type fields struct {
+		slurmClient client.Client
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string]NodeWorkload
+		wantErr bool
+	}{
+		{
+			name: "workloads",
+			fields: fields{
+				slurmClient: fake.NewClientBuilder().WithLists(nodeList, jobList).Build(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			want: map[string]NodeWorkload{
+				nodesetutils.GetSlurmNodeName(pod): {
+					Allocation:  NodeAllocationAllocated,
+					RunningJobs: 2,
+					Deadline:    now.Add(time.Hour),
+				},
+				nodesetutils.GetSlurmNodeName(pod2): {
+					Allocation:  NodeAllocationMixed,
+					RunningJobs: 1,
+					Deadline:    now.Add(30 * time.Minute),
+				},
+				nodesetutils.GetSlurmNodeName(pod3): {
+					Allocation: NodeAllocationIdle,
+				},
+			},
+		},
+		{
+			name: "no jobs found",
+			fields: fields{
+				slurmClient: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						switch list := list.(type) {
+						case *types.V0044NodeList:
+							*list = *nodeList
+						case *types.V0044JobInfoList:
+							return errors.New(http.StatusText(http.StatusNotFound))
+						}
+						return nil
+					},
+				}).Build(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			want: map[string]NodeWorkload{
+				nodesetutils.GetSlurmNodeName(pod): {
+					Allocation: NodeAllocationAllocated,
+				},
+				nodesetutils.GetSlurmNodeName(pod2): {
+					Allocation: NodeAllocationMixed,
+				},
+				nodesetutils.GetSlurmNodeName(pod3): {
+					Allocation: NodeAllocationIdle,
+				},
+			},
+		},
+		{
+			name: "list failure",
+			fields: fields{
+				slurmClient: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, tt.fields.slurmClient))
+			got, err := r.GetNodeWorkloads(tt.args.ctx, tt.args.nodeset, tt.args.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNodeWorkloads() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetNodeWorkloads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetPendingJobCount(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 1)
+	type fields struct {
+		jobList *types.V0044JobInfoList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int32
+		wantErr error
+	}{
+		{
+			name: "no jobs",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want: 0,
+		},
+		{
+			name: "pending jobs for partition",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](1),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](2),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("bar,foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](3),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("bar"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](4),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Partition: ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](5),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("foobar"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want: 2,
+		},
+		{
+			name:   "no client",
+			fields: fields{},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want:    0,
+			wantErr: ErrNoSlurmClient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMap := clientmap.NewClientMap()
+			if tt.fields.jobList != nil {
+				sclient := fake.NewClientBuilder().WithLists(tt.fields.jobList).Build()
+				clientMap = newSlurmClientMap(tt.args.nodeset.Spec.ControllerRef.Name, sclient)
+			}
+			r := NewSlurmControl(clientMap)
+			got, err := r.GetPendingJobCount(tt.args.ctx, tt.args.nodeset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPendingJobCount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetPendingJobCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_CreatePowerSaveNodes(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 0)
+	nodeset.Spec.PowerSave = slinkyv1beta1.NodeSetPowerSave{
+		Enabled:  true,
+		MaxNodes: 3,
+		NodeConf: "CPUs=4 RealMemory=8000",
+	}
+	type fields struct {
+		nodeList *types.V0044NodeList
+	}
+	type args struct {
+		ctx       context.Context
+		nodeset   *slinkyv1beta1.NodeSet
+		nodeNames []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "create all",
+			fields: fields{
+				nodeList: &types.V0044NodeList{},
+			},
+			args: args{
+				ctx:       ctx,
+				nodeset:   nodeset,
+				nodeNames: []string{"foo-0", "foo-1"},
+			},
+			want: []string{
+				"NodeName=foo-0 State=CLOUD Features=foo CPUs=4 RealMemory=8000",
+				"NodeName=foo-1 State=CLOUD Features=foo CPUs=4 RealMemory=8000",
+			},
+		},
+		{
+			name: "create missing",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{V0044Node: api.V0044Node{Name: ptr.To("foo-0")}},
+						{V0044Node: api.V0044Node{Name: ptr.To("bar-0")}},
+					},
+				},
+			},
+			args: args{
+				ctx:       ctx,
+				nodeset:   nodeset,
+				nodeNames: []string{"foo-0", "foo-1", "foo-2"},
+			},
+			want: []string{
+				"NodeName=foo-1 State=CLOUD Features=foo CPUs=4 RealMemory=8000",
+				"NodeName=foo-2 State=CLOUD Features=foo CPUs=4 RealMemory=8000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			sclient := fake.NewClientBuilder().
+				WithLists(tt.fields.nodeList).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, obj object.Object, req any, opts ...client.CreateOption) error {
+						got = append(got, req.(api.V0044OpenapiCreateNodeReq).NodeConf)
+						return nil
+					},
+				}).
+				Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.CreatePowerSaveNodes(tt.args.ctx, tt.args.nodeset, tt.args.nodeNames); (err != nil) != tt.wantErr {
+				t.Errorf("CreatePowerSaveNodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreatePowerSaveNodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetPoweredUpNodes(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 0)
+	newNode := func(name string, states ...api.V0044NodeState) types.V0044Node {
+		return types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name:  ptr.To(name),
+				State: ptr.To(states),
+			},
+		}
+	}
+	type fields struct {
+		nodeList *types.V0044NodeList
+	}
+	type args struct {
+		ctx       context.Context
+		nodeset   *slinkyv1beta1.NodeSet
+		nodeNames []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			name: "power states",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						newNode("foo-0", api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWEREDDOWN),
+						newNode("foo-1", api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWERINGUP),
+						newNode("foo-2", api.V0044NodeStateALLOCATED, api.V0044NodeStateCLOUD),
+						newNode("foo-3", api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWERINGDOWN),
+						newNode("foo-4", api.V0044NodeStateFUTURE),
+						newNode("foo-5", api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWEREDDOWN, api.V0044NodeStatePOWERUP),
+						newNode("bar-0", api.V0044NodeStateIDLE),
+					},
+				},
+			},
+			args: args{
+				ctx:       ctx,
+				nodeset:   nodeset,
+				nodeNames: []string{"foo-0", "foo-1", "foo-2", "foo-3", "foo-4", "foo-5", "foo-6"},
+			},
+			want:   []string{"foo-1", "foo-2", "foo-5"},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.fields.nodeList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			got, gotOk, err := r.GetPoweredUpNodes(tt.args.ctx, tt.args.nodeset, tt.args.nodeNames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPoweredUpNodes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPoweredUpNodes() got = %v, want %v", got, tt.want)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("GetPoweredUpNodes() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_GetNodesForPods(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
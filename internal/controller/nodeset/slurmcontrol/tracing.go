@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/tracing"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/timestore"
+)
+
+// attributePods is the number of pods which the method was called with.
+const attributePods = attribute.Key("slinky.pods")
+
+// tracedSlurmControl traces each method of SlurmControlInterface.
+type tracedSlurmControl struct {
+	SlurmControlInterface
+}
+
+// RefreshNodeCache implements SlurmControlInterface.
+func (r *tracedSlurmControl) RefreshNodeCache(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+	ctx, span := startSpan(ctx, "RefreshNodeCache", nodeset)
+	err := r.SlurmControlInterface.RefreshNodeCache(ctx, nodeset)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateNodeWithPodInfo implements SlurmControlInterface.
+func (r *tracedSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	ctx, span := startSpan(ctx, "UpdateNodeWithPodInfo", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.UpdateNodeWithPodInfo(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateNodeTopology implements SlurmControlInterface.
+func (r *tracedSlurmControl) UpdateNodeTopology(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, topologySpec string) error {
+	ctx, span := startSpan(ctx, "UpdateNodeTopology", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.UpdateNodeTopology(ctx, nodeset, pod, topologySpec)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateNodeFeatures implements SlurmControlInterface.
+func (r *tracedSlurmControl) UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error {
+	ctx, span := startSpan(ctx, "UpdateNodeFeatures", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.UpdateNodeFeatures(ctx, nodeset, pod, features)
+	tracing.End(span, err)
+	return err
+}
+
+// MakeNodeDrain implements SlurmControlInterface.
+func (r *tracedSlurmControl) MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string, overrideReason bool) error {
+	ctx, span := startSpan(ctx, "MakeNodeDrain", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.MakeNodeDrain(ctx, nodeset, pod, reason, overrideReason)
+	tracing.End(span, err)
+	return err
+}
+
+// MakeNodeUndrain implements SlurmControlInterface.
+func (r *tracedSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	ctx, span := startSpan(ctx, "MakeNodeUndrain", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.MakeNodeUndrain(ctx, nodeset, pod, reason)
+	tracing.End(span, err)
+	return err
+}
+
+// RequeueNodeJobs implements SlurmControlInterface.
+func (r *tracedSlurmControl) RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	ctx, span := startSpan(ctx, "RequeueNodeJobs", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.RequeueNodeJobs(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return err
+}
+
+// MakeNodeDown implements SlurmControlInterface.
+func (r *tracedSlurmControl) MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	ctx, span := startSpan(ctx, "MakeNodeDown", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.MakeNodeDown(ctx, nodeset, pod, reason)
+	tracing.End(span, err)
+	return err
+}
+
+// MakeNodeResume implements SlurmControlInterface.
+func (r *tracedSlurmControl) MakeNodeResume(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	ctx, span := startSpan(ctx, "MakeNodeResume", nodeset, podAttributes(pod)...)
+	err := r.SlurmControlInterface.MakeNodeResume(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return err
+}
+
+// IsNodeDrain implements SlurmControlInterface.
+func (r *tracedSlurmControl) IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
+	ctx, span := startSpan(ctx, "IsNodeDrain", nodeset, podAttributes(pod)...)
+	ok, err := r.SlurmControlInterface.IsNodeDrain(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return ok, err
+}
+
+// IsNodeDrained implements SlurmControlInterface.
+func (r *tracedSlurmControl) IsNodeDrained(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
+	ctx, span := startSpan(ctx, "IsNodeDrained", nodeset, podAttributes(pod)...)
+	ok, err := r.SlurmControlInterface.IsNodeDrained(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return ok, err
+}
+
+// IsNodeDownForUnresponsive implements SlurmControlInterface.
+func (r *tracedSlurmControl) IsNodeDownForUnresponsive(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
+	ctx, span := startSpan(ctx, "IsNodeDownForUnresponsive", nodeset, podAttributes(pod)...)
+	ok, err := r.SlurmControlInterface.IsNodeDownForUnresponsive(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return ok, err
+}
+
+// IsNodeReasonOurs implements SlurmControlInterface.
+func (r *tracedSlurmControl) IsNodeReasonOurs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
+	ctx, span := startSpan(ctx, "IsNodeReasonOurs", nodeset, podAttributes(pod)...)
+	ok, err := r.SlurmControlInterface.IsNodeReasonOurs(ctx, nodeset, pod)
+	tracing.End(span, err)
+	return ok, err
+}
+
+// CalculateNodeStatus implements SlurmControlInterface.
+func (r *tracedSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error) {
+	ctx, span := startSpan(ctx, "CalculateNodeStatus", nodeset, attributePods.Int(len(pods)))
+	status, err := r.SlurmControlInterface.CalculateNodeStatus(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return status, err
+}
+
+// GetNodeDeadlines implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetNodeDeadlines(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (*timestore.TimeStore, error) {
+	ctx, span := startSpan(ctx, "GetNodeDeadlines", nodeset, attributePods.Int(len(pods)))
+	deadlines, err := r.SlurmControlInterface.GetNodeDeadlines(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return deadlines, err
+}
+
+// GetNodeWorkloads implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetNodeWorkloads(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string]NodeWorkload, error) {
+	ctx, span := startSpan(ctx, "GetNodeWorkloads", nodeset, attributePods.Int(len(pods)))
+	workloads, err := r.SlurmControlInterface.GetNodeWorkloads(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return workloads, err
+}
+
+// GetPendingJobCount implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetPendingJobCount(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (int32, error) {
+	ctx, span := startSpan(ctx, "GetPendingJobCount", nodeset)
+	count, err := r.SlurmControlInterface.GetPendingJobCount(ctx, nodeset)
+	tracing.End(span, err)
+	return count, err
+}
+
+// CreatePowerSaveNodes implements SlurmControlInterface.
+func (r *tracedSlurmControl) CreatePowerSaveNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) error {
+	ctx, span := startSpan(ctx, "CreatePowerSaveNodes", nodeset, tracing.AttributeSlurmNode.StringSlice(nodeNames))
+	err := r.SlurmControlInterface.CreatePowerSaveNodes(ctx, nodeset, nodeNames)
+	tracing.End(span, err)
+	return err
+}
+
+// GetPoweredUpNodes implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetPoweredUpNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) ([]string, bool, error) {
+	ctx, span := startSpan(ctx, "GetPoweredUpNodes", nodeset, tracing.AttributeSlurmNode.StringSlice(nodeNames))
+	poweredUp, ok, err := r.SlurmControlInterface.GetPoweredUpNodes(ctx, nodeset, nodeNames)
+	tracing.End(span, err)
+	return poweredUp, ok, err
+}
+
+// GetNodesForPods implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, bool, error) {
+	ctx, span := startSpan(ctx, "GetNodesForPods", nodeset, attributePods.Int(len(pods)))
+	nodes, ok, err := r.SlurmControlInterface.GetNodesForPods(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return nodes, ok, err
+}
+
+// CheckReservationForNodeSet implements SlurmControlInterface.
+func (r *tracedSlurmControl) CheckReservationForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (bool, error) {
+	ctx, span := startSpan(ctx, "CheckReservationForNodeSet", nodeset)
+	ok, err := r.SlurmControlInterface.CheckReservationForNodeSet(ctx, nodeset)
+	tracing.End(span, err)
+	return ok, err
+}
+
+// GetPodsUnderReservation implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetPodsUnderReservation(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]*corev1.Pod, error) {
+	ctx, span := startSpan(ctx, "GetPodsUnderReservation", nodeset, attributePods.Int(len(pods)))
+	reserved, err := r.SlurmControlInterface.GetPodsUnderReservation(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return reserved, err
+}
+
+// SyncReservationForNodeSet implements SlurmControlInterface.
+func (r *tracedSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) error {
+	ctx, span := startSpan(ctx, "SyncReservationForNodeSet", nodeset, attributePods.Int(len(pods)))
+	err := r.SlurmControlInterface.SyncReservationForNodeSet(ctx, nodeset, pods)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteReservationForNodeSet implements SlurmControlInterface.
+func (r *tracedSlurmControl) DeleteReservationForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+	ctx, span := startSpan(ctx, "DeleteReservationForNodeSet", nodeset)
+	err := r.SlurmControlInterface.DeleteReservationForNodeSet(ctx, nodeset)
+	tracing.End(span, err)
+	return err
+}
+
+// GetDefunctNodesForNodeSet implements SlurmControlInterface.
+func (r *tracedSlurmControl) GetDefunctNodesForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) ([]DefunctNode, bool, error) {
+	ctx, span := startSpan(ctx, "GetDefunctNodesForNodeSet", nodeset)
+	nodes, ok, err := r.SlurmControlInterface.GetDefunctNodesForNodeSet(ctx, nodeset)
+	tracing.End(span, err)
+	return nodes, ok, err
+}
+
+// DeleteNode implements SlurmControlInterface.
+func (r *tracedSlurmControl) DeleteNode(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeName string) error {
+	ctx, span := startSpan(ctx, "DeleteNode", nodeset, tracing.AttributeSlurmNode.String(nodeName))
+	err := r.SlurmControlInterface.DeleteNode(ctx, nodeset, nodeName)
+	tracing.End(span, err)
+	return err
+}
+
+// startSpan starts the span of the method (e.g. "SlurmControl MakeNodeDrain").
+func startSpan(ctx context.Context, method string, nodeset *slinkyv1beta1.NodeSet, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		tracing.AttributeNamespace.String(nodeset.Namespace),
+		tracing.AttributeNodeSet.String(nodeset.Name),
+		tracing.AttributeController.String(nodeset.Spec.ControllerRef.Name),
+	)
+	return tracing.Start(ctx, "SlurmControl "+method, attrs...)
+}
+
+// podAttributes returns the attributes of the pod and its Slurm node.
+func podAttributes(pod *corev1.Pod) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.AttributePod.String(pod.Name),
+		tracing.AttributeSlurmNode.String(nodesetutils.GetSlurmNodeName(pod)),
+	}
+}
+
+var _ SlurmControlInterface = &tracedSlurmControl{}
@@ -330,6 +330,15 @@ func GetOrdinalPodName(nodeset *slinkyv1beta1.NodeSet, ordinal int) string {
 	return fmt.Sprintf("%s-%s", nodeset.Name, paddedOrdinal)
 }
 
+// GetOrdinalSlurmNodeName gets the Slurm node name of nodeset's child Pod with an ordinal index of ordinal,
+// before the Pod exists. The Slurm node name of a Pod using the host network cannot be known in advance.
+func GetOrdinalSlurmNodeName(nodeset *slinkyv1beta1.NodeSet, ordinal int) string {
+	if hostname := nodeset.Spec.Template.PodSpecWrapper.Hostname; hostname != "" {
+		return fmt.Sprintf("%s%s", hostname, GetPaddedOrdinal(nodeset, ordinal))
+	}
+	return GetOrdinalPodName(nodeset, ordinal)
+}
+
 // GetSlurmNodeName returns the Slurm node name.
 func GetSlurmNodeName(pod *corev1.Pod) string {
 	if pod.Labels[slinkyv1beta1.LabelNodeSetScalingMode] == string(slinkyv1beta1.ScalingModeStatefulset) {
@@ -434,6 +434,69 @@ func TestGetSlurmNodeName(t *testing.T) {
 	}
 }
 
+func TestGetOrdinalSlurmNodeName(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		ordinal int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "foo-0",
+			args: args{
+				nodeset: newNodeSet("foo"),
+				ordinal: 0,
+			},
+			want: "foo-0",
+		},
+		{
+			name: "hostname",
+			args: args{
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					nodeset := newNodeSet("foo")
+					nodeset.Spec.Template.PodSpecWrapper.Hostname = "bar-"
+					return nodeset
+				}(),
+				ordinal: 1,
+			},
+			want: "bar-1",
+		},
+		{
+			name: "ordinal padding",
+			args: args{
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					nodeset := newNodeSet("foo")
+					nodeset.Spec.Template.PodSpecWrapper.Hostname = "bar"
+					nodeset.Spec.OrdinalPadding = 3
+					return nodeset
+				}(),
+				ordinal: 2,
+			},
+			want: "bar002",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetOrdinalSlurmNodeName(tt.args.nodeset, tt.args.ordinal)
+			if got != tt.want {
+				t.Errorf("GetOrdinalSlurmNodeName() = %v, want %v", got, tt.want)
+			}
+			pod := NewNodeSetStatefulSetPod(fake.NewFakeClient(), tt.args.nodeset, controller, tt.args.ordinal, "")
+			if podNodeName := GetSlurmNodeName(pod); got != podNodeName {
+				t.Errorf("GetOrdinalSlurmNodeName() = %v, want GetSlurmNodeName() = %v", got, podNodeName)
+			}
+		})
+	}
+}
+
 func TestIsIdentityMatch(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/rolloututils"
 )
 
 // syncStatus handles determining and updating the status.
@@ -23,18 +25,19 @@ func (r *RestapiReconciler) syncStatus(
 	ctx context.Context,
 	restapi *slinkyv1beta1.RestApi,
 ) error {
-	logger := log.FromContext(ctx)
-
 	newStatus := slinkyv1beta1.RestApiStatus{
 		Conditions: []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, restapi.Status.Conditions...)
 
-	if apiequality.Semantic.DeepEqual(restapi.Status, newStatus) {
-		logger.V(2).Info("Restapi Status has not changed, skipping status update",
-			"restapi", klog.KObj(restapi), "status", restapi.Status)
-		return nil
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, restapi.Key(), deployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Deployment: %w", err)
+		}
+		deployment = nil
 	}
+	rolloututils.SetConditions(&newStatus.Conditions, restapi.Generation, rolloututils.FromDeployment(deployment))
 
 	if err := r.updateStatus(ctx, restapi, &newStatus); err != nil {
 		return fmt.Errorf("error updating Restapi(%s) status: %w",
@@ -66,6 +69,11 @@ func (r *RestapiReconciler) updateStatus(
 			}
 			return err
 		}
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("Restapi Status has not changed, skipping status update",
+				"restapi", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -102,6 +103,8 @@ func (r *SlurmClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&slinkyv1beta1.RestApi{}, eventhandler.NewRestApiEventHandler(r.Client)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
+			// The ClientMap is in memory, every replica which uses it must populate its own.
+			NeedLeaderElection: ptr.To(false),
 		}).
 		Complete(r)
 }
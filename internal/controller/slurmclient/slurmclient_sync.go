@@ -20,7 +20,9 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/restapibuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient/utils"
-	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
+	"github.com/SlinkyProject/slurm-operator/internal/tracing"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 )
 
 // Sync implements control logic for synchronizing a Restapi.
@@ -47,21 +49,21 @@ func (r *SlurmClientReconciler) Sync(ctx context.Context, req reconcile.Request)
 		return nil
 	}
 
-	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtRef(), controller.Namespace)
+	signingKey, err := r.refResolver.GetJwtSigningKey(ctx, controller)
 	if err != nil {
 		return err
 	}
 
 	lifetime := 15 * time.Minute
 	refresh := lifetime * 4 / 5
-	authToken, err := slurmjwt.NewToken(signingKey).
+	authToken, err := slurmjwt.NewTokenWithKey(signingKey).
 		WithLifetime(lifetime).
 		NewSignedToken()
 	if err != nil {
 		return fmt.Errorf("failed to create Slurm auth token: %w", err)
 	}
 
-	authTokenClaims, err := slurmjwt.ParseTokenClaims(authToken, signingKey)
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKey(authToken, signingKey)
 	if err != nil {
 		return fmt.Errorf("failed to parse Slurm auth token: %w", err)
 	}
@@ -90,6 +92,8 @@ func (r *SlurmClientReconciler) Sync(ctx context.Context, req reconcile.Request)
 	if err != nil {
 		return fmt.Errorf("failed to create slurm client: %w", err)
 	}
+	slurmClient = metrics.NewInstrumentedClient(controllerKey, slurmClient)
+	slurmClient = tracing.NewTracedClient(controllerKey, slurmClient)
 
 	if r.ClientMap.Add(controllerKey, slurmClient) {
 		logger.Info("Added slurm client", "controller", controllerKey.String())
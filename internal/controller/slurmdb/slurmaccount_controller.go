@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+)
+
+const (
+	SlurmAccountControllerName = "slurmaccount-controller"
+)
+
+// SlurmAccountReconciler reconciles a SlurmAccount object
+type SlurmAccountReconciler struct {
+	slurmdbReconciler
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *SlurmAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing SlurmAccount", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime), "error", retErr)
+		}
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: r.durationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = mgr.GetEventRecorder(SlurmAccountControllerName)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmAccount{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewSlurmAccountReconciler(c client.Client, cm *clientmap.ClientMap) *SlurmAccountReconciler {
+	return &SlurmAccountReconciler{
+		slurmdbReconciler: newSlurmdbReconciler(c, slurmcontrol.NewSlurmControl(cm)),
+	}
+}
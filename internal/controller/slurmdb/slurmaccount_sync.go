@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+// Sync implements control logic for synchronizing a SlurmAccount.
+func (r *SlurmAccountReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	account := &slinkyv1beta1.SlurmAccount{}
+	if err := r.Get(ctx, req.NamespacedName, account); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmAccount has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	account = account.DeepCopy()
+	defaults.SetSlurmAccountDefaults(account)
+
+	return syncEntity(ctx, &r.slurmdbReconciler, account, r.entity())
+}
+
+func (r *SlurmAccountReconciler) entity() *entity[*slinkyv1beta1.SlurmAccount, slinkyv1beta1.SlurmAccountSpec] {
+	return &entity[*slinkyv1beta1.SlurmAccount, slinkyv1beta1.SlurmAccountSpec]{
+		kind: "account",
+		name: func(account *slinkyv1beta1.SlurmAccount) string {
+			return account.AccountName()
+		},
+		controllerRef: func(account *slinkyv1beta1.SlurmAccount) corev1.LocalObjectReference {
+			return account.Spec.ControllerRef
+		},
+		deletionPolicy: func(account *slinkyv1beta1.SlurmAccount) slinkyv1beta1.SlurmdbDeletionPolicy {
+			return account.Spec.DeletionPolicy
+		},
+		status: func(account *slinkyv1beta1.SlurmAccount) *slinkyv1beta1.SlurmdbStatus {
+			return &account.Status
+		},
+		desired: desiredAccount,
+		get:     r.slurmControl.GetAccount,
+		sync:    r.slurmControl.SyncAccount,
+		delete:  r.slurmControl.DeleteAccount,
+	}
+}
+
+func desiredAccount(account *slinkyv1beta1.SlurmAccount) *slinkyv1beta1.SlurmAccountSpec {
+	s := &account.Spec
+	desired := &slinkyv1beta1.SlurmAccountSpec{
+		Description:   s.Description,
+		Organization:  s.Organization,
+		ParentAccount: s.ParentAccount,
+		Fairshare:     s.Fairshare,
+		QOS:           slices.Sorted(slices.Values(s.QOS)),
+		DefaultQOS:    s.DefaultQOS,
+	}
+	return desired
+}
@@ -0,0 +1,579 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+)
+
+// ErrNoClient indicates that there is no Slurm client for the Controller yet
+// (e.g. slurmrestd is not ready).
+var ErrNoClient = errors.New("no Slurm client for Controller")
+
+type SlurmControlInterface interface {
+	// GetAccount returns the account as observed in slurmdbd, or nil if it does not exist.
+	GetAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) (*slinkyv1beta1.SlurmAccountSpec, error)
+	// SyncAccount creates or updates the account, and its cluster association, in slurmdbd.
+	SyncAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error
+	// DeleteAccount deletes the account from slurmdbd.
+	DeleteAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error
+	// GetUser returns the user as observed in slurmdbd, or nil if it does not exist.
+	GetUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) (*slinkyv1beta1.SlurmUserSpec, error)
+	// SyncUser creates or updates the user, and its cluster associations, in slurmdbd.
+	SyncUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error
+	// DeleteUser deletes the user from slurmdbd.
+	DeleteUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error
+	// GetQOS returns the QOS as observed in slurmdbd, or nil if it does not exist.
+	GetQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) (*slinkyv1beta1.SlurmQOSSpec, error)
+	// SyncQOS creates or updates the QOS in slurmdbd.
+	SyncQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error
+	// DeleteQOS deletes the QOS from slurmdbd.
+	DeleteQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// GetAccount implements SlurmControlInterface.
+func (r *realSlurmControl) GetAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) (*slinkyv1beta1.SlurmAccountSpec, error) {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return nil, ErrNoClient
+	}
+
+	acct, err := getAccount(ctx, slurmClient, account.AccountName())
+	if err != nil || acct == nil {
+		return nil, err
+	}
+
+	observed := &slinkyv1beta1.SlurmAccountSpec{
+		Description:  acct.Description,
+		Organization: acct.Organization,
+	}
+
+	assocs, err := listAssociations(ctx, slurmClient, func(assoc *slurmapi.V0044Assoc) bool {
+		return ptr.Deref(assoc.Cluster, "") == controller.ClusterName() &&
+			ptr.Deref(assoc.Account, "") == account.AccountName() &&
+			assoc.User == "" &&
+			ptr.Deref(assoc.Partition, "") == ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(assocs) > 0 {
+		assoc := assocs[0]
+		observed.ParentAccount = ptr.Deref(assoc.ParentAccount, "")
+		observed.Fairshare = assoc.SharesRaw
+		observed.QOS = slices.Sorted(slices.Values(ptr.Deref(assoc.Qos, nil)))
+		if assoc.Default != nil {
+			observed.DefaultQOS = ptr.Deref(assoc.Default.Qos, "")
+		}
+	}
+
+	return observed, nil
+}
+
+// SyncAccount implements SlurmControlInterface.
+func (r *realSlurmControl) SyncAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	name := account.AccountName()
+	acct, err := getAccount(ctx, slurmClient, name)
+	if err != nil {
+		return err
+	}
+	if acct == nil {
+		// Match sacctmgr, which defaults both to the account name.
+		acct = &slurmapi.V0044Account{
+			Name:         name,
+			Description:  name,
+			Organization: name,
+		}
+	}
+	if account.Spec.Description != "" {
+		acct.Description = account.Spec.Description
+	}
+	if account.Spec.Organization != "" {
+		acct.Organization = account.Spec.Organization
+	}
+
+	slurmAccount := &slurmtypes.V0044Account{V0044Account: *acct}
+	// NOTE: slurmdbd creates or modifies the account as needed.
+	req := slurmapi.V0044OpenapiAccountsResp{
+		Accounts: []slurmapi.V0044Account{slurmAccount.V0044Account},
+	}
+	if err := slurmClient.Create(ctx, slurmAccount, req); err != nil {
+		return err
+	}
+
+	assoc := slurmapi.V0044Assoc{
+		Account:   ptr.To(name),
+		Cluster:   ptr.To(controller.ClusterName()),
+		SharesRaw: account.Spec.Fairshare,
+	}
+	if account.Spec.ParentAccount != "" {
+		assoc.ParentAccount = ptr.To(account.Spec.ParentAccount)
+	}
+	if len(account.Spec.QOS) > 0 {
+		assoc.Qos = ptr.To(slurmapi.V0044QosStringIdList(account.Spec.QOS))
+	}
+	if account.Spec.DefaultQOS != "" {
+		ensure(&assoc.Default).Qos = ptr.To(account.Spec.DefaultQOS)
+	}
+	return createAssociations(ctx, slurmClient, assoc)
+}
+
+// DeleteAccount implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	slurmAccount := &slurmtypes.V0044Account{
+		V0044Account: slurmapi.V0044Account{Name: account.AccountName()},
+	}
+	if err := slurmClient.Delete(ctx, slurmAccount); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GetUser implements SlurmControlInterface.
+func (r *realSlurmControl) GetUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) (*slinkyv1beta1.SlurmUserSpec, error) {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return nil, ErrNoClient
+	}
+
+	u, err := getUser(ctx, slurmClient, user.UserName())
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	observed := &slinkyv1beta1.SlurmUserSpec{}
+	if u.Default != nil {
+		observed.DefaultAccount = ptr.Deref(u.Default.Account, "")
+	}
+	if adminLevels := ptr.Deref(u.AdministratorLevel, nil); len(adminLevels) > 0 {
+		observed.AdminLevel = slinkyv1beta1.SlurmUserAdminLevel(adminLevels[0])
+	}
+
+	assocs, err := listAssociations(ctx, slurmClient, func(assoc *slurmapi.V0044Assoc) bool {
+		return ptr.Deref(assoc.Cluster, "") == controller.ClusterName() &&
+			assoc.User == user.UserName() &&
+			ptr.Deref(assoc.Partition, "") == ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, assoc := range assocs {
+		account := ptr.Deref(assoc.Account, "")
+		observed.Accounts = append(observed.Accounts, account)
+		// The limits are applied to every association of the user, so they
+		// are observed from the association of the default account.
+		if account != observed.DefaultAccount {
+			continue
+		}
+		observed.Fairshare = assoc.SharesRaw
+		observed.QOS = slices.Sorted(slices.Values(ptr.Deref(assoc.Qos, nil)))
+		if assoc.Default != nil {
+			observed.DefaultQOS = ptr.Deref(assoc.Default.Qos, "")
+		}
+	}
+
+	return observed, nil
+}
+
+// SyncUser implements SlurmControlInterface.
+func (r *realSlurmControl) SyncUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	name := user.UserName()
+	slurmUser := &slurmtypes.V0044User{
+		V0044User: slurmapi.V0044User{Name: name},
+	}
+	if account := user.DefaultAccount(); account != "" {
+		ensure(&slurmUser.Default).Account = ptr.To(account)
+	}
+	if user.Spec.AdminLevel != "" {
+		slurmUser.AdministratorLevel = ptr.To([]slurmapi.V0044UserAdministratorLevel{
+			slurmapi.V0044UserAdministratorLevel(user.Spec.AdminLevel),
+		})
+	}
+	// NOTE: slurmdbd creates or modifies the user as needed.
+	req := slurmapi.V0044OpenapiUsersResp{
+		Users: []slurmapi.V0044User{slurmUser.V0044User},
+	}
+	if err := slurmClient.Create(ctx, slurmUser, req); err != nil {
+		return err
+	}
+
+	assocs := make([]slurmapi.V0044Assoc, 0, len(user.Spec.Accounts))
+	for _, account := range user.Spec.Accounts {
+		assoc := slurmapi.V0044Assoc{
+			Account:   ptr.To(account),
+			Cluster:   ptr.To(controller.ClusterName()),
+			User:      name,
+			SharesRaw: user.Spec.Fairshare,
+		}
+		if len(user.Spec.QOS) > 0 {
+			assoc.Qos = ptr.To(slurmapi.V0044QosStringIdList(user.Spec.QOS))
+		}
+		if user.Spec.DefaultQOS != "" {
+			ensure(&assoc.Default).Qos = ptr.To(user.Spec.DefaultQOS)
+		}
+		assocs = append(assocs, assoc)
+	}
+	if err := createAssociations(ctx, slurmClient, assocs...); err != nil {
+		return err
+	}
+
+	// Remove the associations with accounts which are no longer listed.
+	accounts := set.New(user.Spec.Accounts...)
+	staleAssocs, err := listAssociations(ctx, slurmClient, func(assoc *slurmapi.V0044Assoc) bool {
+		return ptr.Deref(assoc.Cluster, "") == controller.ClusterName() &&
+			assoc.User == name &&
+			ptr.Deref(assoc.Partition, "") == "" &&
+			!accounts.Has(ptr.Deref(assoc.Account, ""))
+	})
+	if err != nil {
+		return err
+	}
+	for _, assoc := range staleAssocs {
+		slurmAssoc := &slurmtypes.V0044Assoc{V0044Assoc: assoc}
+		if err := slurmClient.Delete(ctx, slurmAssoc); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteUser implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	slurmUser := &slurmtypes.V0044User{
+		V0044User: slurmapi.V0044User{Name: user.UserName()},
+	}
+	if err := slurmClient.Delete(ctx, slurmUser); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GetQOS implements SlurmControlInterface.
+func (r *realSlurmControl) GetQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) (*slinkyv1beta1.SlurmQOSSpec, error) {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return nil, ErrNoClient
+	}
+
+	q, err := getQOS(ctx, slurmClient, qos.QOSName())
+	if err != nil || q == nil {
+		return nil, err
+	}
+
+	observed := &slinkyv1beta1.SlurmQOSSpec{
+		Description: ptr.Deref(q.Description, ""),
+		Priority:    noValToInt32(q.Priority),
+	}
+	for _, flag := range ptr.Deref(q.Flags, nil) {
+		observed.Flags = append(observed.Flags, string(flag))
+	}
+	slices.Sort(observed.Flags)
+	if q.Limits != nil && q.Limits.Max != nil {
+		limitsMax := q.Limits.Max
+		if limitsMax.WallClock != nil && limitsMax.WallClock.Per != nil {
+			if minutes := noValToInt32(limitsMax.WallClock.Per.Job); minutes != nil {
+				observed.MaxWallDurationPerJob = &metav1.Duration{
+					Duration: time.Duration(*minutes) * time.Minute,
+				}
+			}
+		}
+		if limitsMax.Tres != nil && limitsMax.Tres.Per != nil {
+			observed.MaxTRESPerJob = tresToMap(limitsMax.Tres.Per.Job)
+			observed.MaxTRESPerUser = tresToMap(limitsMax.Tres.Per.User)
+		}
+	}
+
+	return observed, nil
+}
+
+// SyncQOS implements SlurmControlInterface.
+func (r *realSlurmControl) SyncQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	current, err := getQOS(ctx, slurmClient, qos.QOSName())
+	if err != nil {
+		return err
+	}
+	var currentTRESPerJob, currentTRESPerUser *slurmapi.V0044TresList
+	if current != nil && current.Limits != nil && current.Limits.Max != nil &&
+		current.Limits.Max.Tres != nil && current.Limits.Max.Tres.Per != nil {
+		currentTRESPerJob = current.Limits.Max.Tres.Per.Job
+		currentTRESPerUser = current.Limits.Max.Tres.Per.User
+	}
+
+	slurmQOS := &slurmtypes.V0044Qos{
+		V0044Qos: slurmapi.V0044Qos{Name: ptr.To(qos.QOSName())},
+	}
+	if qos.Spec.Description != "" {
+		slurmQOS.Description = ptr.To(qos.Spec.Description)
+	}
+	if len(qos.Spec.Flags) > 0 {
+		flags := make([]slurmapi.V0044QosFlags, 0, len(qos.Spec.Flags))
+		for _, flag := range qos.Spec.Flags {
+			flags = append(flags, slurmapi.V0044QosFlags(flag))
+		}
+		slurmQOS.Flags = ptr.To(flags)
+	}
+	if qos.Spec.Priority != nil {
+		slurmQOS.Priority = newNoVal(*qos.Spec.Priority)
+	}
+	if d := qos.Spec.MaxWallDurationPerJob; d != nil {
+		limitsMax := ensure(&ensure(&slurmQOS.Limits).Max)
+		ensure(&ensure(&limitsMax.WallClock).Per).Job = newNoVal(int32(d.Duration / time.Minute))
+	}
+	if qos.Spec.MaxTRESPerJob != nil || qos.Spec.MaxTRESPerUser != nil {
+		limitsMax := ensure(&ensure(&slurmQOS.Limits).Max)
+		tresPer := ensure(&ensure(&limitsMax.Tres).Per)
+		if qos.Spec.MaxTRESPerJob != nil {
+			tresPer.Job = tresFromMap(qos.Spec.MaxTRESPerJob, currentTRESPerJob)
+		}
+		if qos.Spec.MaxTRESPerUser != nil {
+			tresPer.User = tresFromMap(qos.Spec.MaxTRESPerUser, currentTRESPerUser)
+		}
+	}
+
+	// NOTE: slurmdbd creates or modifies the QOS as needed.
+	req := slurmapi.V0044OpenapiSlurmdbdQosResp{
+		Qos: []slurmapi.V0044Qos{slurmQOS.V0044Qos},
+	}
+	return slurmClient.Create(ctx, slurmQOS, req)
+}
+
+// DeleteQOS implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error {
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+
+	slurmQOS := &slurmtypes.V0044Qos{
+		V0044Qos: slurmapi.V0044Qos{Name: ptr.To(qos.QOSName())},
+	}
+	if err := slurmClient.Delete(ctx, slurmQOS); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	key := ktypes.NamespacedName{
+		Namespace: controller.Namespace,
+		Name:      controller.Name,
+	}
+	return r.clientMap.Get(key)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func isNotFound(err error) bool {
+	return err != nil && err.Error() == http.StatusText(http.StatusNotFound)
+}
+
+// ensure allocates the value of the pointer, if it is nil, and returns it. The
+// nested structs of the Slurm API types are anonymous, so they are built by it.
+func ensure[T any](p **T) *T {
+	if *p == nil {
+		*p = new(T)
+	}
+	return *p
+}
+
+func getAccount(ctx context.Context, slurmClient slurmclient.Client, name string) (*slurmapi.V0044Account, error) {
+	slurmAccount := &slurmtypes.V0044Account{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(name), slurmAccount); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if slurmAccount.Name == "" {
+		return nil, nil
+	}
+	return &slurmAccount.V0044Account, nil
+}
+
+func getUser(ctx context.Context, slurmClient slurmclient.Client, name string) (*slurmapi.V0044User, error) {
+	slurmUser := &slurmtypes.V0044User{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(name), slurmUser); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if slurmUser.Name == "" {
+		return nil, nil
+	}
+	return &slurmUser.V0044User, nil
+}
+
+func getQOS(ctx context.Context, slurmClient slurmclient.Client, name string) (*slurmapi.V0044Qos, error) {
+	slurmQOS := &slurmtypes.V0044Qos{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(name), slurmQOS); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if ptr.Deref(slurmQOS.Name, "") == "" {
+		return nil, nil
+	}
+	return &slurmQOS.V0044Qos, nil
+}
+
+// listAssociations returns the associations which match, sorted by account.
+func listAssociations(ctx context.Context, slurmClient slurmclient.Client, match func(*slurmapi.V0044Assoc) bool) ([]slurmapi.V0044Assoc, error) {
+	assocList := &slurmtypes.V0044AssocList{}
+	if err := slurmClient.List(ctx, assocList); err != nil {
+		return nil, err
+	}
+
+	assocs := make([]slurmapi.V0044Assoc, 0)
+	for _, item := range assocList.Items {
+		if match(&item.V0044Assoc) {
+			assocs = append(assocs, item.V0044Assoc)
+		}
+	}
+	slices.SortFunc(assocs, func(a, b slurmapi.V0044Assoc) int {
+		return strings.Compare(ptr.Deref(a.Account, ""), ptr.Deref(b.Account, ""))
+	})
+
+	return assocs, nil
+}
+
+func createAssociations(ctx context.Context, slurmClient slurmclient.Client, assocs ...slurmapi.V0044Assoc) error {
+	if len(assocs) == 0 {
+		return nil
+	}
+
+	// NOTE: slurmdbd creates or modifies the associations as needed.
+	req := slurmapi.V0044OpenapiAssocsResp{
+		Associations: assocs,
+	}
+	slurmAssoc := &slurmtypes.V0044Assoc{V0044Assoc: assocs[0]}
+	return slurmClient.Create(ctx, slurmAssoc, req)
+}
+
+// tresName returns the TRES name (e.g. `cpu`, `gres/gpu`).
+func tresName(t slurmapi.V0044Tres) string {
+	name := ptr.Deref(t.Name, "")
+	if name == "" {
+		return t.Type
+	}
+	return t.Type + "/" + name
+}
+
+// tresToMap returns the TRES counts by TRES name (e.g. `cpu`, `gres/gpu`).
+// Cleared limits (negative counts) are omitted.
+func tresToMap(list *slurmapi.V0044TresList) map[string]int64 {
+	out := make(map[string]int64)
+	for _, t := range ptr.Deref(list, nil) {
+		count := ptr.Deref(t.Count, -1)
+		if count < 0 {
+			continue
+		}
+		out[tresName(t)] = count
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// tresFromMap returns the TRES list for the desired counts, clearing the
+// limits of the current TRES which are no longer desired.
+func tresFromMap(desired map[string]int64, current *slurmapi.V0044TresList) *slurmapi.V0044TresList {
+	names := set.KeySet(desired)
+	for _, t := range ptr.Deref(current, nil) {
+		if ptr.Deref(t.Count, -1) >= 0 {
+			names.Insert(tresName(t))
+		}
+	}
+
+	out := make(slurmapi.V0044TresList, 0, names.Len())
+	for _, name := range names.SortedList() {
+		typ, n, _ := strings.Cut(name, "/")
+		count, ok := desired[name]
+		if !ok {
+			count = -1
+		}
+		t := slurmapi.V0044Tres{Type: typ, Count: ptr.To(count)}
+		if n != "" {
+			t.Name = ptr.To(n)
+		}
+		out = append(out, t)
+	}
+	return &out
+}
+
+// newNoVal returns the number, as set.
+func newNoVal(number int32) *slurmapi.V0044Uint32NoValStruct {
+	return &slurmapi.V0044Uint32NoValStruct{
+		Set:    ptr.To(true),
+		Number: ptr.To(number),
+	}
+}
+
+// noValToInt32 returns the number, or nil if it is unset or infinite.
+func noValToInt32(v *slurmapi.V0044Uint32NoValStruct) *int32 {
+	if v == nil || !ptr.Deref(v.Set, false) || ptr.Deref(v.Infinite, false) {
+		return nil
+	}
+	return ptr.To(ptr.Deref(v.Number, 0))
+}
@@ -0,0 +1,543 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const clusterName = "slurm"
+
+func newController() *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			ClusterName: clusterName,
+		},
+	}
+}
+
+func newSlurmClientMap(controllerName string, client client.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := k8stypes.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+// requests records the requests which the Slurm client is given.
+type requests struct {
+	created []any
+	deleted []object.Object
+}
+
+func (r *requests) interceptorFuncs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(_ context.Context, _ object.Object, req any, _ ...client.CreateOption) error {
+			r.created = append(r.created, req)
+			return nil
+		},
+		Delete: func(_ context.Context, obj object.Object, _ ...client.DeleteOption) error {
+			r.deleted = append(r.deleted, obj)
+			return nil
+		},
+	}
+}
+
+func newAssoc(account, user string) types.V0044Assoc {
+	return types.V0044Assoc{
+		V0044Assoc: api.V0044Assoc{
+			Account: ptr.To(account),
+			Cluster: ptr.To(clusterName),
+			User:    user,
+		},
+	}
+}
+
+func newTres(typ, name string, count int64) api.V0044Tres {
+	tres := api.V0044Tres{Type: typ, Count: ptr.To(count)}
+	if name != "" {
+		tres.Name = ptr.To(name)
+	}
+	return tres
+}
+
+func Test_realSlurmControl_GetAccount(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	account := &slinkyv1beta1.SlurmAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "physics"},
+	}
+	assoc := newAssoc("physics", "")
+	assoc.ParentAccount = ptr.To("science")
+	assoc.SharesRaw = ptr.To[int32](10)
+	assoc.Qos = ptr.To(api.V0044QosStringIdList{"normal", "high"})
+	assoc.Default = &struct {
+		Qos *string `json:"qos,omitempty"`
+	}{Qos: ptr.To("normal")}
+	tests := []struct {
+		name    string
+		client  client.Client
+		want    *slinkyv1beta1.SlurmAccountSpec
+		wantErr bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewClientBuilder().Build(),
+			want:   nil,
+		},
+		{
+			name: "Found",
+			client: fake.NewClientBuilder().
+				WithObjects(&types.V0044Account{
+					V0044Account: api.V0044Account{
+						Name:         "physics",
+						Description:  "Physics",
+						Organization: "University",
+					},
+				}).
+				WithLists(&types.V0044AssocList{
+					Items: []types.V0044Assoc{assoc, newAssoc("physics", "alice")},
+				}).
+				Build(),
+			want: &slinkyv1beta1.SlurmAccountSpec{
+				Description:   "Physics",
+				Organization:  "University",
+				ParentAccount: "science",
+				Fairshare:     ptr.To[int32](10),
+				QOS:           []string{"high", "normal"},
+				DefaultQOS:    "normal",
+			},
+		},
+		{
+			name: "Get error",
+			client: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(context.Context, object.ObjectKey, object.Object, ...client.GetOption) error {
+					return errors.New(http.StatusText(http.StatusInternalServerError))
+				},
+			}).Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, tt.client))
+			got, err := r.GetAccount(ctx, controller, account)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncAccount(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	tests := []struct {
+		name        string
+		objs        []object.Object
+		account     *slinkyv1beta1.SlurmAccount
+		wantAccount api.V0044Account
+		wantAssoc   api.V0044Assoc
+	}{
+		{
+			name: "Create with defaults",
+			account: &slinkyv1beta1.SlurmAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "physics"},
+			},
+			wantAccount: api.V0044Account{
+				Name:         "physics",
+				Description:  "physics",
+				Organization: "physics",
+			},
+			wantAssoc: api.V0044Assoc{
+				Account: ptr.To("physics"),
+				Cluster: ptr.To(clusterName),
+			},
+		},
+		{
+			name: "Update",
+			objs: []object.Object{
+				&types.V0044Account{
+					V0044Account: api.V0044Account{
+						Name:         "physics",
+						Description:  "Physics",
+						Organization: "University",
+					},
+				},
+			},
+			account: &slinkyv1beta1.SlurmAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "physics"},
+				Spec: slinkyv1beta1.SlurmAccountSpec{
+					Description:   "Physics Department",
+					ParentAccount: "science",
+					Fairshare:     ptr.To[int32](10),
+					QOS:           []string{"normal"},
+					DefaultQOS:    "normal",
+				},
+			},
+			wantAccount: api.V0044Account{
+				Name:         "physics",
+				Description:  "Physics Department",
+				Organization: "University",
+			},
+			wantAssoc: api.V0044Assoc{
+				Account:       ptr.To("physics"),
+				Cluster:       ptr.To(clusterName),
+				ParentAccount: ptr.To("science"),
+				SharesRaw:     ptr.To[int32](10),
+				Qos:           ptr.To(api.V0044QosStringIdList{"normal"}),
+				Default: &struct {
+					Qos *string `json:"qos,omitempty"`
+				}{Qos: ptr.To("normal")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := &requests{}
+			sclient := fake.NewClientBuilder().WithObjects(tt.objs...).WithInterceptorFuncs(reqs.interceptorFuncs()).Build()
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, sclient))
+			if err := r.SyncAccount(ctx, controller, tt.account); err != nil {
+				t.Fatalf("SyncAccount() error = %v", err)
+			}
+			if len(reqs.created) != 2 {
+				t.Fatalf("SyncAccount() created = %v, want 2 requests", reqs.created)
+			}
+			accountReq := reqs.created[0].(api.V0044OpenapiAccountsResp)
+			if !apiequality.Semantic.DeepEqual(accountReq.Accounts, []api.V0044Account{tt.wantAccount}) {
+				t.Errorf("SyncAccount() accounts = %v, want %v", accountReq.Accounts, tt.wantAccount)
+			}
+			assocReq := reqs.created[1].(api.V0044OpenapiAssocsResp)
+			if !apiequality.Semantic.DeepEqual(assocReq.Associations, []api.V0044Assoc{tt.wantAssoc}) {
+				t.Errorf("SyncAccount() associations = %v, want %v", assocReq.Associations, tt.wantAssoc)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetUser(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	user := &slinkyv1beta1.SlurmUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+	}
+	defaultAssoc := newAssoc("physics", "alice")
+	defaultAssoc.SharesRaw = ptr.To[int32](5)
+	defaultAssoc.Qos = ptr.To(api.V0044QosStringIdList{"normal"})
+	slurmUser := &types.V0044User{
+		V0044User: api.V0044User{
+			Name:               "alice",
+			AdministratorLevel: ptr.To([]api.V0044UserAdministratorLevel{api.V0044UserAdministratorLevelOperator}),
+		},
+	}
+	slurmUser.Default = &struct {
+		Account *string `json:"account,omitempty"`
+		Wckey   *string `json:"wckey,omitempty"`
+	}{Account: ptr.To("physics")}
+	tests := []struct {
+		name    string
+		client  client.Client
+		want    *slinkyv1beta1.SlurmUserSpec
+		wantErr bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewClientBuilder().Build(),
+			want:   nil,
+		},
+		{
+			name: "Found",
+			client: fake.NewClientBuilder().
+				WithObjects(slurmUser).
+				WithLists(&types.V0044AssocList{
+					Items: []types.V0044Assoc{newAssoc("chemistry", "alice"), defaultAssoc, newAssoc("physics", "bob")},
+				}).
+				Build(),
+			want: &slinkyv1beta1.SlurmUserSpec{
+				DefaultAccount: "physics",
+				Accounts:       []string{"chemistry", "physics"},
+				AdminLevel:     slinkyv1beta1.SlurmUserAdminLevelOperator,
+				Fairshare:      ptr.To[int32](5),
+				QOS:            []string{"normal"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, tt.client))
+			got, err := r.GetUser(ctx, controller, user)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncUser(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	user := &slinkyv1beta1.SlurmUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec: slinkyv1beta1.SlurmUserSpec{
+			Accounts:   []string{"physics"},
+			AdminLevel: slinkyv1beta1.SlurmUserAdminLevelAdministrator,
+		},
+	}
+	tests := []struct {
+		name        string
+		lists       []object.ObjectList
+		wantDeleted []string
+	}{
+		{
+			name: "No stale associations",
+			lists: []object.ObjectList{
+				&types.V0044AssocList{
+					Items: []types.V0044Assoc{newAssoc("physics", "alice"), newAssoc("chemistry", "bob")},
+				},
+			},
+		},
+		{
+			name: "Stale association",
+			lists: []object.ObjectList{
+				&types.V0044AssocList{
+					Items: []types.V0044Assoc{newAssoc("physics", "alice"), newAssoc("chemistry", "alice")},
+				},
+			},
+			wantDeleted: []string{"chemistry"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := &requests{}
+			sclient := fake.NewClientBuilder().WithLists(tt.lists...).WithInterceptorFuncs(reqs.interceptorFuncs()).Build()
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, sclient))
+			if err := r.SyncUser(ctx, controller, user); err != nil {
+				t.Fatalf("SyncUser() error = %v", err)
+			}
+			if len(reqs.created) != 2 {
+				t.Fatalf("SyncUser() created = %v, want 2 requests", reqs.created)
+			}
+			userReq := reqs.created[0].(api.V0044OpenapiUsersResp)
+			gotUser := userReq.Users[0]
+			if gotUser.Name != "alice" || gotUser.Default == nil || ptr.Deref(gotUser.Default.Account, "") != "physics" {
+				t.Errorf("SyncUser() user = %v, want alice with default account physics", gotUser)
+			}
+			if got := ptr.Deref(gotUser.AdministratorLevel, nil); len(got) != 1 || got[0] != api.V0044UserAdministratorLevelAdministrator {
+				t.Errorf("SyncUser() administrator level = %v, want %v", got, api.V0044UserAdministratorLevelAdministrator)
+			}
+			var gotDeleted []string
+			for _, obj := range reqs.deleted {
+				gotDeleted = append(gotDeleted, ptr.Deref(obj.(*types.V0044Assoc).Account, ""))
+			}
+			if !apiequality.Semantic.DeepEqual(gotDeleted, tt.wantDeleted) {
+				t.Errorf("SyncUser() deleted = %v, want %v", gotDeleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetQOS(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	qos := &slinkyv1beta1.SlurmQOS{
+		ObjectMeta: metav1.ObjectMeta{Name: "high"},
+	}
+	slurmQOS := &types.V0044Qos{
+		V0044Qos: api.V0044Qos{
+			Name:        ptr.To("high"),
+			Description: ptr.To("High priority"),
+			Flags:       ptr.To([]api.V0044QosFlags{"DENY_LIMIT", "NO_DECAY"}),
+			Priority:    newNoVal(100),
+		},
+	}
+	limitsMax := ensure(&ensure(&slurmQOS.Limits).Max)
+	ensure(&ensure(&limitsMax.WallClock).Per).Job = newNoVal(90)
+	ensure(&ensure(&limitsMax.Tres).Per).Job = &api.V0044TresList{
+		newTres("cpu", "", 64),
+		newTres("gres", "gpu", 4),
+		newTres("mem", "", -1),
+	}
+	tests := []struct {
+		name   string
+		client client.Client
+		want   *slinkyv1beta1.SlurmQOSSpec
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewClientBuilder().Build(),
+			want:   nil,
+		},
+		{
+			name:   "Found",
+			client: fake.NewClientBuilder().WithObjects(slurmQOS).Build(),
+			want: &slinkyv1beta1.SlurmQOSSpec{
+				Description:           "High priority",
+				Flags:                 []string{"DENY_LIMIT", "NO_DECAY"},
+				Priority:              ptr.To[int32](100),
+				MaxWallDurationPerJob: &metav1.Duration{Duration: 90 * time.Minute},
+				MaxTRESPerJob:         map[string]int64{"cpu": 64, "gres/gpu": 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, tt.client))
+			got, err := r.GetQOS(ctx, controller, qos)
+			if err != nil {
+				t.Fatalf("GetQOS() error = %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetQOS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncQOS(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	currentQOS := &types.V0044Qos{
+		V0044Qos: api.V0044Qos{Name: ptr.To("high")},
+	}
+	ensure(&ensure(&ensure(&ensure(&currentQOS.Limits).Max).Tres).Per).Job = &api.V0044TresList{
+		newTres("cpu", "", 64),
+		newTres("gres", "gpu", 4),
+	}
+	qos := &slinkyv1beta1.SlurmQOS{
+		ObjectMeta: metav1.ObjectMeta{Name: "high"},
+		Spec: slinkyv1beta1.SlurmQOSSpec{
+			Priority:              ptr.To[int32](100),
+			MaxWallDurationPerJob: &metav1.Duration{Duration: 2 * time.Hour},
+			MaxTRESPerJob:         map[string]int64{"cpu": 32},
+		},
+	}
+	tests := []struct {
+		name        string
+		objs        []object.Object
+		wantTRESJob *api.V0044TresList
+	}{
+		{
+			name:        "Create",
+			wantTRESJob: &api.V0044TresList{newTres("cpu", "", 32)},
+		},
+		{
+			name: "Clear removed TRES",
+			objs: []object.Object{currentQOS},
+			wantTRESJob: &api.V0044TresList{
+				newTres("cpu", "", 32),
+				newTres("gres", "gpu", -1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := &requests{}
+			sclient := fake.NewClientBuilder().WithObjects(tt.objs...).WithInterceptorFuncs(reqs.interceptorFuncs()).Build()
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, sclient))
+			if err := r.SyncQOS(ctx, controller, qos); err != nil {
+				t.Fatalf("SyncQOS() error = %v", err)
+			}
+			if len(reqs.created) != 1 {
+				t.Fatalf("SyncQOS() created = %v, want 1 request", reqs.created)
+			}
+			got := reqs.created[0].(api.V0044OpenapiSlurmdbdQosResp).Qos[0]
+			if ptr.Deref(got.Name, "") != "high" {
+				t.Errorf("SyncQOS() name = %v, want %v", ptr.Deref(got.Name, ""), "high")
+			}
+			if got := noValToInt32(got.Priority); ptr.Deref(got, 0) != 100 {
+				t.Errorf("SyncQOS() priority = %v, want %v", got, 100)
+			}
+			if got := noValToInt32(got.Limits.Max.WallClock.Per.Job); ptr.Deref(got, 0) != 120 {
+				t.Errorf("SyncQOS() max wall clock = %v, want %v", got, 120)
+			}
+			if got := got.Limits.Max.Tres.Per.Job; !apiequality.Semantic.DeepEqual(got, tt.wantTRESJob) {
+				t.Errorf("SyncQOS() max TRES per job = %v, want %v", got, tt.wantTRESJob)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteQOS(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	qos := &slinkyv1beta1.SlurmQOS{
+		ObjectMeta: metav1.ObjectMeta{Name: "high"},
+	}
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "Deleted",
+		},
+		{
+			name: "Not found",
+			err:  errors.New(http.StatusText(http.StatusNotFound)),
+		},
+		{
+			name:    "Error",
+			err:     errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+					return tt.err
+				},
+			}).Build()
+			r := NewSlurmControl(newSlurmClientMap(controller.Name, sclient))
+			if err := r.DeleteQOS(ctx, controller, qos); (err != nil) != tt.wantErr {
+				t.Errorf("DeleteQOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	ctx := context.Background()
+	controller := newController()
+	r := NewSlurmControl(clientmap.NewClientMap())
+	if _, err := r.GetAccount(ctx, controller, &slinkyv1beta1.SlurmAccount{}); !errors.Is(err, ErrNoClient) {
+		t.Errorf("GetAccount() error = %v, want %v", err, ErrNoClient)
+	}
+	if err := r.SyncUser(ctx, controller, &slinkyv1beta1.SlurmUser{}); !errors.Is(err, ErrNoClient) {
+		t.Errorf("SyncUser() error = %v, want %v", err, ErrNoClient)
+	}
+	if err := r.DeleteQOS(ctx, controller, &slinkyv1beta1.SlurmQOS{}); !errors.Is(err, ErrNoClient) {
+		t.Errorf("DeleteQOS() error = %v, want %v", err, ErrNoClient)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"flag"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+const (
+	// ResyncInterval is how often the entities are compared with slurmdbd,
+	// which cannot be watched for changes made by other clients (e.g. sacctmgr).
+	ResyncInterval = 5 * time.Minute
+
+	// NotReadyRequeueInterval is how long to wait before syncing again, when
+	// the Controller or its Slurm client is not ready.
+	NotReadyRequeueInterval = 30 * time.Second
+)
+
+// Reasons for Slurm accounting entity events and conditions
+const (
+	// CreatedReason is added to an event when the entity is created in slurmdbd.
+	CreatedReason = "Created"
+	// DeletedReason is added to an event when the entity is deleted from slurmdbd.
+	DeletedReason = "Deleted"
+	// DriftCorrectedReason is added to an event when the entity differed from its spec in slurmdbd, and was reverted.
+	DriftCorrectedReason = "DriftCorrected"
+	// SyncedReason is added to a condition when the entity matches its spec in slurmdbd.
+	SyncedReason = "Synced"
+	// SyncFailedReason is added to an event or condition when the entity could not be synced with slurmdbd.
+	SyncFailedReason = "SyncFailed"
+	// DeleteFailedReason is added to an event when the entity could not be deleted from slurmdbd.
+	DeleteFailedReason = "DeleteFailed"
+	// ControllerNotFoundReason is added to a condition when the referenced Controller does not exist.
+	ControllerNotFoundReason = "ControllerNotFound"
+	// ClientNotReadyReason is added to a condition when there is no Slurm client for the Controller.
+	ClientNotReadyReason = "ClientNotReady"
+)
+
+func init() {
+	flag.IntVar(&maxConcurrentReconciles, "slurmdb-workers", maxConcurrentReconciles, "Max concurrent workers for each of the SlurmAccount, SlurmUser, and SlurmQOS controllers.")
+}
+
+var (
+	maxConcurrentReconciles = 1
+)
+
+// slurmdbReconciler holds what is common to the reconcilers of Slurm accounting entities.
+type slurmdbReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	refResolver   *refresolver.RefResolver
+	slurmControl  slurmcontrol.SlurmControlInterface
+	eventRecorder events.EventRecorder
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	durationStore *durationstore.DurationStore
+}
+
+func newSlurmdbReconciler(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) slurmdbReconciler {
+	return slurmdbReconciler{
+		Client:        c,
+		Scheme:        c.Scheme(),
+		refResolver:   refresolver.New(c),
+		slurmControl:  slurmControl,
+		eventRecorder: events.NewFakeRecorder(100),
+		durationStore: durationstore.NewDurationStore(durationstore.Less),
+	}
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// entity describes how one kind of Slurm accounting entity, whose spec is S,
+// is synced with slurmdbd.
+type entity[T client.Object, S any] struct {
+	// The Slurm name of the kind (e.g. "account").
+	kind string
+	// The Slurm name of the entity.
+	name           func(T) string
+	controllerRef  func(T) corev1.LocalObjectReference
+	deletionPolicy func(T) slinkyv1beta1.SlurmdbDeletionPolicy
+	status         func(T) *slinkyv1beta1.SlurmdbStatus
+	// The desired state of the entity, normalized for comparison with the
+	// observed state. Fields which are not synced must be left unset.
+	desired func(T) *S
+
+	get    func(context.Context, *slinkyv1beta1.Controller, T) (*S, error)
+	sync   func(context.Context, *slinkyv1beta1.Controller, T) error
+	delete func(context.Context, *slinkyv1beta1.Controller, T) error
+}
+
+// syncEntity implements control logic for synchronizing a Slurm accounting entity with slurmdbd.
+func syncEntity[T client.Object, S any](ctx context.Context, r *slurmdbReconciler, obj T, e *entity[T, S]) error {
+	logger := log.FromContext(ctx)
+
+	controllerRef := e.controllerRef(obj)
+	controller, err := r.refResolver.GetController(ctx, controllerRef, obj.GetNamespace())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		controller = nil
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return syncDeletion(ctx, r, obj, controller, e)
+	}
+
+	if err := addFinalizerIfNeeded(ctx, r, obj); err != nil {
+		return err
+	}
+
+	newStatus := e.status(obj).DeepCopy()
+	newStatus.ObservedGeneration = obj.GetGeneration()
+
+	key := objectutils.KeyFunc(obj)
+	var syncErr error
+	if controller == nil {
+		logger.Info("Controller not found, cannot sync", "controllerRef", controllerRef.Name)
+		setSyncedCondition(newStatus, obj, metav1.ConditionFalse, ControllerNotFoundReason,
+			fmt.Sprintf("Controller %q not found", controllerRef.Name))
+		r.durationStore.Push(key, NotReadyRequeueInterval)
+	} else {
+		syncErr = syncSlurmdb(ctx, r, obj, controller, newStatus, e)
+		switch {
+		case errors.Is(syncErr, slurmcontrol.ErrNoClient):
+			logger.V(1).Info("Slurm client is not ready, cannot sync", "controller", klog.KObj(controller))
+			setSyncedCondition(newStatus, obj, metav1.ConditionFalse, ClientNotReadyReason, syncErr.Error())
+			r.durationStore.Push(key, NotReadyRequeueInterval)
+			syncErr = nil
+		case syncErr != nil:
+			msg := fmt.Sprintf("Failed to sync %s %q: %v", e.kind, e.name(obj), syncErr)
+			r.eventRecorder.Eventf(obj, nil, corev1.EventTypeWarning, SyncFailedReason, "Sync", msg)
+			setSyncedCondition(newStatus, obj, metav1.ConditionFalse, SyncFailedReason, msg)
+		default:
+			newStatus.LastSyncTime = new(metav1.Now())
+			setSyncedCondition(newStatus, obj, metav1.ConditionTrue, SyncedReason,
+				fmt.Sprintf("The %s %q matches the spec in slurmdbd", e.kind, e.name(obj)))
+			r.durationStore.Push(key, ResyncInterval)
+		}
+	}
+
+	if err := syncStatus(ctx, r, obj, newStatus, e); err != nil {
+		return errors.Join(syncErr, fmt.Errorf("failed status syncFn: %w", err))
+	}
+	return syncErr
+}
+
+// syncSlurmdb creates the entity in slurmdbd, or reverts any drift from its spec.
+func syncSlurmdb[T client.Object, S any](
+	ctx context.Context,
+	r *slurmdbReconciler,
+	obj T,
+	controller *slinkyv1beta1.Controller,
+	newStatus *slinkyv1beta1.SlurmdbStatus,
+	e *entity[T, S],
+) error {
+	logger := log.FromContext(ctx)
+
+	observed, err := e.get(ctx, controller, obj)
+	if err != nil {
+		return err
+	}
+
+	if observed == nil {
+		if err := e.sync(ctx, controller, obj); err != nil {
+			return err
+		}
+		newStatus.Drift = nil
+		r.eventRecorder.Eventf(obj, nil, corev1.EventTypeNormal, CreatedReason, "Create",
+			"Created %s %q in slurmdbd", e.kind, e.name(obj))
+		return nil
+	}
+
+	drift := diffSpec(e.desired(obj), observed)
+	newStatus.Drift = drift
+	if len(drift) == 0 {
+		logger.V(2).Info("Slurm accounting entity has not drifted", "kind", e.kind, "name", e.name(obj))
+		return nil
+	}
+
+	if err := e.sync(ctx, controller, obj); err != nil {
+		return err
+	}
+	r.eventRecorder.Eventf(obj, nil, corev1.EventTypeWarning, DriftCorrectedReason, "Sync",
+		"Reverted %s %q in slurmdbd, fields differed from spec: %s", e.kind, e.name(obj), strings.Join(drift, ", "))
+
+	return nil
+}
+
+// syncDeletion deletes the entity from slurmdbd, according to the deletion policy, then removes the finalizer.
+func syncDeletion[T client.Object, S any](
+	ctx context.Context,
+	r *slurmdbReconciler,
+	obj T,
+	controller *slinkyv1beta1.Controller,
+	e *entity[T, S],
+) error {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(obj, slinkyv1beta1.FinalizerSlurmdbEntity) {
+		return nil
+	}
+
+	// If the Controller or its Slurm client does not exist, we cannot delete
+	// from slurmdbd and must remove the finalizer in order to permit cleanup.
+	switch {
+	case controller == nil:
+		logger.Info("Controller not found, cannot delete from slurmdbd", "kind", e.kind, "name", e.name(obj))
+	case e.deletionPolicy(obj) == slinkyv1beta1.SlurmdbDeletionPolicyRetain:
+		logger.V(1).Info("Retaining Slurm accounting entity in slurmdbd", "kind", e.kind, "name", e.name(obj))
+	default:
+		err := e.delete(ctx, controller, obj)
+		switch {
+		case errors.Is(err, slurmcontrol.ErrNoClient):
+			logger.Info("Slurm client is not ready, cannot delete from slurmdbd", "kind", e.kind, "name", e.name(obj))
+		case err != nil:
+			msg := fmt.Sprintf("Failed to delete %s %q: %v", e.kind, e.name(obj), err)
+			r.eventRecorder.Eventf(obj, nil, corev1.EventTypeWarning, DeleteFailedReason, "Delete", msg)
+			return err
+		default:
+			r.eventRecorder.Eventf(obj, nil, corev1.EventTypeNormal, DeletedReason, "Delete",
+				"Deleted %s %q from slurmdbd", e.kind, e.name(obj))
+		}
+	}
+
+	return updateFinalizers(ctx, r, obj, func(o T) bool {
+		return controllerutil.RemoveFinalizer(o, slinkyv1beta1.FinalizerSlurmdbEntity)
+	})
+}
+
+func addFinalizerIfNeeded[T client.Object](ctx context.Context, r *slurmdbReconciler, obj T) error {
+	return updateFinalizers(ctx, r, obj, func(o T) bool {
+		return controllerutil.AddFinalizer(o, slinkyv1beta1.FinalizerSlurmdbEntity)
+	})
+}
+
+func updateFinalizers[T client.Object](ctx context.Context, r *slurmdbReconciler, obj T, mutate func(T) bool) error {
+	logger := log.FromContext(ctx)
+
+	if !mutate(obj.DeepCopyObject().(T)) {
+		return nil
+	}
+
+	logger.V(1).Info("Pending Finalizer update", "object", klog.KObj(obj))
+	mutateFn := func(o T) error {
+		mutate(o)
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, obj, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncStatus handles updating the status.
+func syncStatus[T client.Object, S any](
+	ctx context.Context,
+	r *slurmdbReconciler,
+	obj T,
+	newStatus *slinkyv1beta1.SlurmdbStatus,
+	e *entity[T, S],
+) error {
+	logger := log.FromContext(ctx)
+
+	if apiequality.Semantic.DeepEqual(e.status(obj), newStatus) {
+		logger.V(2).Info("Status has not changed, skipping status update",
+			"object", klog.KObj(obj), "status", e.status(obj))
+		return nil
+	}
+
+	logger.V(1).Info("Pending Status update",
+		"object", klog.KObj(obj), "newStatus", newStatus)
+	mutateFn := func(o T) error {
+		*e.status(o) = *newStatus
+		return nil
+	}
+	if err := objectutils.StatusPatchObject(r.Client, ctx, obj, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error updating %s status: %w", klog.KObj(obj), err)
+		}
+	}
+
+	return nil
+}
+
+func setSyncedCondition(status *slinkyv1beta1.SlurmdbStatus, obj client.Object, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               slurmconditions.SlurmdbConditionSynced,
+		Status:             conditionStatus,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// diffSpec returns the JSON names of the fields of desired which differ from
+// observed. Fields which are unset in desired are not synced, so they are
+// never reported.
+func diffSpec[S any](desired, observed *S) []string {
+	var drift []string
+
+	desiredValue := reflect.ValueOf(desired).Elem()
+	observedValue := reflect.ValueOf(observed).Elem()
+	for i := range desiredValue.NumField() {
+		if desiredValue.Field(i).IsZero() {
+			continue
+		}
+		if apiequality.Semantic.DeepEqual(desiredValue.Field(i).Interface(), observedValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(desiredValue.Type().Field(i).Tag.Get("json"), ",")
+		drift = append(drift, name)
+	}
+
+	return drift
+}
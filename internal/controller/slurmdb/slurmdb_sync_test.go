@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func init() {
+	utilruntime.Must(scheme.AddToScheme(scheme.Scheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme.Scheme))
+}
+
+// fakeSlurmControl is an in-memory slurmdbd, holding accounts, users, and QOS
+// by name.
+type fakeSlurmControl struct {
+	slurmcontrol.SlurmControlInterface
+
+	accounts map[string]*slinkyv1beta1.SlurmAccountSpec
+	users    map[string]*slinkyv1beta1.SlurmUserSpec
+	qos      map[string]*slinkyv1beta1.SlurmQOSSpec
+	err      error
+
+	synced  int
+	deleted int
+}
+
+func (f *fakeSlurmControl) GetAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) (*slinkyv1beta1.SlurmAccountSpec, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.accounts[account.AccountName()], nil
+}
+
+func (f *fakeSlurmControl) SyncAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.synced++
+	f.accounts[account.AccountName()] = desiredAccount(account)
+	return nil
+}
+
+func (f *fakeSlurmControl) DeleteAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.SlurmAccount) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted++
+	delete(f.accounts, account.AccountName())
+	return nil
+}
+
+func (f *fakeSlurmControl) GetUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) (*slinkyv1beta1.SlurmUserSpec, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.users[user.UserName()], nil
+}
+
+func (f *fakeSlurmControl) SyncUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.synced++
+	f.users[user.UserName()] = desiredUser(user)
+	return nil
+}
+
+func (f *fakeSlurmControl) DeleteUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted++
+	delete(f.users, user.UserName())
+	return nil
+}
+
+func (f *fakeSlurmControl) GetQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) (*slinkyv1beta1.SlurmQOSSpec, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.qos[qos.QOSName()], nil
+}
+
+func (f *fakeSlurmControl) SyncQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.synced++
+	f.qos[qos.QOSName()] = desiredQOS(qos)
+	return nil
+}
+
+func (f *fakeSlurmControl) DeleteQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.SlurmQOS) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted++
+	delete(f.qos, qos.QOSName())
+	return nil
+}
+
+func newSlurmAccountController(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) *SlurmAccountReconciler {
+	return &SlurmAccountReconciler{
+		slurmdbReconciler: newSlurmdbReconciler(c, slurmControl),
+	}
+}
+
+func TestSlurmAccountReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newAccount := func() *slinkyv1beta1.SlurmAccount {
+		account := testutils.NewSlurmAccount("science", controller)
+		account.Spec.Description = "Science"
+		account.Spec.Fairshare = ptr.To[int32](10)
+		account.Spec.QOS = []string{"normal", "high"}
+		return account
+	}
+	request := reconcile.Request{
+		NamespacedName: newAccount().Key(),
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantSynced   int
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+		wantDrift    []string
+	}{
+		{
+			name:    "Create",
+			objects: []client.Object{controller.DeepCopy(), newAccount()},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "No drift",
+			objects: []client.Object{controller.DeepCopy(), newAccount()},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{
+					"science": desiredAccount(newAccount()),
+				},
+			},
+			wantSynced: 0,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "Drift is reverted",
+			objects: []client.Object{controller.DeepCopy(), newAccount()},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{
+					"science": {
+						Description:  "Science",
+						Organization: "university",
+						Fairshare:    ptr.To[int32](1),
+						QOS:          []string{"high", "normal"},
+					},
+				},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+			wantDrift:  []string{"fairshare"},
+		},
+		{
+			name:    "Controller not found",
+			objects: []client.Object{newAccount()},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ControllerNotFoundReason,
+		},
+		{
+			name:    "Client not ready",
+			objects: []client.Object{controller.DeepCopy(), newAccount()},
+			slurmControl: &fakeSlurmControl{
+				err: slurmcontrol.ErrNoClient,
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ClientNotReadyReason,
+		},
+		{
+			name:    "Sync failed",
+			objects: []client.Object{controller.DeepCopy(), newAccount()},
+			slurmControl: &fakeSlurmControl{
+				err: errors.New("slurmdbd unavailable"),
+			},
+			wantErr:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: SyncFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmAccount{}).
+				Build()
+			r := newSlurmAccountController(c, tt.slurmControl)
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmAccountReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.synced != tt.wantSynced {
+				t.Errorf("SyncAccount() calls = %v, want %v", tt.slurmControl.synced, tt.wantSynced)
+			}
+
+			account := &slinkyv1beta1.SlurmAccount{}
+			if err := c.Get(context.TODO(), request.NamespacedName, account); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !controllerutil.ContainsFinalizer(account, slinkyv1beta1.FinalizerSlurmdbEntity) {
+				t.Errorf("SlurmAccount is missing finalizer %q", slinkyv1beta1.FinalizerSlurmdbEntity)
+			}
+			cond := meta.FindStatusCondition(account.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			if cond == nil {
+				t.Fatalf("SlurmAccount is missing condition %q", slurmconditions.SlurmdbConditionSynced)
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("Synced condition = %v/%v, want %v/%v", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if len(account.Status.Drift) != len(tt.wantDrift) {
+				t.Errorf("Status.Drift = %v, want %v", account.Status.Drift, tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestSlurmAccountReconciler_Sync_Deletion(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newAccount := func(policy slinkyv1beta1.SlurmdbDeletionPolicy) *slinkyv1beta1.SlurmAccount {
+		account := testutils.NewSlurmAccount("science", controller)
+		account.Spec.DeletionPolicy = policy
+		account.Finalizers = []string{slinkyv1beta1.FinalizerSlurmdbEntity}
+		account.DeletionTimestamp = ptr.To(metav1.Now())
+		return account
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantDeleted  int
+	}{
+		{
+			name:    "Delete",
+			objects: []client.Object{controller.DeepCopy(), newAccount(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{
+					"science": {},
+				},
+			},
+			wantDeleted: 1,
+		},
+		{
+			name:    "Retain",
+			objects: []client.Object{controller.DeepCopy(), newAccount(slinkyv1beta1.SlurmdbDeletionPolicyRetain)},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{
+					"science": {},
+				},
+			},
+			wantDeleted: 0,
+		},
+		{
+			name:    "Controller not found",
+			objects: []client.Object{newAccount(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				accounts: map[string]*slinkyv1beta1.SlurmAccountSpec{},
+			},
+			wantDeleted: 0,
+		},
+		{
+			name:    "Delete failed",
+			objects: []client.Object{controller.DeepCopy(), newAccount(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				err: errors.New("slurmdbd unavailable"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmAccount{}).
+				Build()
+			r := newSlurmAccountController(c, tt.slurmControl)
+			request := reconcile.Request{
+				NamespacedName: newAccount("").Key(),
+			}
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmAccountReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.deleted != tt.wantDeleted {
+				t.Errorf("DeleteAccount() calls = %v, want %v", tt.slurmControl.deleted, tt.wantDeleted)
+			}
+
+			// The fake client removes the object once its last finalizer is removed.
+			account := &slinkyv1beta1.SlurmAccount{}
+			gotRemoved := apierrors.IsNotFound(c.Get(context.TODO(), request.NamespacedName, account))
+			if wantRemoved := !tt.wantErr; gotRemoved != wantRemoved {
+				t.Errorf("SlurmAccount removed = %v, want %v", gotRemoved, wantRemoved)
+			}
+		})
+	}
+}
+
+func Test_diffSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  *slinkyv1beta1.SlurmQOSSpec
+		observed *slinkyv1beta1.SlurmQOSSpec
+		want     []string
+	}{
+		{
+			name:     "Unset fields are not managed",
+			desired:  &slinkyv1beta1.SlurmQOSSpec{},
+			observed: &slinkyv1beta1.SlurmQOSSpec{Description: "normal", Priority: ptr.To[int32](10)},
+			want:     nil,
+		},
+		{
+			name: "Equal",
+			desired: &slinkyv1beta1.SlurmQOSSpec{
+				Priority:      ptr.To[int32](10),
+				MaxTRESPerJob: map[string]int64{"cpu": 8},
+			},
+			observed: &slinkyv1beta1.SlurmQOSSpec{
+				Priority:      ptr.To[int32](10),
+				MaxTRESPerJob: map[string]int64{"cpu": 8},
+			},
+			want: nil,
+		},
+		{
+			name: "Drift",
+			desired: &slinkyv1beta1.SlurmQOSSpec{
+				Description:   "high",
+				Priority:      ptr.To[int32](10),
+				MaxTRESPerJob: map[string]int64{"cpu": 8},
+			},
+			observed: &slinkyv1beta1.SlurmQOSSpec{
+				Description:   "high",
+				Priority:      ptr.To[int32](1),
+				MaxTRESPerJob: map[string]int64{"cpu": 16},
+			},
+			want: []string{"priority", "maxTRESPerJob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffSpec(tt.desired, tt.observed)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diffSpec() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("diffSpec() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+// Sync implements control logic for synchronizing a SlurmQOS.
+func (r *SlurmQOSReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	qos := &slinkyv1beta1.SlurmQOS{}
+	if err := r.Get(ctx, req.NamespacedName, qos); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmQOS has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	qos = qos.DeepCopy()
+	defaults.SetSlurmQOSDefaults(qos)
+
+	return syncEntity(ctx, &r.slurmdbReconciler, qos, r.entity())
+}
+
+func (r *SlurmQOSReconciler) entity() *entity[*slinkyv1beta1.SlurmQOS, slinkyv1beta1.SlurmQOSSpec] {
+	return &entity[*slinkyv1beta1.SlurmQOS, slinkyv1beta1.SlurmQOSSpec]{
+		kind: "QOS",
+		name: func(qos *slinkyv1beta1.SlurmQOS) string {
+			return qos.QOSName()
+		},
+		controllerRef: func(qos *slinkyv1beta1.SlurmQOS) corev1.LocalObjectReference {
+			return qos.Spec.ControllerRef
+		},
+		deletionPolicy: func(qos *slinkyv1beta1.SlurmQOS) slinkyv1beta1.SlurmdbDeletionPolicy {
+			return qos.Spec.DeletionPolicy
+		},
+		status: func(qos *slinkyv1beta1.SlurmQOS) *slinkyv1beta1.SlurmdbStatus {
+			return &qos.Status
+		},
+		desired: desiredQOS,
+		get:     r.slurmControl.GetQOS,
+		sync:    r.slurmControl.SyncQOS,
+		delete:  r.slurmControl.DeleteQOS,
+	}
+}
+
+func desiredQOS(qos *slinkyv1beta1.SlurmQOS) *slinkyv1beta1.SlurmQOSSpec {
+	s := &qos.Spec
+	desired := &slinkyv1beta1.SlurmQOSSpec{
+		Description:    s.Description,
+		Priority:       s.Priority,
+		Flags:          slices.Sorted(slices.Values(s.Flags)),
+		MaxTRESPerJob:  s.MaxTRESPerJob,
+		MaxTRESPerUser: s.MaxTRESPerUser,
+	}
+	if s.MaxWallDurationPerJob != nil {
+		// slurmdbd stores the limit in whole minutes.
+		desired.MaxWallDurationPerJob = &metav1.Duration{
+			Duration: s.MaxWallDurationPerJob.Truncate(time.Minute),
+		}
+	}
+	return desired
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newSlurmQOSController(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) *SlurmQOSReconciler {
+	return &SlurmQOSReconciler{
+		slurmdbReconciler: newSlurmdbReconciler(c, slurmControl),
+	}
+}
+
+func TestSlurmQOSReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newQOS := func() *slinkyv1beta1.SlurmQOS {
+		qos := testutils.NewSlurmQOS("high", controller)
+		qos.Spec.Priority = ptr.To[int32](100)
+		qos.Spec.MaxWallDurationPerJob = &metav1.Duration{Duration: 90*time.Minute + 30*time.Second}
+		qos.Spec.MaxTRESPerJob = map[string]int64{"cpu": 64}
+		return qos
+	}
+	request := reconcile.Request{
+		NamespacedName: newQOS().Key(),
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantSynced   int
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+		wantDrift    []string
+	}{
+		{
+			name:    "Create",
+			objects: []client.Object{controller.DeepCopy(), newQOS()},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "No drift, wall clock in whole minutes",
+			objects: []client.Object{controller.DeepCopy(), newQOS()},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{
+					"high": {
+						Description:           "high",
+						Priority:              ptr.To[int32](100),
+						MaxWallDurationPerJob: &metav1.Duration{Duration: 90 * time.Minute},
+						MaxTRESPerJob:         map[string]int64{"cpu": 64},
+					},
+				},
+			},
+			wantSynced: 0,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "Drift is reverted",
+			objects: []client.Object{controller.DeepCopy(), newQOS()},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{
+					"high": {
+						Priority:              ptr.To[int32](10),
+						MaxWallDurationPerJob: &metav1.Duration{Duration: 90 * time.Minute},
+						MaxTRESPerJob:         map[string]int64{"cpu": 32},
+					},
+				},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+			wantDrift:  []string{"priority", "maxTRESPerJob"},
+		},
+		{
+			name:    "Client not ready",
+			objects: []client.Object{controller.DeepCopy(), newQOS()},
+			slurmControl: &fakeSlurmControl{
+				err: slurmcontrol.ErrNoClient,
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ClientNotReadyReason,
+		},
+		{
+			name:    "Sync failed",
+			objects: []client.Object{controller.DeepCopy(), newQOS()},
+			slurmControl: &fakeSlurmControl{
+				err: errors.New("slurmdbd unavailable"),
+			},
+			wantErr:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: SyncFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmQOS{}).
+				Build()
+			r := newSlurmQOSController(c, tt.slurmControl)
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmQOSReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.synced != tt.wantSynced {
+				t.Errorf("SyncQOS() calls = %v, want %v", tt.slurmControl.synced, tt.wantSynced)
+			}
+
+			qos := &slinkyv1beta1.SlurmQOS{}
+			if err := c.Get(context.TODO(), request.NamespacedName, qos); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !controllerutil.ContainsFinalizer(qos, slinkyv1beta1.FinalizerSlurmdbEntity) {
+				t.Errorf("SlurmQOS is missing finalizer %q", slinkyv1beta1.FinalizerSlurmdbEntity)
+			}
+			cond := meta.FindStatusCondition(qos.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			if cond == nil {
+				t.Fatalf("SlurmQOS is missing condition %q", slurmconditions.SlurmdbConditionSynced)
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("Synced condition = %v/%v, want %v/%v", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if !slices.Equal(qos.Status.Drift, tt.wantDrift) {
+				t.Errorf("Status.Drift = %v, want %v", qos.Status.Drift, tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestSlurmQOSReconciler_Sync_Deletion(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newQOS := func(policy slinkyv1beta1.SlurmdbDeletionPolicy) *slinkyv1beta1.SlurmQOS {
+		qos := testutils.NewSlurmQOS("high", controller)
+		qos.Spec.DeletionPolicy = policy
+		qos.Finalizers = []string{slinkyv1beta1.FinalizerSlurmdbEntity}
+		qos.DeletionTimestamp = ptr.To(metav1.Now())
+		return qos
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantDeleted  int
+	}{
+		{
+			name:    "Delete",
+			objects: []client.Object{controller.DeepCopy(), newQOS(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{
+					"high": {},
+				},
+			},
+			wantDeleted: 1,
+		},
+		{
+			name:    "Retain",
+			objects: []client.Object{controller.DeepCopy(), newQOS(slinkyv1beta1.SlurmdbDeletionPolicyRetain)},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{
+					"high": {},
+				},
+			},
+			wantDeleted: 0,
+		},
+		{
+			name:    "Controller not found",
+			objects: []client.Object{newQOS(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				qos: map[string]*slinkyv1beta1.SlurmQOSSpec{},
+			},
+			wantDeleted: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmQOS{}).
+				Build()
+			r := newSlurmQOSController(c, tt.slurmControl)
+			request := reconcile.Request{
+				NamespacedName: newQOS("").Key(),
+			}
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmQOSReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.deleted != tt.wantDeleted {
+				t.Errorf("DeleteQOS() calls = %v, want %v", tt.slurmControl.deleted, tt.wantDeleted)
+			}
+
+			// The fake client removes the object once its last finalizer is removed.
+			qos := &slinkyv1beta1.SlurmQOS{}
+			gotRemoved := apierrors.IsNotFound(c.Get(context.TODO(), request.NamespacedName, qos))
+			if wantRemoved := !tt.wantErr; gotRemoved != wantRemoved {
+				t.Errorf("SlurmQOS removed = %v, want %v", gotRemoved, wantRemoved)
+			}
+		})
+	}
+}
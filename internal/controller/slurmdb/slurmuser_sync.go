@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+// Sync implements control logic for synchronizing a SlurmUser.
+func (r *SlurmUserReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	user := &slinkyv1beta1.SlurmUser{}
+	if err := r.Get(ctx, req.NamespacedName, user); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmUser has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	user = user.DeepCopy()
+	defaults.SetSlurmUserDefaults(user)
+
+	return syncEntity(ctx, &r.slurmdbReconciler, user, r.entity())
+}
+
+func (r *SlurmUserReconciler) entity() *entity[*slinkyv1beta1.SlurmUser, slinkyv1beta1.SlurmUserSpec] {
+	return &entity[*slinkyv1beta1.SlurmUser, slinkyv1beta1.SlurmUserSpec]{
+		kind: "user",
+		name: func(user *slinkyv1beta1.SlurmUser) string {
+			return user.UserName()
+		},
+		controllerRef: func(user *slinkyv1beta1.SlurmUser) corev1.LocalObjectReference {
+			return user.Spec.ControllerRef
+		},
+		deletionPolicy: func(user *slinkyv1beta1.SlurmUser) slinkyv1beta1.SlurmdbDeletionPolicy {
+			return user.Spec.DeletionPolicy
+		},
+		status: func(user *slinkyv1beta1.SlurmUser) *slinkyv1beta1.SlurmdbStatus {
+			return &user.Status
+		},
+		desired: desiredUser,
+		get:     r.slurmControl.GetUser,
+		sync:    r.slurmControl.SyncUser,
+		delete:  r.slurmControl.DeleteUser,
+	}
+}
+
+func desiredUser(user *slinkyv1beta1.SlurmUser) *slinkyv1beta1.SlurmUserSpec {
+	s := &user.Spec
+	desired := &slinkyv1beta1.SlurmUserSpec{
+		Accounts:       slices.Sorted(slices.Values(s.Accounts)),
+		DefaultAccount: user.DefaultAccount(),
+		AdminLevel:     s.AdminLevel,
+		Fairshare:      s.Fairshare,
+		QOS:            slices.Sorted(slices.Values(s.QOS)),
+		DefaultQOS:     s.DefaultQOS,
+	}
+	return desired
+}
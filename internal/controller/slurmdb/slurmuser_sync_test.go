@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newSlurmUserController(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) *SlurmUserReconciler {
+	return &SlurmUserReconciler{
+		slurmdbReconciler: newSlurmdbReconciler(c, slurmControl),
+	}
+}
+
+func TestSlurmUserReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newUser := func() *slinkyv1beta1.SlurmUser {
+		user := testutils.NewSlurmUser("alice", controller, "physics", "chemistry")
+		user.Spec.AdminLevel = slinkyv1beta1.SlurmUserAdminLevelOperator
+		user.Spec.QOS = []string{"normal"}
+		return user
+	}
+	request := reconcile.Request{
+		NamespacedName: newUser().Key(),
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantSynced   int
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+		wantDrift    []string
+	}{
+		{
+			name:    "Create",
+			objects: []client.Object{controller.DeepCopy(), newUser()},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "No drift",
+			objects: []client.Object{controller.DeepCopy(), newUser()},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{
+					"alice": desiredUser(newUser()),
+				},
+			},
+			wantSynced: 0,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+		},
+		{
+			name:    "Drift is reverted",
+			objects: []client.Object{controller.DeepCopy(), newUser()},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{
+					"alice": {
+						Accounts:       []string{"physics"},
+						DefaultAccount: "physics",
+						AdminLevel:     slinkyv1beta1.SlurmUserAdminLevelNone,
+						QOS:            []string{"normal"},
+					},
+				},
+			},
+			wantSynced: 1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: SyncedReason,
+			wantDrift:  []string{"accounts", "adminLevel"},
+		},
+		{
+			name:    "Controller not found",
+			objects: []client.Object{newUser()},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ControllerNotFoundReason,
+		},
+		{
+			name:    "Sync failed",
+			objects: []client.Object{controller.DeepCopy(), newUser()},
+			slurmControl: &fakeSlurmControl{
+				err: errors.New("slurmdbd unavailable"),
+			},
+			wantErr:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: SyncFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmUser{}).
+				Build()
+			r := newSlurmUserController(c, tt.slurmControl)
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmUserReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.synced != tt.wantSynced {
+				t.Errorf("SyncUser() calls = %v, want %v", tt.slurmControl.synced, tt.wantSynced)
+			}
+
+			user := &slinkyv1beta1.SlurmUser{}
+			if err := c.Get(context.TODO(), request.NamespacedName, user); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !controllerutil.ContainsFinalizer(user, slinkyv1beta1.FinalizerSlurmdbEntity) {
+				t.Errorf("SlurmUser is missing finalizer %q", slinkyv1beta1.FinalizerSlurmdbEntity)
+			}
+			cond := meta.FindStatusCondition(user.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			if cond == nil {
+				t.Fatalf("SlurmUser is missing condition %q", slurmconditions.SlurmdbConditionSynced)
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("Synced condition = %v/%v, want %v/%v", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if !slices.Equal(user.Status.Drift, tt.wantDrift) {
+				t.Errorf("Status.Drift = %v, want %v", user.Status.Drift, tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestSlurmUserReconciler_Sync_Deletion(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newUser := func(policy slinkyv1beta1.SlurmdbDeletionPolicy) *slinkyv1beta1.SlurmUser {
+		user := testutils.NewSlurmUser("alice", controller, "physics")
+		user.Spec.DeletionPolicy = policy
+		user.Finalizers = []string{slinkyv1beta1.FinalizerSlurmdbEntity}
+		user.DeletionTimestamp = ptr.To(metav1.Now())
+		return user
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		slurmControl *fakeSlurmControl
+		wantErr      bool
+		wantDeleted  int
+	}{
+		{
+			name:    "Delete",
+			objects: []client.Object{controller.DeepCopy(), newUser(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{
+					"alice": {},
+				},
+			},
+			wantDeleted: 1,
+		},
+		{
+			name:    "Retain",
+			objects: []client.Object{controller.DeepCopy(), newUser(slinkyv1beta1.SlurmdbDeletionPolicyRetain)},
+			slurmControl: &fakeSlurmControl{
+				users: map[string]*slinkyv1beta1.SlurmUserSpec{
+					"alice": {},
+				},
+			},
+			wantDeleted: 0,
+		},
+		{
+			name:    "Delete failed",
+			objects: []client.Object{controller.DeepCopy(), newUser(slinkyv1beta1.SlurmdbDeletionPolicyDelete)},
+			slurmControl: &fakeSlurmControl{
+				err: errors.New("slurmdbd unavailable"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&slinkyv1beta1.SlurmUser{}).
+				Build()
+			r := newSlurmUserController(c, tt.slurmControl)
+			request := reconcile.Request{
+				NamespacedName: newUser("").Key(),
+			}
+			if err := r.Sync(context.TODO(), request); (err != nil) != tt.wantErr {
+				t.Errorf("SlurmUserReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.slurmControl.deleted != tt.wantDeleted {
+				t.Errorf("DeleteUser() calls = %v, want %v", tt.slurmControl.deleted, tt.wantDeleted)
+			}
+
+			// The fake client removes the object once its last finalizer is removed.
+			user := &slinkyv1beta1.SlurmUser{}
+			gotRemoved := apierrors.IsNotFound(c.Get(context.TODO(), request.NamespacedName, user))
+			if wantRemoved := !tt.wantErr; gotRemoved != wantRemoved {
+				t.Errorf("SlurmUser removed = %v, want %v", gotRemoved, wantRemoved)
+			}
+		})
+	}
+}
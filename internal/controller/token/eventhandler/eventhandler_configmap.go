@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func NewConfigMapEventHandler(reader client.Reader) *ConfigMapEventHandler {
+	return &ConfigMapEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &ConfigMapEventHandler{}
+
+// ConfigMapEventHandler enqueues the Tokens which reference a Controller whose
+// JWKS is in the ConfigMap, so their JWT is signed with the `kid` of the key.
+type ConfigMapEventHandler struct {
+	client.Reader
+}
+
+func (e *ConfigMapEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ConfigMapEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldConfigMap, ok := evt.ObjectOld.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	newConfigMap, ok := evt.ObjectNew.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	// Only the JWKS concerns the Tokens.
+	if apiequality.Semantic.DeepEqual(oldConfigMap.Data, newConfigMap.Data) {
+		return
+	}
+	e.enqueueRequest(ctx, newConfigMap, q)
+}
+
+func (e *ConfigMapEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ConfigMapEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *ConfigMapEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	controllerList := &slinkyv1beta1.ControllerList{}
+	if err := e.List(ctx, controllerList, client.InNamespace(configMap.Namespace)); err != nil {
+		logger.Error(err, "failed to list controller CRs")
+		return
+	}
+
+	controllerNames := map[string]bool{}
+	for _, controller := range controllerList.Items {
+		jwksRef := controller.AuthJwksRef()
+		if controller.Spec.JwtSigningKeyRef == nil || jwksRef == nil || jwksRef.Name != configMap.Name {
+			continue
+		}
+		controllerNames[controller.Name] = true
+	}
+	if len(controllerNames) == 0 {
+		return
+	}
+
+	tokenList := &slinkyv1beta1.TokenList{}
+	if err := e.List(ctx, tokenList, client.InNamespace(configMap.Namespace)); err != nil {
+		logger.Error(err, "failed to list token CRs")
+		return
+	}
+
+	for _, token := range tokenList.Items {
+		if token.Spec.ControllerRef == nil || !controllerNames[token.Spec.ControllerRef.Name] {
+			continue
+		}
+		objectutils.EnqueueRequest(q, &token)
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newJwksController(name, configMapName string) *slinkyv1beta1.Controller {
+	controller := testutils.NewController(name, testutils.NewSlurmKeyRef(name), testutils.NewJwtKeyRef(name), nil)
+	signingKeyRef := testutils.NewJwtKeyRef(name + "-es256")
+	controller.Spec.JwtSigningKeyRef = &signingKeyRef
+	controller.Spec.JwksKeyRef = &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+		Key:                  "jwks.json",
+	}
+	return controller
+}
+
+func newJwksConfigMap(name, jwks string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string]string{
+			"jwks.json": jwks,
+		},
+	}
+}
+
+func Test_ConfigMapEventHandler_Create(t *testing.T) {
+	configMap := newJwksConfigMap("slurm-jwks", `{"keys":[]}`)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newJwksController("slurm", configMap.Name),
+					newJwksController("other", "other-jwks"),
+					newToken("token1", "slurm"),
+					newToken("token2", "other"),
+					newToken("token3", ""),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: configMap,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "without jwtSigningKeyRef",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					func() *slinkyv1beta1.Controller {
+						controller := newJwksController("slurm", configMap.Name)
+						controller.Spec.JwtSigningKeyRef = nil
+						return controller
+					}(),
+					newToken("token1", "slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: configMap,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConfigMapEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ConfigMapEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ConfigMapEventHandler_Update(t *testing.T) {
+	oldConfigMap := newJwksConfigMap("slurm-jwks", `{"keys":[{"kid":"key1"}]}`)
+	newConfigMap := newJwksConfigMap("slurm-jwks", `{"keys":[{"kid":"key2"}]}`)
+	reader := fake.NewFakeClient(
+		newJwksController("slurm", oldConfigMap.Name),
+		newToken("token1", "slurm"),
+	)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "JWKS changed",
+			fields: fields{
+				Reader: reader,
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: oldConfigMap,
+					ObjectNew: newConfigMap,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "JWKS unchanged",
+			fields: fields{
+				Reader: reader,
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: oldConfigMap,
+					ObjectNew: oldConfigMap.DeepCopy(),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConfigMapEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ConfigMapEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func NewControllerEventHandler(reader client.Reader) *ControllerEventHandler {
+	return &ControllerEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &ControllerEventHandler{}
+
+// ControllerEventHandler enqueues the Tokens which reference the Controller,
+// whose JWT is signed by its `auth/jwt` key.
+type ControllerEventHandler struct {
+	client.Reader
+}
+
+func (e *ControllerEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ControllerEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldController, ok := evt.ObjectOld.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+	newController, ok := evt.ObjectNew.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+	// Only the signing key concerns the Tokens.
+	if apiequality.Semantic.DeepEqual(oldController.AuthJwtSigningRef(), newController.AuthJwtSigningRef()) {
+		return
+	}
+	e.enqueueRequest(ctx, newController, q)
+}
+
+func (e *ControllerEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ControllerEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *ControllerEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	controller, ok := obj.(*slinkyv1beta1.Controller)
+	if !ok {
+		return
+	}
+
+	tokenList := &slinkyv1beta1.TokenList{}
+	if err := e.List(ctx, tokenList, client.InNamespace(controller.Namespace)); err != nil {
+		logger.Error(err, "failed to list token CRs")
+		return
+	}
+
+	for _, token := range tokenList.Items {
+		if token.Spec.ControllerRef == nil || token.Spec.ControllerRef.Name != controller.Name {
+			continue
+		}
+		objectutils.EnqueueRequest(q, &token)
+	}
+}
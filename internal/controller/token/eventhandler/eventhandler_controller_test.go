@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newToken(name, controllerName string) *slinkyv1beta1.Token {
+	token := &slinkyv1beta1.Token{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.TokenSpec{
+			Username: "slurm",
+		},
+	}
+	if controllerName != "" {
+		token.Spec.ControllerRef = &corev1.LocalObjectReference{Name: controllerName}
+	}
+	return token
+}
+
+func Test_ControllerEventHandler_Create(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					newToken("token1", "slurm"),
+					newToken("token2", "other"),
+					newToken("token3", ""),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: controller,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ControllerEventHandler_Delete(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "referenced",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newToken("token1", "slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: controller,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Delete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ControllerEventHandler_Generic(t *testing.T) {
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.GenericEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				Reader: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.GenericEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Generic(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Generic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ControllerEventHandler_Update(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	rotatedController := controller.DeepCopy()
+	rotatedController.Spec.JwtKeyRef = new(testutils.NewJwtKeyRef("slurm-new"))
+	otherController := controller.DeepCopy()
+	otherController.Spec.ExtraConf = "MinJobAge=30"
+	jwksController := controller.DeepCopy()
+	jwksController.Spec.JwtSigningKeyRef = new(testutils.NewJwtKeyRef("slurm-rs256"))
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "signing key changed",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newToken("token1", "slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: rotatedController,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "JWKS signing key added",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newToken("token1", "slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: jwksController,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "other change",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newToken("token1", "slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: otherController,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
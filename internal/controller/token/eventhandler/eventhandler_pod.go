@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func NewPodEventHandler() *PodEventHandler {
+	return &PodEventHandler{}
+}
+
+var _ handler.EventHandler = &PodEventHandler{}
+
+// PodEventHandler enqueues the Tokens which pods request to be created by
+// their annotations.
+type PodEventHandler struct{}
+
+func (e *PodEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *PodEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldPod, ok := evt.ObjectOld.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	newPod, ok := evt.ObjectNew.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	// Only the token label and annotations concern the Tokens.
+	if oldPod.Labels[slinkyv1beta1.LabelPodTokenInject] != newPod.Labels[slinkyv1beta1.LabelPodTokenInject] {
+		e.enqueueRequest(ctx, newPod, q)
+		return
+	}
+	for _, annotation := range []string{
+		slinkyv1beta1.AnnotationPodToken,
+		slinkyv1beta1.AnnotationTokenUsername,
+		slinkyv1beta1.AnnotationTokenController,
+	} {
+		if oldPod.Annotations[annotation] != newPod.Annotations[annotation] {
+			e.enqueueRequest(ctx, newPod, q)
+			return
+		}
+	}
+}
+
+func (e *PodEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank, the Token is garbage collected with its pods.
+}
+
+func (e *PodEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *PodEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	token := common.NewPodToken(pod)
+	if token == nil {
+		return
+	}
+	objectutils.EnqueueRequest(q, token)
+}
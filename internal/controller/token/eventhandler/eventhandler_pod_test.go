@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func newTokenPod(name string, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+			Labels: map[string]string{
+				slinkyv1beta1.LabelPodTokenInject: "true",
+			},
+			Annotations: annotations,
+		},
+	}
+}
+
+func Test_PodEventHandler_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Token requested",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newTokenPod("pod", map[string]string{
+						slinkyv1beta1.AnnotationPodToken:        "token",
+						slinkyv1beta1.AnnotationTokenUsername:   "alice",
+						slinkyv1beta1.AnnotationTokenController: "slurm",
+					}),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Existing Token",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newTokenPod("pod", map[string]string{
+						slinkyv1beta1.AnnotationPodToken: "token",
+					}),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Not annotated",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newTokenPod("pod", nil),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPodEventHandler()
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("PodEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_PodEventHandler_Update(t *testing.T) {
+	annotations := map[string]string{
+		slinkyv1beta1.AnnotationPodToken:        "token",
+		slinkyv1beta1.AnnotationTokenUsername:   "alice",
+		slinkyv1beta1.AnnotationTokenController: "slurm",
+	}
+	pod := newTokenPod("pod", annotations)
+	labeledPod := pod.DeepCopy()
+	labeledPod.Labels["foo"] = "bar"
+	uninjectedPod := pod.DeepCopy()
+	uninjectedPod.Labels = nil
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Annotated",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newTokenPod("pod", nil),
+					ObjectNew: pod,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Labeled",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: uninjectedPod,
+					ObjectNew: pod,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Annotations unchanged",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: pod,
+					ObjectNew: labeledPod,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPodEventHandler()
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("PodEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
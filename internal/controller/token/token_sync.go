@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -17,10 +19,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
-	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 	jwt "github.com/golang-jwt/jwt/v5"
 )
 
@@ -32,7 +34,7 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 	if err := r.Get(ctx, req.NamespacedName, token); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Token has been deleted", "request", req)
-			return nil
+			return r.syncPodToken(ctx, req)
 		}
 		return err
 	}
@@ -55,6 +57,12 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 	}
 
 	steps := []syncsteps.Step[*slinkyv1beta1.Token]{
+		{
+			Name: "PodOwners",
+			SyncFn: func(ctx context.Context, token *slinkyv1beta1.Token) error {
+				return r.syncPodTokenOwners(ctx, token)
+			},
+		},
 		{
 			Name: "Secret",
 			SyncFn: func(ctx context.Context, token *slinkyv1beta1.Token) error {
@@ -72,15 +80,19 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 			Name: "Refresh",
 			SyncFn: func(ctx context.Context, token *slinkyv1beta1.Token) error {
 				if !ptr.Deref(token.Spec.Refresh, defaults.DefaultTokenRefresh) {
-					return nil
+					return r.syncImmutableSecret(ctx, token)
 				}
 
 				now := time.Now()
 				expirationTime, err := r.getExpTime(ctx, token)
 				if err != nil {
-					if errors.Is(err, jwt.ErrTokenExpired) {
+					switch {
+					case errors.Is(err, jwt.ErrTokenExpired):
 						logger.Info("Token's JWT is expired")
-					} else {
+					case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+						// The signing key was rotated.
+						logger.Info("Token's JWT is not signed by the signing key")
+					default:
 						return err
 					}
 				}
@@ -122,18 +134,41 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 	return r.syncStatus(ctx, token)
 }
 
+// syncImmutableSecret deletes the immutable Secret of the Token, which is not
+// refreshed, once its JWT is not signed by the signing key (e.g. it was
+// rotated), so it is recreated and signed by the signing key.
+func (r *TokenReconciler) syncImmutableSecret(ctx context.Context, token *slinkyv1beta1.Token) error {
+	logger := log.FromContext(ctx)
+
+	if _, err := r.getExpTime(ctx, token); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		return nil
+	}
+
+	logger.Info("Token's JWT is not signed by the signing key, recreating its Secret")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      token.SecretKey().Name,
+			Namespace: token.Namespace,
+		},
+	}
+	if err := objectutils.DeleteObject(r.Client, ctx, r.eventRecorder, token, secret); err != nil {
+		return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(secret), err)
+	}
+
+	return nil
+}
+
 func (r *TokenReconciler) getExpTime(ctx context.Context, token *slinkyv1beta1.Token) (time.Time, error) {
 	authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
 	if err != nil {
 		return time.Time{}, err
 	}
-	jwtRef := token.JwtRef()
-	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, jwtRef, token.Namespace)
+	signingKey, err := r.refResolver.GetTokenSigningKey(ctx, token)
 	if err != nil {
 		return time.Time{}, err
 	}
 
-	authTokenClaims, err := slurmjwt.ParseTokenClaims(string(authToken), signingKey)
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKey(string(authToken), signingKey)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to parse Slurm auth token claims: %w", err)
 	}
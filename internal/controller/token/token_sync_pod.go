@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// syncPodToken creates the Token, which does not exist, if pods request it by
+// annotations, as a username allowed by its Controller. The Token is owned by
+// all of them, so it is deleted with the last.
+func (r *TokenReconciler) syncPodToken(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	podTokens, err := r.listPodTokens(ctx, req.NamespacedName)
+	if err != nil {
+		return err
+	}
+
+	var token *slinkyv1beta1.Token
+	for _, podToken := range podTokens {
+		if token != nil {
+			if isSamePodToken(token, podToken) {
+				token.OwnerReferences = append(token.OwnerReferences, podToken.OwnerReferences...)
+			}
+			continue
+		}
+		allowed, err := r.isTokenUserAllowed(ctx, podToken)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			logger.Info("Pod requested a Token as a username not allowed by its Controller",
+				"pod", podToken.OwnerReferences[0].Name, "username", podToken.Spec.Username)
+			continue
+		}
+		token = podToken
+	}
+	if token == nil {
+		return nil
+	}
+
+	if err := r.Create(ctx, token); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create Token (%s): %w", klog.KObj(token), err)
+	}
+	logger.Info("Created Token for pods", "token", klog.KObj(token), "pods", len(token.OwnerReferences))
+
+	return nil
+}
+
+// syncPodTokenOwners adds the pods which request the Token, if it was created
+// for pods, to its owners, so it is not deleted with the pods it was created
+// for.
+func (r *TokenReconciler) syncPodTokenOwners(ctx context.Context, token *slinkyv1beta1.Token) error {
+	if !slices.ContainsFunc(token.OwnerReferences, isPodOwnerRef) {
+		return nil
+	}
+
+	podTokens, err := r.listPodTokens(ctx, token.Key())
+	if err != nil {
+		return err
+	}
+
+	var ownerRefs []metav1.OwnerReference
+	for _, podToken := range podTokens {
+		if !isSamePodToken(token, podToken) {
+			continue
+		}
+		ownerRef := podToken.OwnerReferences[0]
+		if slices.ContainsFunc(token.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ref.UID == ownerRef.UID
+		}) {
+			continue
+		}
+		ownerRefs = append(ownerRefs, ownerRef)
+	}
+	if len(ownerRefs) == 0 {
+		return nil
+	}
+
+	mutateFn := func(token *slinkyv1beta1.Token) error {
+		token.OwnerReferences = append(token.OwnerReferences, ownerRefs...)
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, token, mutateFn); err != nil {
+		return fmt.Errorf("failed to patch Token (%s) owners: %w", klog.KObj(token), err)
+	}
+
+	return nil
+}
+
+// listPodTokens returns the Tokens, owned by their pod, which pods not being
+// deleted request to be created.
+func (r *TokenReconciler) listPodTokens(ctx context.Context, key types.NamespacedName) ([]*slinkyv1beta1.Token, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(key.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var podTokens []*slinkyv1beta1.Token
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		podToken := common.NewPodToken(pod)
+		if podToken == nil || podToken.Name != key.Name {
+			continue
+		}
+		podTokens = append(podTokens, podToken)
+	}
+	return podTokens, nil
+}
+
+// isTokenUserAllowed returns true if the Controller of the Token allows its
+// username.
+func (r *TokenReconciler) isTokenUserAllowed(ctx context.Context, token *slinkyv1beta1.Token) (bool, error) {
+	controller, err := r.refResolver.GetController(ctx, *token.Spec.ControllerRef, token.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return controller.IsTokenUserAllowed(token.Spec.Username), nil
+}
+
+// isSamePodToken returns true if the pod requests the Token as its username and
+// Controller.
+func isSamePodToken(token, podToken *slinkyv1beta1.Token) bool {
+	return token.Spec.Username == podToken.Spec.Username &&
+		token.Spec.ControllerRef != nil &&
+		token.Spec.ControllerRef.Name == podToken.Spec.ControllerRef.Name
+}
+
+func isPodOwnerRef(ref metav1.OwnerReference) bool {
+	return ref.APIVersion == corev1.SchemeGroupVersion.String() && ref.Kind == "Pod"
+}
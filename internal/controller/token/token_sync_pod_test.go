@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newTokenPod(name, tokenName, username string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID("uid-" + name),
+			Labels: map[string]string{
+				slinkyv1beta1.LabelPodTokenInject: "true",
+			},
+			Annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodToken:        tokenName,
+				slinkyv1beta1.AnnotationTokenUsername:   username,
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			},
+		},
+	}
+}
+
+func newTokenController(tokenUsers ...string) *slinkyv1beta1.Controller {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	controller.Spec.TokenUsers = tokenUsers
+	return controller
+}
+
+func TestTokenReconciler_syncPodToken(t *testing.T) {
+	newPod := func(name, tokenName string) *corev1.Pod {
+		return newTokenPod(name, tokenName, "alice")
+	}
+	controller := newTokenController("alice")
+	deletingPod := newPod("deleting", "notebook")
+	deletingPod.DeletionTimestamp = new(metav1.Now())
+	deletingPod.Finalizers = []string{"test"}
+	existingPod := newPod("existing", "existing")
+	existingPod.Annotations = map[string]string{
+		slinkyv1beta1.AnnotationPodToken: "existing",
+	}
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "notebook",
+		},
+	}
+	type fields struct {
+		Client client.Client
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		req        reconcile.Request
+		wantOwners []string
+	}{
+		{
+			name: "Created for pods",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, newPod("pod-0", "notebook"), newPod("pod-1", "notebook"), newPod("other", "other"), deletingPod).
+					Build(),
+			},
+			req:        req,
+			wantOwners: []string{"pod-0", "pod-1"},
+		},
+		{
+			name: "Skips username not allowed",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, newTokenPod("pod-0", "notebook", "root"), newPod("pod-1", "notebook")).
+					Build(),
+			},
+			req:        req,
+			wantOwners: []string{"pod-1"},
+		},
+		{
+			name: "Username not allowed",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, newTokenPod("pod-0", "notebook", "bob")).
+					Build(),
+			},
+			req: req,
+		},
+		{
+			name: "Controller not found",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(newPod("pod-0", "notebook")).
+					Build(),
+			},
+			req: req,
+		},
+		{
+			name: "Not labeled",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, func() *corev1.Pod {
+						pod := newPod("pod-0", "notebook")
+						pod.Labels = nil
+						return pod
+					}()).
+					Build(),
+			},
+			req: req,
+		},
+		{
+			name: "Not requested by username and controller",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, existingPod).
+					Build(),
+			},
+			req: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "existing",
+				},
+			},
+		},
+		{
+			name: "Not requested",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(controller, newPod("other", "other")).
+					Build(),
+			},
+			req: req,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(tt.fields.Client)
+			if err := r.syncPodToken(context.TODO(), tt.req); err != nil {
+				t.Fatalf("TokenReconciler.syncPodToken() error = %v", err)
+			}
+			token := &slinkyv1beta1.Token{}
+			err := r.Get(context.TODO(), tt.req.NamespacedName, token)
+			if len(tt.wantOwners) == 0 {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("TokenReconciler.syncPodToken() created Token, err = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.Spec.Username != "alice" || token.Spec.ControllerRef == nil || token.Spec.ControllerRef.Name != "slurm" {
+				t.Errorf("TokenReconciler.syncPodToken() Spec = %v", token.Spec)
+			}
+			var owners []string
+			for _, ref := range token.OwnerReferences {
+				owners = append(owners, ref.Name)
+			}
+			if !slices.Equal(owners, tt.wantOwners) {
+				t.Errorf("TokenReconciler.syncPodToken() owners = %v, want %v", owners, tt.wantOwners)
+			}
+		})
+	}
+}
+
+func TestTokenReconciler_syncPodTokenOwners(t *testing.T) {
+	newToken := func(owners ...string) *slinkyv1beta1.Token {
+		token := &slinkyv1beta1.Token{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "notebook",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: slinkyv1beta1.TokenSpec{
+				Username: "alice",
+				ControllerRef: &corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+		}
+		for _, owner := range owners {
+			token.OwnerReferences = append(token.OwnerReferences, metav1.OwnerReference{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Pod",
+				Name:       owner,
+				UID:        types.UID("uid-" + owner),
+			})
+		}
+		return token
+	}
+	type fields struct {
+		Client client.Client
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		token      *slinkyv1beta1.Token
+		wantOwners []string
+	}{
+		{
+			name: "Adds pods",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(newToken("pod-0"), newTokenPod("pod-0", "notebook", "alice"), newTokenPod("pod-1", "notebook", "alice")).
+					Build(),
+			},
+			token:      newToken("pod-0"),
+			wantOwners: []string{"pod-0", "pod-1"},
+		},
+		{
+			name: "Skips other username",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(newToken("pod-0"), newTokenPod("pod-1", "notebook", "bob")).
+					Build(),
+			},
+			token:      newToken("pod-0"),
+			wantOwners: []string{"pod-0"},
+		},
+		{
+			name: "Not created for pods",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithObjects(newToken(), newTokenPod("pod-0", "notebook", "alice")).
+					Build(),
+			},
+			token: newToken(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(tt.fields.Client)
+			token := &slinkyv1beta1.Token{}
+			if err := r.Get(context.TODO(), tt.token.Key(), token); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.syncPodTokenOwners(context.TODO(), token); err != nil {
+				t.Fatalf("TokenReconciler.syncPodTokenOwners() error = %v", err)
+			}
+			if err := r.Get(context.TODO(), tt.token.Key(), token); err != nil {
+				t.Fatal(err)
+			}
+			var owners []string
+			for _, ref := range token.OwnerReferences {
+				owners = append(owners, ref.Name)
+			}
+			if !slices.Equal(owners, tt.wantOwners) {
+				t.Errorf("TokenReconciler.syncPodTokenOwners() owners = %v, want %v", owners, tt.wantOwners)
+			}
+		})
+	}
+}
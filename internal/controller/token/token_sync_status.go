@@ -16,8 +16,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
-	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
@@ -26,19 +26,16 @@ func (r *TokenReconciler) syncStatus(
 	ctx context.Context,
 	token *slinkyv1beta1.Token,
 ) error {
-	logger := log.FromContext(ctx)
-
 	authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
 	if err != nil {
 		return err
 	}
-	jwtRef := token.JwtRef()
-	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, jwtRef, token.Namespace)
+	signingKey, err := r.refResolver.GetTokenSigningKey(ctx, token)
 	if err != nil {
 		return err
 	}
 
-	authTokenClaims, err := slurmjwt.ParseTokenClaims(string(authToken), signingKey)
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKey(string(authToken), signingKey)
 	if err != nil {
 		return fmt.Errorf("failed to parse Slurm auth token: %w", err)
 	}
@@ -57,12 +54,6 @@ func (r *TokenReconciler) syncStatus(
 		Conditions: structutils.MergeList(token.Status.Conditions),
 	}
 
-	if apiequality.Semantic.DeepEqual(token.Status, newStatus) {
-		logger.V(2).Info("Token Status has not changed, skipping status update",
-			"token", klog.KObj(token), "status", token.Status)
-		return nil
-	}
-
 	if err := r.updateStatus(ctx, token, &newStatus); err != nil {
 		return fmt.Errorf("error updating Token(%s) status: %w",
 			klog.KObj(token), err)
@@ -89,6 +80,11 @@ func (r *TokenReconciler) updateStatus(
 			}
 			return err
 		}
+		if apiequality.Semantic.DeepEqual(toUpdate.Status, *newStatus) {
+			logger.V(2).Info("Token Status has not changed, skipping status update",
+				"token", klog.KObj(toUpdate), "status", toUpdate.Status)
+			return nil
+		}
 		toUpdate.Status = *newStatus
 		return r.Status().Update(ctx, toUpdate)
 	})
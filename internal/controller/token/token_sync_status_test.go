@@ -5,16 +5,19 @@ package token
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
-	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 )
 
 func TestTokenReconciler_syncStatus(t *testing.T) {
@@ -144,6 +147,89 @@ func TestTokenReconciler_syncStatus(t *testing.T) {
 	}
 }
 
+func TestTokenReconciler_syncStatus_stepConditions(t *testing.T) {
+	signingKey := crypto.NewSigningKey()
+	signedToken, err := slurmjwt.NewToken(signingKey).NewSignedToken()
+	if err != nil {
+		t.Fatalf("failed to create signed token: %v", err)
+	}
+	token := &slinkyv1beta1.Token{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.TokenSpec{
+			Username: "slurm",
+			JwtKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "test-jwtkey",
+				},
+				Key: "jwt.key",
+			},
+		},
+	}
+	jwtKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-jwtkey",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			"jwt.key": signingKey,
+		},
+	}
+	authSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      token.SecretKey().Name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			"SLURM_JWT": []byte(signedToken),
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithRuntimeObjects(token, jwtKeySecret, authSecret).
+		WithStatusSubresource(&slinkyv1beta1.Token{}).
+		Build()
+	r := NewReconciler(c)
+
+	// syncStatus syncs the status with the steps, as Sync does, and returns
+	// whether the stored Token has the condition of the step.
+	syncStatus := func(stepErr error) bool {
+		t.Helper()
+		token := &slinkyv1beta1.Token{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Namespace: corev1.NamespaceDefault, Name: "test"}, token); err != nil {
+			t.Fatal(err)
+		}
+		steps := []syncsteps.Step[*slinkyv1beta1.Token]{
+			{
+				Name: "Secret",
+				SyncFn: func(context.Context, *slinkyv1beta1.Token) error {
+					return stepErr
+				},
+			},
+		}
+		_ = syncsteps.Sync(context.TODO(), nil, token, steps)
+		if err := r.syncStatus(context.TODO(), token); err != nil {
+			t.Fatalf("TokenReconciler.syncStatus() error = %v", err)
+		}
+		if err := c.Get(context.TODO(), client.ObjectKeyFromObject(token), token); err != nil {
+			t.Fatal(err)
+		}
+		return meta.FindStatusCondition(token.Status.Conditions, syncsteps.ConditionType("Secret")) != nil
+	}
+
+	// The status is unchanged but for the condition of the step.
+	if syncStatus(nil) {
+		t.Fatal("TokenReconciler.syncStatus() stored a condition for a step which succeeded")
+	}
+	if !syncStatus(errors.New("failed")) {
+		t.Error("TokenReconciler.syncStatus() did not store the condition of a step which failed")
+	}
+	if syncStatus(nil) {
+		t.Error("TokenReconciler.syncStatus() did not remove the condition of a step which succeeded")
+	}
+}
+
 func TestTokenReconciler_updateStatus(t *testing.T) {
 	token := &slinkyv1beta1.Token{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
+)
+
+func TestTokenReconciler_syncImmutableSecret(t *testing.T) {
+	signingKey := crypto.NewSigningKey()
+	token := &slinkyv1beta1.Token{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.TokenSpec{
+			Username: "slurm",
+			JwtKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "test-jwtkey",
+				},
+				Key: "jwt.key",
+			},
+			Refresh: ptr.To(false),
+		},
+	}
+	jwtKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-jwtkey",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			"jwt.key": signingKey,
+		},
+	}
+	newAuthSecret := func(signingKey []byte) *corev1.Secret {
+		signedToken, err := slurmjwt.NewToken(signingKey).NewSignedToken()
+		if err != nil {
+			t.Fatalf("failed to create signed token: %v", err)
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      token.SecretKey().Name,
+				Namespace: corev1.NamespaceDefault,
+			},
+			Data: map[string][]byte{
+				"SLURM_JWT": []byte(signedToken),
+			},
+			Immutable: ptr.To(true),
+		}
+	}
+	tests := []struct {
+		name        string
+		authSecret  *corev1.Secret
+		wantDeleted bool
+	}{
+		{
+			name:       "Signed by the signing key",
+			authSecret: newAuthSecret(signingKey),
+		},
+		{
+			name:        "Signed by another key",
+			authSecret:  newAuthSecret(crypto.NewSigningKey()),
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(token.DeepCopy(), jwtKeySecret.DeepCopy(), tt.authSecret)
+			r := NewReconciler(c)
+			if err := r.syncImmutableSecret(context.TODO(), token.DeepCopy()); err != nil {
+				t.Fatalf("TokenReconciler.syncImmutableSecret() error = %v", err)
+			}
+			err := c.Get(context.TODO(), token.SecretKey(), &corev1.Secret{})
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("TokenReconciler.syncImmutableSecret() deleted = %v, want %v (err = %v)", deleted, tt.wantDeleted, err)
+			}
+		})
+	}
+}
@@ -4,10 +4,16 @@
 package defaults
 
 import (
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // Default values for NodeSet Spec fields when unspecified.
@@ -17,11 +23,29 @@ const (
 	DefaultNodeSetScalingMode                  slinkyv1beta1.ScalingModeType                 = slinkyv1beta1.ScalingModeStatefulset
 	DefaultNodeSetUpdateStrategyType           slinkyv1beta1.NodeSetUpdateStrategyType       = slinkyv1beta1.RollingUpdateNodeSetStrategyType
 	DefaultNodeSetPruneSlurmNodeRecordType     slinkyv1beta1.NodeSetPruneSlurmNodeRecordType = slinkyv1beta1.NodeSetPruneNodeRecordTypeNever
+	DefaultNodeSetScaleInStrategy              slinkyv1beta1.NodeSetScaleInStrategyType      = slinkyv1beta1.ScaleInStrategyJobAware
+	DefaultNodeSetAutoscalingTargetPendingJobs int32                                         = 1
+	DefaultNodeSetRollingUpdateCanaryReplicas  int32                                         = 1
+	DefaultNodeSetRemediationMaxRemediations   int32                                         = 1
 )
 
 // Default values for NodeSet Spec fields when unspecified.
 var (
-	DefaultNodeSetRollingUpdateMaxUnavailable intstr.IntOrString = intstr.FromString("25%")
+	DefaultNodeSetRollingUpdateMaxUnavailable  intstr.IntOrString = intstr.FromString("25%")
+	DefaultNodeSetRollingUpdateCanarySoakTime  metav1.Duration    = metav1.Duration{Duration: 10 * time.Minute}
+	DefaultNodeSetRollbackProgressDeadline     metav1.Duration    = metav1.Duration{Duration: 10 * time.Minute}
+	DefaultNodeSetAutoscalingScaleUpCooldown   metav1.Duration    = metav1.Duration{Duration: 30 * time.Second}
+	DefaultNodeSetAutoscalingScaleDownCooldown metav1.Duration    = metav1.Duration{Duration: 5 * time.Minute}
+	DefaultNodeSetPowerSaveSuspendTime         metav1.Duration    = metav1.Duration{Duration: 10 * time.Minute}
+	DefaultNodeSetPowerSaveResumeTimeout       metav1.Duration    = metav1.Duration{Duration: 10 * time.Minute}
+	DefaultNodeSetRemediationUnhealthyDuration metav1.Duration    = metav1.Duration{Duration: 5 * time.Minute}
+	DefaultNodeSetRemediationMaxUnhealthy      intstr.IntOrString = intstr.FromString("50%")
+	DefaultNodeSetRemediationWindow            metav1.Duration    = metav1.Duration{Duration: 10 * time.Minute}
+	DefaultNodeSetRemediationConditions                           = []corev1.PodConditionType{
+		slurmconditions.PodConditionNotResponding,
+		slurmconditions.PodConditionFail,
+		slurmconditions.PodConditionInvalidReg,
+	}
 )
 
 func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
@@ -50,6 +74,20 @@ func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
 		if s.UpdateStrategy.RollingUpdate.MaxUnavailable == nil {
 			s.UpdateStrategy.RollingUpdate.MaxUnavailable = ptr.To(DefaultNodeSetRollingUpdateMaxUnavailable)
 		}
+		if canary := &s.UpdateStrategy.RollingUpdate.Canary; canary.Enabled {
+			if canary.Replicas == 0 {
+				canary.Replicas = DefaultNodeSetRollingUpdateCanaryReplicas
+			}
+			if canary.SoakTime.Duration == 0 {
+				canary.SoakTime = DefaultNodeSetRollingUpdateCanarySoakTime
+			}
+		}
+	}
+
+	if s.UpdateStrategy.Rollback.Enabled {
+		if s.UpdateStrategy.Rollback.ProgressDeadline.Duration == 0 {
+			s.UpdateStrategy.Rollback.ProgressDeadline = DefaultNodeSetRollbackProgressDeadline
+		}
 	}
 
 	if s.PersistentVolumeClaimRetentionPolicy.WhenDeleted == "" {
@@ -63,4 +101,45 @@ func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
 	if s.PruneSlurmNodeRecords == "" {
 		s.PruneSlurmNodeRecords = DefaultNodeSetPruneSlurmNodeRecordType
 	}
+
+	if s.ScaleInStrategy == "" {
+		s.ScaleInStrategy = DefaultNodeSetScaleInStrategy
+	}
+
+	if s.Autoscaling.Enabled {
+		if s.Autoscaling.TargetPendingJobsPerNode == 0 {
+			s.Autoscaling.TargetPendingJobsPerNode = DefaultNodeSetAutoscalingTargetPendingJobs
+		}
+		if s.Autoscaling.ScaleUpCooldown.Duration == 0 {
+			s.Autoscaling.ScaleUpCooldown = DefaultNodeSetAutoscalingScaleUpCooldown
+		}
+		if s.Autoscaling.ScaleDownCooldown.Duration == 0 {
+			s.Autoscaling.ScaleDownCooldown = DefaultNodeSetAutoscalingScaleDownCooldown
+		}
+	}
+	if s.PowerSave.Enabled {
+		if s.PowerSave.SuspendTime.Duration == 0 {
+			s.PowerSave.SuspendTime = DefaultNodeSetPowerSaveSuspendTime
+		}
+		if s.PowerSave.ResumeTimeout.Duration == 0 {
+			s.PowerSave.ResumeTimeout = DefaultNodeSetPowerSaveResumeTimeout
+		}
+	}
+	if s.Remediation.Enabled {
+		if len(s.Remediation.Conditions) == 0 {
+			s.Remediation.Conditions = slices.Clone(DefaultNodeSetRemediationConditions)
+		}
+		if s.Remediation.UnhealthyDuration.Duration == 0 {
+			s.Remediation.UnhealthyDuration = DefaultNodeSetRemediationUnhealthyDuration
+		}
+		if s.Remediation.MaxUnhealthy == nil {
+			s.Remediation.MaxUnhealthy = ptr.To(DefaultNodeSetRemediationMaxUnhealthy)
+		}
+		if s.Remediation.MaxRemediations == 0 {
+			s.Remediation.MaxRemediations = DefaultNodeSetRemediationMaxRemediations
+		}
+		if s.Remediation.Window.Duration == 0 {
+			s.Remediation.Window = DefaultNodeSetRemediationWindow
+		}
+	}
 }
@@ -4,6 +4,7 @@
 package defaults
 
 import (
+	"slices"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -45,6 +46,9 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		if ns.Spec.PruneSlurmNodeRecords != DefaultNodeSetPruneSlurmNodeRecordType {
 			t.Errorf("PruneSlurmNodeRecords: want %q, got %q", DefaultNodeSetPruneSlurmNodeRecordType, ns.Spec.PruneSlurmNodeRecords)
 		}
+		if ns.Spec.ScaleInStrategy != DefaultNodeSetScaleInStrategy {
+			t.Errorf("ScaleInStrategy: want %q, got %q", DefaultNodeSetScaleInStrategy, ns.Spec.ScaleInStrategy)
+		}
 	})
 
 	t.Run("explicit values are not overridden", func(t *testing.T) {
@@ -57,6 +61,7 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted = slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
 		ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled = slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
 		ns.Spec.PruneSlurmNodeRecords = slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound
+		ns.Spec.ScaleInStrategy = slinkyv1beta1.ScaleInStrategyDefault
 		SetNodeSetDefaults(ns)
 		if ptr.Deref(ns.Spec.Replicas, 0) != 3 {
 			t.Errorf("Replicas: want 3, got %v", ptr.Deref(ns.Spec.Replicas, 0))
@@ -79,5 +84,102 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		if ns.Spec.PruneSlurmNodeRecords != slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound {
 			t.Errorf("PruneSlurmNodeRecords: want %q, got %q", slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound, ns.Spec.PruneSlurmNodeRecords)
 		}
+		if ns.Spec.ScaleInStrategy != slinkyv1beta1.ScaleInStrategyDefault {
+			t.Errorf("ScaleInStrategy: want %q, got %q", slinkyv1beta1.ScaleInStrategyDefault, ns.Spec.ScaleInStrategy)
+		}
+	})
+
+	t.Run("autoscaling gets defaults when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.Autoscaling.Enabled = true
+		SetNodeSetDefaults(ns)
+		if ns.Spec.Autoscaling.TargetPendingJobsPerNode != DefaultNodeSetAutoscalingTargetPendingJobs {
+			t.Errorf("Autoscaling.TargetPendingJobsPerNode: want %d, got %d", DefaultNodeSetAutoscalingTargetPendingJobs, ns.Spec.Autoscaling.TargetPendingJobsPerNode)
+		}
+		if ns.Spec.Autoscaling.ScaleUpCooldown != DefaultNodeSetAutoscalingScaleUpCooldown {
+			t.Errorf("Autoscaling.ScaleUpCooldown: want %v, got %v", DefaultNodeSetAutoscalingScaleUpCooldown, ns.Spec.Autoscaling.ScaleUpCooldown)
+		}
+		if ns.Spec.Autoscaling.ScaleDownCooldown != DefaultNodeSetAutoscalingScaleDownCooldown {
+			t.Errorf("Autoscaling.ScaleDownCooldown: want %v, got %v", DefaultNodeSetAutoscalingScaleDownCooldown, ns.Spec.Autoscaling.ScaleDownCooldown)
+		}
+	})
+
+	t.Run("autoscaling is not defaulted when disabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+		if !equality.Semantic.DeepEqual(ns.Spec.Autoscaling, slinkyv1beta1.NodeSetAutoscaling{}) {
+			t.Errorf("Autoscaling: want zero value, got %+v", ns.Spec.Autoscaling)
+		}
+	})
+	t.Run("power save gets defaults when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.PowerSave.Enabled = true
+		SetNodeSetDefaults(ns)
+		if ns.Spec.PowerSave.SuspendTime != DefaultNodeSetPowerSaveSuspendTime {
+			t.Errorf("PowerSave.SuspendTime: want %v, got %v", DefaultNodeSetPowerSaveSuspendTime, ns.Spec.PowerSave.SuspendTime)
+		}
+		if ns.Spec.PowerSave.ResumeTimeout != DefaultNodeSetPowerSaveResumeTimeout {
+			t.Errorf("PowerSave.ResumeTimeout: want %v, got %v", DefaultNodeSetPowerSaveResumeTimeout, ns.Spec.PowerSave.ResumeTimeout)
+		}
+	})
+
+	t.Run("power save is not defaulted when disabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+		if !equality.Semantic.DeepEqual(ns.Spec.PowerSave, slinkyv1beta1.NodeSetPowerSave{}) {
+			t.Errorf("PowerSave: want zero value, got %+v", ns.Spec.PowerSave)
+		}
+	})
+
+	t.Run("rolling update canary gets defaults when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.UpdateStrategy.RollingUpdate.Canary.Enabled = true
+		SetNodeSetDefaults(ns)
+		canary := ns.Spec.UpdateStrategy.RollingUpdate.Canary
+		if canary.Replicas != DefaultNodeSetRollingUpdateCanaryReplicas {
+			t.Errorf("Canary.Replicas: want %v, got %v", DefaultNodeSetRollingUpdateCanaryReplicas, canary.Replicas)
+		}
+		if canary.SoakTime != DefaultNodeSetRollingUpdateCanarySoakTime {
+			t.Errorf("Canary.SoakTime: want %v, got %v", DefaultNodeSetRollingUpdateCanarySoakTime, canary.SoakTime)
+		}
+	})
+
+	t.Run("rolling update canary is not defaulted when disabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+		if !equality.Semantic.DeepEqual(ns.Spec.UpdateStrategy.RollingUpdate.Canary, slinkyv1beta1.NodeSetCanary{}) {
+			t.Errorf("Canary: want zero value, got %+v", ns.Spec.UpdateStrategy.RollingUpdate.Canary)
+		}
+	})
+
+	t.Run("rollback gets defaults when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.UpdateStrategy.Rollback.Enabled = true
+		SetNodeSetDefaults(ns)
+		if ns.Spec.UpdateStrategy.Rollback.ProgressDeadline != DefaultNodeSetRollbackProgressDeadline {
+			t.Errorf("Rollback.ProgressDeadline: want %v, got %v", DefaultNodeSetRollbackProgressDeadline, ns.Spec.UpdateStrategy.Rollback.ProgressDeadline)
+		}
+	})
+
+	t.Run("remediation gets defaults when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.Remediation.Enabled = true
+		SetNodeSetDefaults(ns)
+		r := ns.Spec.Remediation
+		if !slices.Equal(r.Conditions, DefaultNodeSetRemediationConditions) {
+			t.Errorf("Remediation.Conditions: want %v, got %v", DefaultNodeSetRemediationConditions, r.Conditions)
+		}
+		if r.UnhealthyDuration != DefaultNodeSetRemediationUnhealthyDuration {
+			t.Errorf("Remediation.UnhealthyDuration: want %v, got %v", DefaultNodeSetRemediationUnhealthyDuration, r.UnhealthyDuration)
+		}
+		if r.MaxUnhealthy == nil || *r.MaxUnhealthy != DefaultNodeSetRemediationMaxUnhealthy {
+			t.Errorf("Remediation.MaxUnhealthy: want %v, got %v", DefaultNodeSetRemediationMaxUnhealthy, r.MaxUnhealthy)
+		}
+		if r.MaxRemediations != DefaultNodeSetRemediationMaxRemediations {
+			t.Errorf("Remediation.MaxRemediations: want %v, got %v", DefaultNodeSetRemediationMaxRemediations, r.MaxRemediations)
+		}
+		if r.Window != DefaultNodeSetRemediationWindow {
+			t.Errorf("Remediation.Window: want %v, got %v", DefaultNodeSetRemediationWindow, r.Window)
+		}
 	})
 }
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// Default values for SlurmAccount, SlurmUser, and SlurmQOS Spec fields when unspecified.
+const (
+	DefaultSlurmdbDeletionPolicy = slinkyv1beta1.SlurmdbDeletionPolicyDelete
+)
+
+func SetSlurmAccountDefaults(account *slinkyv1beta1.SlurmAccount) {
+	if account == nil {
+		return
+	}
+	s := &account.Spec
+
+	if s.DeletionPolicy == "" {
+		s.DeletionPolicy = DefaultSlurmdbDeletionPolicy
+	}
+}
+
+func SetSlurmUserDefaults(user *slinkyv1beta1.SlurmUser) {
+	if user == nil {
+		return
+	}
+	s := &user.Spec
+
+	if s.DeletionPolicy == "" {
+		s.DeletionPolicy = DefaultSlurmdbDeletionPolicy
+	}
+}
+
+func SetSlurmQOSDefaults(qos *slinkyv1beta1.SlurmQOS) {
+	if qos == nil {
+		return
+	}
+	s := &qos.Spec
+
+	if s.DeletionPolicy == "" {
+		s.DeletionPolicy = DefaultSlurmdbDeletionPolicy
+	}
+}
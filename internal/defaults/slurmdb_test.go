@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	"testing"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestSetSlurmdbDefaults(t *testing.T) {
+	t.Run("nil objects are a no-op", func(t *testing.T) {
+		SetSlurmAccountDefaults(nil)
+		SetSlurmUserDefaults(nil)
+		SetSlurmQOSDefaults(nil)
+	})
+
+	t.Run("zero value spec gets defaults", func(t *testing.T) {
+		account := &slinkyv1beta1.SlurmAccount{}
+		SetSlurmAccountDefaults(account)
+		if account.Spec.DeletionPolicy != DefaultSlurmdbDeletionPolicy {
+			t.Errorf("SlurmAccount DeletionPolicy: want %v, got %v", DefaultSlurmdbDeletionPolicy, account.Spec.DeletionPolicy)
+		}
+		user := &slinkyv1beta1.SlurmUser{}
+		SetSlurmUserDefaults(user)
+		if user.Spec.DeletionPolicy != DefaultSlurmdbDeletionPolicy {
+			t.Errorf("SlurmUser DeletionPolicy: want %v, got %v", DefaultSlurmdbDeletionPolicy, user.Spec.DeletionPolicy)
+		}
+		qos := &slinkyv1beta1.SlurmQOS{}
+		SetSlurmQOSDefaults(qos)
+		if qos.Spec.DeletionPolicy != DefaultSlurmdbDeletionPolicy {
+			t.Errorf("SlurmQOS DeletionPolicy: want %v, got %v", DefaultSlurmdbDeletionPolicy, qos.Spec.DeletionPolicy)
+		}
+	})
+
+	t.Run("explicit values are not overridden", func(t *testing.T) {
+		account := &slinkyv1beta1.SlurmAccount{}
+		account.Spec.DeletionPolicy = slinkyv1beta1.SlurmdbDeletionPolicyRetain
+		SetSlurmAccountDefaults(account)
+		if account.Spec.DeletionPolicy != slinkyv1beta1.SlurmdbDeletionPolicyRetain {
+			t.Errorf("SlurmAccount DeletionPolicy: want Retain, got %v", account.Spec.DeletionPolicy)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "slinky"
+
+	labelNamespace  = "namespace"
+	labelNodeSet    = "nodeset"
+	labelController = "controller"
+	labelState      = "state"
+	labelReason     = "reason"
+	labelMethod     = "method"
+	labelObject     = "object"
+)
+
+const (
+	// DrainReasonOperator is the drain reason of the Slurm nodes drained by the operator.
+	DrainReasonOperator = "operator"
+	// DrainReasonOther is the drain reason of the Slurm nodes drained otherwise (e.g. by an admin).
+	DrainReasonOther = "other"
+)
+
+var (
+	// NodeSetNodes is the number of Slurm nodes of a NodeSet by Slurm state.
+	NodeSetNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "nodeset",
+			Name:      "nodes",
+			Help:      "Number of Slurm nodes of the NodeSet, by Slurm node state (e.g. idle, allocated, drain).",
+		},
+		[]string{labelNamespace, labelNodeSet, labelController, labelState},
+	)
+
+	// NodeSetDrainReasons is the number of drained Slurm nodes of a NodeSet by reason.
+	NodeSetDrainReasons = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "nodeset",
+			Name:      "drain_reasons",
+			Help:      "Number of draining or drained Slurm nodes of the NodeSet, by who drained them (operator, other).",
+		},
+		[]string{labelNamespace, labelNodeSet, labelController, labelReason},
+	)
+
+	// NodeSetReservedPods is the number of pods of a NodeSet under its maintenance reservation.
+	NodeSetReservedPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "nodeset",
+			Name:      "reserved_pods",
+			Help:      "Number of pods of the NodeSet under its scheduled update reservation.",
+		},
+		[]string{labelNamespace, labelNodeSet, labelController},
+	)
+
+	// NodeSetScaleInPendingPods is the number of pods of a NodeSet pending
+	// scale-in until the deadline of their Slurm jobs.
+	NodeSetScaleInPendingPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "nodeset",
+			Name:      "scale_in_pending_pods",
+			Help:      "Number of cordoned pods of the NodeSet waiting on the deadline of their Slurm jobs before scale-in.",
+		},
+		[]string{labelNamespace, labelNodeSet, labelController},
+	)
+
+	// SlurmrestdRequestDuration is the latency of requests to slurmrestd.
+	SlurmrestdRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "slurmrestd",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to slurmrestd, by Controller, method, and Slurm object.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{labelController, labelMethod, labelObject},
+	)
+
+	// SlurmrestdRequestErrors is the number of failed requests to slurmrestd.
+	SlurmrestdRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "slurmrestd",
+			Name:      "request_errors_total",
+			Help:      "Number of failed requests to slurmrestd, by Controller, method, and Slurm object.",
+		},
+		[]string{labelController, labelMethod, labelObject},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		NodeSetNodes,
+		NodeSetDrainReasons,
+		NodeSetReservedPods,
+		NodeSetScaleInPendingPods,
+		SlurmrestdRequestDuration,
+		SlurmrestdRequestErrors,
+	)
+}
+
+// NodeSetState is the Slurm state of the nodes of a NodeSet.
+type NodeSetState struct {
+	// Controller is the name of the Controller of the NodeSet.
+	Controller string
+	// Nodes is the number of Slurm nodes by state.
+	Nodes map[string]int32
+	// DrainReasons is the number of drained Slurm nodes by DrainReasonOperator
+	// or DrainReasonOther.
+	DrainReasons map[string]int32
+	// ReservedPods is the number of pods under the NodeSet reservation.
+	ReservedPods int32
+	// ScaleInPendingPods is the number of pods pending scale-in.
+	ScaleInPendingPods int32
+}
+
+var (
+	nodeSetSeriesMu sync.Mutex
+	// nodeSetSeries is the series of each NodeSet, so the stale ones are deleted.
+	nodeSetSeries = map[types.NamespacedName][]series{}
+)
+
+type series struct {
+	vec    *prometheus.GaugeVec
+	labels prometheus.Labels
+}
+
+func (s series) equal(other series) bool {
+	return s.vec == other.vec && maps.Equal(s.labels, other.labels)
+}
+
+// SetNodeSetState replaces the metrics of the NodeSet with its state. The new
+// series are set before the stale ones are deleted, so scrapes see no gap.
+func SetNodeSetState(nodeset types.NamespacedName, state NodeSetState) {
+	nodeSetSeriesMu.Lock()
+	defer nodeSetSeriesMu.Unlock()
+
+	labels := prometheus.Labels{
+		labelNamespace:  nodeset.Namespace,
+		labelNodeSet:    nodeset.Name,
+		labelController: state.Controller,
+	}
+	var current []series
+	set := func(vec *prometheus.GaugeVec, labels prometheus.Labels, value float64) {
+		vec.With(labels).Set(value)
+		current = append(current, series{vec: vec, labels: labels})
+	}
+	for s, count := range state.Nodes {
+		set(NodeSetNodes, withLabel(labels, labelState, s), float64(count))
+	}
+	for reason, count := range state.DrainReasons {
+		set(NodeSetDrainReasons, withLabel(labels, labelReason, reason), float64(count))
+	}
+	set(NodeSetReservedPods, labels, float64(state.ReservedPods))
+	set(NodeSetScaleInPendingPods, labels, float64(state.ScaleInPendingPods))
+
+	for _, old := range nodeSetSeries[nodeset] {
+		if !slices.ContainsFunc(current, old.equal) {
+			old.vec.Delete(old.labels)
+		}
+	}
+	nodeSetSeries[nodeset] = current
+}
+
+// DeleteNodeSetState deletes the metrics of the NodeSet.
+func DeleteNodeSetState(nodeset types.NamespacedName) {
+	nodeSetSeriesMu.Lock()
+	defer nodeSetSeriesMu.Unlock()
+
+	labels := prometheus.Labels{
+		labelNamespace: nodeset.Namespace,
+		labelNodeSet:   nodeset.Name,
+	}
+	NodeSetNodes.DeletePartialMatch(labels)
+	NodeSetDrainReasons.DeletePartialMatch(labels)
+	NodeSetReservedPods.DeletePartialMatch(labels)
+	NodeSetScaleInPendingPods.DeletePartialMatch(labels)
+	delete(nodeSetSeries, nodeset)
+}
+
+func withLabel(labels prometheus.Labels, name, value string) prometheus.Labels {
+	out := make(prometheus.Labels, len(labels)+1)
+	maps.Copy(out, labels)
+	out[name] = value
+	return out
+}
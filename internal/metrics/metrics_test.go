@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+func TestSetNodeSetState(t *testing.T) {
+	nodeset := types.NamespacedName{Namespace: "slurm", Name: "slurm-worker-foo"}
+	other := types.NamespacedName{Namespace: "slurm", Name: "slurm-worker-bar"}
+	defer DeleteNodeSetState(nodeset)
+	defer DeleteNodeSetState(other)
+
+	SetNodeSetState(other, NodeSetState{
+		Controller: "slurm",
+		Nodes:      map[string]int32{"idle": 1},
+	})
+	SetNodeSetState(nodeset, NodeSetState{
+		Controller:   "slurm",
+		Nodes:        map[string]int32{"idle": 2, "drain": 1},
+		DrainReasons: map[string]int32{DrainReasonOther: 1},
+		ReservedPods: 3,
+	})
+
+	if got := testutil.ToFloat64(NodeSetNodes.WithLabelValues("slurm", "slurm-worker-foo", "slurm", "idle")); got != 2 {
+		t.Errorf("NodeSetNodes{idle} = %v, want %v", got, 2)
+	}
+	if got := testutil.ToFloat64(NodeSetDrainReasons.WithLabelValues("slurm", "slurm-worker-foo", "slurm", DrainReasonOther)); got != 1 {
+		t.Errorf("NodeSetDrainReasons{other} = %v, want %v", got, 1)
+	}
+	if got := testutil.ToFloat64(NodeSetReservedPods.WithLabelValues("slurm", "slurm-worker-foo", "slurm")); got != 3 {
+		t.Errorf("NodeSetReservedPods = %v, want %v", got, 3)
+	}
+
+	// Stale series are removed when the state changes.
+	SetNodeSetState(nodeset, NodeSetState{
+		Controller: "slurm",
+		Nodes:      map[string]int32{"idle": 3},
+	})
+	if got := testutil.CollectAndCount(NodeSetNodes); got != 2 {
+		t.Errorf("CollectAndCount(NodeSetNodes) = %v, want %v", got, 2)
+	}
+	if got := testutil.CollectAndCount(NodeSetDrainReasons); got != 0 {
+		t.Errorf("CollectAndCount(NodeSetDrainReasons) = %v, want %v", got, 0)
+	}
+
+	DeleteNodeSetState(nodeset)
+	if got := testutil.CollectAndCount(NodeSetNodes); got != 1 {
+		t.Errorf("CollectAndCount(NodeSetNodes) = %v, want %v", got, 1)
+	}
+	if got := testutil.CollectAndCount(NodeSetReservedPods); got != 1 {
+		t.Errorf("CollectAndCount(NodeSetReservedPods) = %v, want %v", got, 1)
+	}
+}
+
+type fakeClient struct {
+	slurmclient.Client
+
+	err error
+}
+
+func (c *fakeClient) Get(ctx context.Context, key slurmobject.ObjectKey, obj slurmobject.Object, opts ...slurmclient.GetOption) error {
+	return c.err
+}
+
+func TestInstrumentedClient(t *testing.T) {
+	controller := types.NamespacedName{Namespace: "slurm", Name: "slurm"}
+	c := &fakeClient{}
+	instrumented := NewInstrumentedClient(controller, c)
+
+	if err := instrumented.Get(context.TODO(), "root", &slurmtypes.V0044Account{}); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	c.err = errors.New("unreachable")
+	if err := instrumented.Get(context.TODO(), "root", &slurmtypes.V0044Account{}); err == nil {
+		t.Fatalf("Get() error = nil, want %v", c.err)
+	}
+
+	if got := testutil.ToFloat64(SlurmrestdRequestErrors.WithLabelValues("slurm/slurm", "get", "V0044Account")); got != 1 {
+		t.Errorf("SlurmrestdRequestErrors = %v, want %v", got, 1)
+	}
+	if got := testutil.CollectAndCount(SlurmrestdRequestDuration); got != 1 {
+		t.Errorf("CollectAndCount(SlurmrestdRequestDuration) = %v, want %v", got, 1)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reflectutils"
+)
+
+// NewInstrumentedClient returns the Slurm client of the Controller, which
+// observes the latency and errors of its requests to slurmrestd.
+func NewInstrumentedClient(controller types.NamespacedName, c slurmclient.Client) slurmclient.Client {
+	return &instrumentedClient{
+		Client:     c,
+		controller: controller.String(),
+	}
+}
+
+type instrumentedClient struct {
+	slurmclient.Client
+
+	controller string
+}
+
+// Get implements slurmclient.Client.
+func (c *instrumentedClient) Get(ctx context.Context, key slurmobject.ObjectKey, obj slurmobject.Object, opts ...slurmclient.GetOption) error {
+	return c.observe("get", obj, func() error {
+		return c.Client.Get(ctx, key, obj, opts...)
+	})
+}
+
+// List implements slurmclient.Client.
+func (c *instrumentedClient) List(ctx context.Context, list slurmobject.ObjectList, opts ...slurmclient.ListOption) error {
+	return c.observe("list", list, func() error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+// Create implements slurmclient.Client.
+func (c *instrumentedClient) Create(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.CreateOption) error {
+	return c.observe("create", obj, func() error {
+		return c.Client.Create(ctx, obj, req, opts...)
+	})
+}
+
+// Update implements slurmclient.Client.
+func (c *instrumentedClient) Update(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.UpdateOption) error {
+	return c.observe("update", obj, func() error {
+		return c.Client.Update(ctx, obj, req, opts...)
+	})
+}
+
+// Delete implements slurmclient.Client.
+func (c *instrumentedClient) Delete(ctx context.Context, obj slurmobject.Object, opts ...slurmclient.DeleteOption) error {
+	return c.observe("delete", obj, func() error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) observe(method string, obj any, fn func() error) error {
+	object := reflectutils.TypeName(obj)
+	start := time.Now()
+	err := fn()
+	SlurmrestdRequestDuration.WithLabelValues(c.controller, method, object).Observe(time.Since(start).Seconds())
+	if err != nil {
+		SlurmrestdRequestErrors.WithLabelValues(c.controller, method, object).Inc()
+	}
+	return err
+}
+
+var _ slurmclient.Client = &instrumentedClient{}
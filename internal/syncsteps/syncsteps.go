@@ -6,16 +6,35 @@ package syncsteps
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SlinkyProject/slurm-operator/internal/tracing"
 )
 
 const syncAction = "Sync"
 const failedReason = "SyncFailed"
 
+// ConditionsObject is an object whose status has conditions. Sync sets a
+// condition on it for each failed step, and removes it once the step succeeds.
+// The conditions are only set in memory, so the status update must compare
+// against the stored object, not this one.
+type ConditionsObject interface {
+	GetConditions() []metav1.Condition
+	SetConditions([]metav1.Condition)
+}
+
+// ConditionType returns the type of the condition of a failed step.
+func ConditionType(name string) string {
+	return strings.ReplaceAll(name, " ", "") + failedReason
+}
+
 type Step[T client.Object] struct {
 	Name        string
 	SyncFn      func(context.Context, T) error
@@ -28,9 +47,16 @@ func Sync[T client.Object](
 	obj T,
 	steps []Step[T],
 ) error {
+	attrs := tracing.ObjectAttributes(obj)
+	ctx, span := tracing.Start(ctx, syncAction+" "+tracing.KindOf(obj), attrs...)
+
 	var errs []error
 	for _, s := range steps {
-		if err := s.SyncFn(ctx, obj); err != nil {
+		stepCtx, stepSpan := tracing.Start(ctx, syncAction+" "+s.Name, attrs...)
+		err := s.SyncFn(stepCtx, obj)
+		tracing.End(stepSpan, err)
+		setStepCondition(obj, s.Name, err)
+		if err != nil {
 			msg := fmt.Sprintf("Failed %q step: %v", s.Name, err)
 			if recorder != nil {
 				recorder.Eventf(obj, nil, corev1.EventTypeWarning, failedReason, syncAction, msg)
@@ -41,5 +67,31 @@ func Sync[T client.Object](
 			}
 		}
 	}
-	return utilerrors.NewAggregate(errs)
+	err := utilerrors.NewAggregate(errs)
+	tracing.End(span, err)
+	return err
+}
+
+// setStepCondition sets the condition of the step from its error, if the
+// object has conditions.
+func setStepCondition(obj client.Object, name string, err error) {
+	o, ok := obj.(ConditionsObject)
+	if !ok {
+		return
+	}
+	conditions := o.GetConditions()
+	if err == nil {
+		if meta.RemoveStatusCondition(&conditions, ConditionType(name)) {
+			o.SetConditions(conditions)
+		}
+		return
+	}
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               ConditionType(name),
+		Status:             metav1.ConditionTrue,
+		Reason:             failedReason,
+		Message:            err.Error(),
+		ObservedGeneration: obj.GetGeneration(),
+	})
+	o.SetConditions(conditions)
 }
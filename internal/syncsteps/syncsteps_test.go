@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/events"
 )
@@ -177,3 +179,55 @@ func TestSync_FirstSucceedsSecondFails_OneError(t *testing.T) {
 	readOneEvent(t, rec)
 	assertNoEvents(t, rec)
 }
+
+type conditionsConfigMap struct {
+	corev1.ConfigMap
+	conditions []metav1.Condition
+}
+
+func (o *conditionsConfigMap) GetConditions() []metav1.Condition {
+	return o.conditions
+}
+
+func (o *conditionsConfigMap) SetConditions(conditions []metav1.Condition) {
+	o.conditions = conditions
+}
+
+func TestSync_ConditionsObject_SetsAndClearsStepConditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	obj := &conditionsConfigMap{}
+	obj.Generation = 3
+	failing := true
+	steps := []Step[*conditionsConfigMap]{
+		{Name: "ok", SyncFn: func(context.Context, *conditionsConfigMap) error { return nil }},
+		{Name: "SSH Config", SyncFn: func(context.Context, *conditionsConfigMap) error {
+			if failing {
+				return errors.New("bad")
+			}
+			return nil
+		}},
+	}
+
+	if err := Sync(ctx, nil, obj, steps); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(obj.conditions) != 1 {
+		t.Fatalf("want one condition, got %v", obj.conditions)
+	}
+	cond := meta.FindStatusCondition(obj.conditions, "SSHConfigSyncFailed")
+	if cond == nil {
+		t.Fatalf("want SSHConfigSyncFailed condition, got %v", obj.conditions)
+	}
+	if cond.Status != metav1.ConditionTrue || cond.Reason != failedReason || cond.Message != "bad" || cond.ObservedGeneration != 3 {
+		t.Fatalf("condition: %+v", cond)
+	}
+
+	failing = false
+	if err := Sync(ctx, nil, obj, steps); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(obj.conditions) != 0 {
+		t.Fatalf("want conditions cleared, got %v", obj.conditions)
+	}
+}
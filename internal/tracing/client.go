@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AttributeMethod is the method of the request (e.g. patch, list).
+const AttributeMethod = attribute.Key("slinky.method")
+
+// NewClient returns the Kubernetes client, which traces its writes to the
+// Kubernetes API. Reads are served from the cache, so they are not traced.
+func NewClient(c client.Client) client.Client {
+	return &tracedClient{Client: c}
+}
+
+type tracedClient struct {
+	client.Client
+}
+
+// Create implements client.Client.
+func (c *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := startRequest(ctx, "create", obj, "")
+	err := c.Client.Create(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Update implements client.Client.
+func (c *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := startRequest(ctx, "update", obj, "")
+	err := c.Client.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Patch implements client.Client.
+func (c *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := startRequest(ctx, "patch", obj, "")
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+// Delete implements client.Client.
+func (c *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := startRequest(ctx, "delete", obj, "")
+	err := c.Client.Delete(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Status implements client.Client.
+func (c *tracedClient) Status() client.SubResourceWriter {
+	return &tracedSubResourceWriter{SubResourceWriter: c.Client.Status(), subResource: "status"}
+}
+
+type tracedSubResourceWriter struct {
+	client.SubResourceWriter
+
+	subResource string
+}
+
+// Create implements client.SubResourceWriter.
+func (w *tracedSubResourceWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	ctx, span := startRequest(ctx, "create", obj, w.subResource)
+	err := w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+	End(span, err)
+	return err
+}
+
+// Update implements client.SubResourceWriter.
+func (w *tracedSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, span := startRequest(ctx, "update", obj, w.subResource)
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Patch implements client.SubResourceWriter.
+func (w *tracedSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, span := startRequest(ctx, "patch", obj, w.subResource)
+	err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+// startRequest starts the span of a request to the Kubernetes API
+// (e.g. "kube patch NodeSet/status").
+func startRequest(ctx context.Context, method string, obj client.Object, subResource string) (context.Context, trace.Span) {
+	resource := KindOf(obj)
+	if subResource != "" {
+		resource += "/" + subResource
+	}
+	attrs := append(ObjectAttributes(obj), AttributeMethod.String(method))
+	return Start(ctx, "kube "+method+" "+resource, attrs...)
+}
+
+var _ client.Client = &tracedClient{}
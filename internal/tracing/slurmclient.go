@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reflectutils"
+)
+
+// AttributeSlurmObject is the type of the Slurm object (e.g. V0044Node).
+const AttributeSlurmObject = attribute.Key("slurm.object")
+
+// NewTracedClient returns the Slurm client of the Controller, which traces
+// each of its requests to slurmrestd.
+func NewTracedClient(controller types.NamespacedName, c slurmclient.Client) slurmclient.Client {
+	return &tracedSlurmClient{
+		Client:     c,
+		controller: controller.String(),
+	}
+}
+
+type tracedSlurmClient struct {
+	slurmclient.Client
+
+	controller string
+}
+
+// Get implements slurmclient.Client.
+func (c *tracedSlurmClient) Get(ctx context.Context, key slurmobject.ObjectKey, obj slurmobject.Object, opts ...slurmclient.GetOption) error {
+	ctx, span := c.start(ctx, "get", obj, AttributeName.String(string(key)))
+	err := c.Client.Get(ctx, key, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// List implements slurmclient.Client.
+func (c *tracedSlurmClient) List(ctx context.Context, list slurmobject.ObjectList, opts ...slurmclient.ListOption) error {
+	ctx, span := c.start(ctx, "list", list)
+	err := c.Client.List(ctx, list, opts...)
+	End(span, err)
+	return err
+}
+
+// Create implements slurmclient.Client.
+func (c *tracedSlurmClient) Create(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.CreateOption) error {
+	ctx, span := c.start(ctx, "create", obj)
+	err := c.Client.Create(ctx, obj, req, opts...)
+	End(span, err)
+	return err
+}
+
+// Update implements slurmclient.Client.
+func (c *tracedSlurmClient) Update(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.UpdateOption) error {
+	ctx, span := c.start(ctx, "update", obj)
+	err := c.Client.Update(ctx, obj, req, opts...)
+	End(span, err)
+	return err
+}
+
+// Delete implements slurmclient.Client.
+func (c *tracedSlurmClient) Delete(ctx context.Context, obj slurmobject.Object, opts ...slurmclient.DeleteOption) error {
+	ctx, span := c.start(ctx, "delete", obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// start starts the span of a request to slurmrestd (e.g. "slurmrestd list V0044NodeList").
+func (c *tracedSlurmClient) start(ctx context.Context, method string, obj any, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	object := reflectutils.TypeName(obj)
+	attrs = append(attrs,
+		AttributeController.String(c.controller),
+		AttributeMethod.String(method),
+		AttributeSlurmObject.String(object),
+	)
+	return Start(ctx, "slurmrestd "+method+" "+object, attrs...)
+}
+
+var _ slurmclient.Client = &tracedSlurmClient{}
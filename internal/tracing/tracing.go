@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reflectutils"
+)
+
+const (
+	tracerName  = "github.com/SlinkyProject/slurm-operator"
+	serviceName = "slurm-operator"
+)
+
+// Span attributes of the operator.
+const (
+	AttributeNamespace  = attribute.Key("k8s.namespace.name")
+	AttributePod        = attribute.Key("k8s.pod.name")
+	AttributeKind       = attribute.Key("slinky.kind")
+	AttributeName       = attribute.Key("slinky.name")
+	AttributeNodeSet    = attribute.Key("slinky.nodeset")
+	AttributeController = attribute.Key("slinky.controller")
+	AttributeSlurmNode  = attribute.Key("slurm.node.name")
+)
+
+// Options configures the export of traces.
+type Options struct {
+	// Endpoint is the URL of the OTLP/HTTP collector
+	// (e.g. http://otel-collector:4318). Tracing is disabled when empty.
+	Endpoint string
+	// SampleRatio is the ratio of traces which are sampled, from 0 to 1.
+	SampleRatio float64
+}
+
+// Setup configures the global tracer provider to export traces to the OTLP
+// collector. It returns a function which flushes and stops the export.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the operator. It is a no-op unless tracing is set up.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectAttributes returns the attributes of the Kubernetes object.
+func ObjectAttributes(obj client.Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		AttributeNamespace.String(obj.GetNamespace()),
+		AttributeKind.String(KindOf(obj)),
+		AttributeName.String(obj.GetName()),
+	}
+}
+
+// KindOf returns the kind of the object, even when its TypeMeta is empty.
+func KindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflectutils.TypeName(obj)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// collector is an in-process OTLP/HTTP collector, which records the spans
+// exported to it.
+type collector struct {
+	mu    sync.Mutex
+	spans map[string]map[string]string
+}
+
+func newCollector(t *testing.T) (*collector, string) {
+	c := &collector{spans: map[string]map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read export request: %v", err)
+		}
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("failed to unmarshal export request: %v", err)
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, resourceSpans := range req.GetResourceSpans() {
+			for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+				for _, span := range scopeSpans.GetSpans() {
+					attrs := map[string]string{}
+					for _, attr := range span.GetAttributes() {
+						attrs[attr.GetKey()] = attr.GetValue().GetStringValue()
+					}
+					c.spans[span.GetName()] = attrs
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return c, server.URL
+}
+
+func (c *collector) names() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.spans))
+	for name := range c.spans {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+type fakeSlurmClient struct {
+	slurmclient.Client
+}
+
+func (c *fakeSlurmClient) List(ctx context.Context, list slurmobject.ObjectList, opts ...slurmclient.ListOption) error {
+	return errors.New("unreachable")
+}
+
+type fakeClient struct {
+	client.Client
+}
+
+func (c *fakeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+	c, endpoint := newCollector(t)
+
+	shutdown, err := Setup(ctx, Options{Endpoint: endpoint, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "foo"},
+	}
+	ctx, span := Start(ctx, "Sync NodeSet", ObjectAttributes(nodeset)...)
+	slurmClient := NewTracedClient(types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm"}, &fakeSlurmClient{})
+	_ = slurmClient.List(ctx, &slurmtypes.V0044NodeList{})
+	k8sClient := NewClient(&fakeClient{})
+	_ = k8sClient.Patch(ctx, nodeset, client.MergeFrom(nodeset.DeepCopy()))
+	End(span, nil)
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	want := []string{"Sync NodeSet", "kube patch NodeSet", "slurmrestd list V0044NodeList"}
+	if got := c.names(); !slices.Equal(got, want) {
+		t.Errorf("spans = %v, want %v", got, want)
+	}
+	if got := c.spans["Sync NodeSet"][string(AttributeKind)]; got != "NodeSet" {
+		t.Errorf("%s = %q, want %q", AttributeKind, got, "NodeSet")
+	}
+	if got := c.spans["slurmrestd list V0044NodeList"][string(AttributeController)]; got != "default/slurm" {
+		t.Errorf("%s = %q, want %q", AttributeController, got, "default/slurm")
+	}
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package keyrotation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/rolloututils"
+)
+
+// DefaultGracePeriod is how long every key is accepted before the active key
+// signs, when the rotation does not set it.
+const DefaultGracePeriod = 10 * time.Minute
+
+// RolloutInterval is how often the rollout of the accepted keys is checked,
+// once the grace period is over.
+const RolloutInterval = 10 * time.Second
+
+// SyncStatus returns the status of the rotation at the time, and how long
+// until the active key of the spec may sign.
+//
+// The active key of the status only changes to that of the spec once the
+// accepted keys have not changed for the grace period, and every component
+// has rolled out the accepted keys of the status, so every component accepts
+// the new key before it signs. When nothing signs with a key of the spec yet,
+// the active key of the spec is used at once.
+func SyncStatus(
+	rotation *slinkyv1beta1.AuthKeyRotation,
+	status *slinkyv1beta1.AuthKeyRotationStatus,
+	rolledOut bool,
+	now time.Time,
+) (*slinkyv1beta1.AuthKeyRotationStatus, time.Duration) {
+	if rotation == nil {
+		return nil, 0
+	}
+
+	keyIDs := make([]string, 0, len(rotation.Keys))
+	for _, key := range rotation.Keys {
+		keyIDs = append(keyIDs, key.ID)
+	}
+	slices.Sort(keyIDs)
+
+	if status == nil || rotation.Key(status.ActiveKeyID) == nil {
+		return &slinkyv1beta1.AuthKeyRotationStatus{
+			ActiveKeyID:        rotation.ActiveKeyID,
+			KeyIDs:             keyIDs,
+			LastTransitionTime: metav1.NewTime(now),
+		}, 0
+	}
+
+	out := status.DeepCopy()
+	if !slices.Equal(out.KeyIDs, keyIDs) {
+		out.KeyIDs = keyIDs
+		out.LastTransitionTime = metav1.NewTime(now)
+	}
+	if out.ActiveKeyID == rotation.ActiveKeyID {
+		return out, 0
+	}
+
+	gracePeriod := rotation.GracePeriod.Duration
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultGracePeriod
+	}
+	activeTime := out.LastTransitionTime.Add(gracePeriod)
+	if now.Before(activeTime) {
+		return out, activeTime.Sub(now)
+	}
+	if !rolledOut {
+		return out, RolloutInterval
+	}
+
+	out.ActiveKeyID = rotation.ActiveKeyID
+	out.LastTransitionTime = metav1.NewTime(now)
+	return out, 0
+}
+
+// Hash returns the checksum of the accepted keys and the active key of the
+// status, which changes as they are rotated.
+func Hash(status *slinkyv1beta1.AuthKeyRotationStatus) string {
+	if status == nil {
+		return ""
+	}
+	return crypto.CheckSum([]byte(status.ActiveKeyID + ":" + strings.Join(status.KeyIDs, ",")))
+}
+
+// IsStatefulSetRolledOut returns true if the StatefulSet has rolled out the
+// keys of the Secret to every replica, by the checksum annotation of its pod
+// template, or if it does not exist.
+func IsStatefulSetRolledOut(
+	ctx context.Context,
+	c client.Reader,
+	key, secretKey types.NamespacedName,
+	annotation string,
+) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := c.Get(ctx, key, sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return sts.Spec.Template.Annotations[annotation] == crypto.CheckSumFromMap(secret.Data) &&
+		rolloututils.FromStatefulSet(sts).IsComplete(), nil
+}
+
+// IsDeploymentRolledOut returns true if the Deployment has rolled out the pod
+// template with the annotation to every replica, or if it does not exist.
+func IsDeploymentRolledOut(
+	ctx context.Context,
+	c client.Reader,
+	key types.NamespacedName,
+	annotation, value string,
+) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, key, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return deployment.Spec.Template.Annotations[annotation] == value &&
+		rolloututils.FromDeployment(deployment).IsComplete(), nil
+}
+
+// https://slurm.schedmd.com/authentication.html#slurm
+type slurmJwks struct {
+	Keys []slurmJwk `json:"keys"`
+}
+
+type slurmJwk struct {
+	Alg string `json:"alg"`
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	K   string `json:"k"`
+	Use string `json:"use,omitempty"`
+}
+
+// BuildSlurmJwks returns the `slurm.jwks` of the keys by ID, of which the
+// active key signs and all are accepted.
+//
+// https://slurm.schedmd.com/authentication.html#slurm
+func BuildSlurmJwks(keys map[string][]byte, activeKeyID string) (string, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return "", fmt.Errorf("active key %q is not one of the keys", activeKeyID)
+	}
+
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	jwks := slurmJwks{
+		Keys: make([]slurmJwk, 0, len(ids)),
+	}
+	for _, id := range ids {
+		jwk := slurmJwk{
+			Alg: "HS256",
+			Kty: "oct",
+			Kid: id,
+			K:   base64.RawURLEncoding.EncodeToString(keys[id]),
+		}
+		if id == activeKeyID {
+			jwk.Use = "default"
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	out, err := json.MarshalIndent(jwks, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package keyrotation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func newRotation(activeKeyID string, ids ...string) *slinkyv1beta1.AuthKeyRotation {
+	rotation := &slinkyv1beta1.AuthKeyRotation{
+		ActiveKeyID: activeKeyID,
+	}
+	for _, id := range ids {
+		rotation.Keys = append(rotation.Keys, slinkyv1beta1.AuthKey{
+			ID: id,
+			SecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "slurm-key-" + id},
+				Key:                  "slurm.key",
+			},
+		})
+	}
+	return rotation
+}
+
+func TestSyncStatus(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	tests := []struct {
+		name        string
+		rotation    *slinkyv1beta1.AuthKeyRotation
+		status      *slinkyv1beta1.AuthKeyRotationStatus
+		pending     bool
+		want        *slinkyv1beta1.AuthKeyRotationStatus
+		wantRequeue time.Duration
+	}{
+		{
+			name: "no rotation",
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID: "a",
+			},
+			want: nil,
+		},
+		{
+			name:     "initial",
+			rotation: newRotation("b", "b", "a"),
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "b",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:     "key added",
+			rotation: newRotation("a", "a", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a"},
+				LastTransitionTime: before(time.Hour),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:     "key added and activated",
+			rotation: newRotation("b", "a", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a"},
+				LastTransitionTime: before(time.Hour),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+			wantRequeue: DefaultGracePeriod,
+		},
+		{
+			name:     "within grace period",
+			rotation: newRotation("b", "a", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(4 * time.Minute),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(4 * time.Minute),
+			},
+			wantRequeue: 6 * time.Minute,
+		},
+		{
+			name: "custom grace period",
+			rotation: func() *slinkyv1beta1.AuthKeyRotation {
+				rotation := newRotation("b", "a", "b")
+				rotation.GracePeriod = metav1.Duration{Duration: time.Minute}
+				return rotation
+			}(),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(4 * time.Minute),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "b",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:     "after grace period",
+			rotation: newRotation("b", "a", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(time.Hour),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "b",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:     "after grace period, pending rollout",
+			rotation: newRotation("b", "a", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(time.Hour),
+			},
+			pending: true,
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(time.Hour),
+			},
+			wantRequeue: RolloutInterval,
+		},
+		{
+			name:     "initial, pending rollout",
+			rotation: newRotation("b", "b", "a"),
+			pending:  true,
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "b",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:     "active key retired",
+			rotation: newRotation("b", "b"),
+			status: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "a",
+				KeyIDs:             []string{"a", "b"},
+				LastTransitionTime: before(time.Minute),
+			},
+			want: &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID:        "b",
+				KeyIDs:             []string{"b"},
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotRequeue := SyncStatus(tt.rotation, tt.status, !tt.pending, now)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SyncStatus() (-want,+got):\n%s", diff)
+			}
+			if gotRequeue != tt.wantRequeue {
+				t.Errorf("SyncStatus() requeue = %v, want %v", gotRequeue, tt.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	status := &slinkyv1beta1.AuthKeyRotationStatus{
+		ActiveKeyID: "a",
+		KeyIDs:      []string{"a", "b"},
+	}
+	if got := Hash(nil); got != "" {
+		t.Errorf("Hash(nil) = %v, want empty", got)
+	}
+	hash := Hash(status)
+	status.ActiveKeyID = "b"
+	if got := Hash(status); got == hash {
+		t.Errorf("Hash() = %v, want changed on active key", got)
+	}
+}
+
+func TestIsDeploymentRolledOut(t *testing.T) {
+	key := types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm-restapi"}
+	newDeployment := func(value string, updated int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](2),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"hash": value},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				UpdatedReplicas:   updated,
+				AvailableReplicas: 2,
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		objs []client.Object
+		want bool
+	}{
+		{
+			name: "not found",
+			want: true,
+		},
+		{
+			name: "rolled out",
+			objs: []client.Object{newDeployment("new", 2)},
+			want: true,
+		},
+		{
+			name: "rolling out",
+			objs: []client.Object{newDeployment("new", 1)},
+		},
+		{
+			name: "stale annotation",
+			objs: []client.Object{newDeployment("old", 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			got, err := IsDeploymentRolledOut(context.TODO(), c, key, "hash", "new")
+			if err != nil {
+				t.Fatalf("IsDeploymentRolledOut() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsDeploymentRolledOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSlurmJwks(t *testing.T) {
+	keys := map[string][]byte{
+		"b": []byte("new"),
+		"a": []byte("old"),
+	}
+	if _, err := BuildSlurmJwks(keys, "c"); err == nil {
+		t.Errorf("BuildSlurmJwks() error = nil, want unknown active key")
+	}
+
+	got, err := BuildSlurmJwks(keys, "b")
+	if err != nil {
+		t.Fatalf("BuildSlurmJwks() error = %v", err)
+	}
+	jwks := slurmJwks{}
+	if err := json.Unmarshal([]byte(got), &jwks); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := slurmJwks{
+		Keys: []slurmJwk{
+			{Alg: "HS256", Kty: "oct", Kid: "a", K: "b2xk"},
+			{Alg: "HS256", Kty: "oct", Kid: "b", K: "bmV3", Use: "default"},
+		},
+	}
+	if diff := cmp.Diff(want, jwks); diff != "" {
+		t.Errorf("BuildSlurmJwks() (-want,+got):\n%s", diff)
+	}
+}
@@ -16,6 +16,16 @@ func IsPodCordon(pod *corev1.Pod) bool {
 	return pod.GetAnnotations()[slinkyv1beta1.AnnotationPodCordon] == "true"
 }
 
+// IsPodRemediation returns true if and only if the remediation annotation is set.
+func IsPodRemediation(pod *corev1.Pod) bool {
+	return pod.GetAnnotations()[slinkyv1beta1.AnnotationPodRemediation] != ""
+}
+
+// IsPodEviction returns true if and only if the eviction annotation is set.
+func IsPodEviction(pod *corev1.Pod) bool {
+	return pod.GetAnnotations()[slinkyv1beta1.AnnotationPodEviction] != ""
+}
+
 // isRunningAndReady returns true if pod is in the PodRunning Phase, if it has a condition of PodReady.
 func IsRunningAndReady(pod *corev1.Pod) bool {
 	return IsRunning(pod) && podutil.IsPodReady(pod)
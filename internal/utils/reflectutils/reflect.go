@@ -4,7 +4,9 @@
 package reflectutils
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 )
 
 // UseNonZeroOrDefault returns the input if not effectively zero,
@@ -22,3 +24,12 @@ func IsEmpty[T any](in T) bool {
 	isZero := reflect.DeepEqual(in, zero)
 	return isZero
 }
+
+// TypeName returns the name of the type, without its package (e.g. NodeSet).
+func TypeName(obj any) string {
+	name := fmt.Sprintf("%T", obj)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -6,6 +6,7 @@ package reflectutils
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/utils/ptr"
 )
@@ -73,3 +74,29 @@ func TestUseNonZeroOrDefault_Pointer(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  any
+		want string
+	}{
+		{
+			name: "builtin",
+			obj:  "foo",
+			want: "string",
+		},
+		{
+			name: "pointer",
+			obj:  &corev1.Pod{},
+			want: "Pod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TypeName(tt.obj); got != tt.want {
+				t.Errorf("TypeName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
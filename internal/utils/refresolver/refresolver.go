@@ -13,6 +13,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
 )
 
 type RefResolver struct {
@@ -121,6 +122,30 @@ func (r *RefResolver) GetRestapisForController(ctx context.Context, controller *
 	return out, nil
 }
 
+func (r *RefResolver) GetPartitionsForController(ctx context.Context, controller *slinkyv1beta1.Controller) (*slinkyv1beta1.PartitionList, error) {
+	if controller == nil {
+		return &slinkyv1beta1.PartitionList{}, nil
+	}
+
+	list := &slinkyv1beta1.PartitionList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(controller.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.PartitionList{}
+	for _, item := range list.Items {
+		refKey := types.NamespacedName{
+			Namespace: item.Namespace,
+			Name:      item.Spec.ControllerRef.Name,
+		}
+		if IsKeyMatch(refKey, objectutils.NamespacedName(controller)) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
 func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accounting *slinkyv1beta1.Accounting) (*slinkyv1beta1.ControllerList, error) {
 	if accounting == nil {
 		return &slinkyv1beta1.ControllerList{}, nil
@@ -166,6 +191,80 @@ func (r *RefResolver) GetSecretKeyRef(ctx context.Context, selector corev1.Secre
 	return data, nil
 }
 
+func (r *RefResolver) GetConfigMapKeyRef(ctx context.Context, selector corev1.ConfigMapKeySelector, namespace string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{
+		Name:      selector.Name,
+		Namespace: namespace,
+	}
+	if err := r.reader.Get(ctx, key, configMap); err != nil {
+		return nil, err
+	}
+
+	if data, ok := configMap.Data[selector.Key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[selector.Key]; ok {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("configmap key '%s' not found", selector.Key)
+}
+
+// GetTokenJwtRef returns the Secret key of the key which signs the JWT of the
+// Token, which is the one of its Controller, if referenced.
+func (r *RefResolver) GetTokenJwtRef(ctx context.Context, token *slinkyv1beta1.Token) (corev1.SecretKeySelector, error) {
+	if token.Spec.ControllerRef == nil {
+		return token.JwtRef(), nil
+	}
+	controller, err := r.GetController(ctx, *token.Spec.ControllerRef, token.Namespace)
+	if err != nil {
+		return corev1.SecretKeySelector{}, err
+	}
+	return controller.AuthJwtSigningRef(), nil
+}
+
+// GetJwtSigningKey returns the key which signs the JWTs of the Controller.
+// With a JwtSigningKeyRef, its `kid` is found in the JWKS of the Controller,
+// otherwise it is the HS256 `auth/jwt` key.
+func (r *RefResolver) GetJwtSigningKey(ctx context.Context, controller *slinkyv1beta1.Controller) (*slurmjwt.SigningKey, error) {
+	data, err := r.GetSecretKeyRef(ctx, controller.AuthJwtSigningRef(), controller.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if controller.Spec.JwtSigningKeyRef == nil {
+		return slurmjwt.NewHS256Key(data), nil
+	}
+
+	jwksRef := controller.AuthJwksRef()
+	if jwksRef == nil {
+		return nil, fmt.Errorf("controller %s has jwtSigningKeyRef without jwksKeyRef", objectutils.KeyFunc(controller))
+	}
+	jwks, err := r.GetConfigMapKeyRef(ctx, *jwksRef, controller.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return slurmjwt.ParseSigningKey(data, jwks)
+}
+
+// GetTokenSigningKey returns the key which signs the JWT of the Token, which is
+// the one of its Controller, if referenced.
+func (r *RefResolver) GetTokenSigningKey(ctx context.Context, token *slinkyv1beta1.Token) (*slurmjwt.SigningKey, error) {
+	if token.Spec.ControllerRef == nil {
+		data, err := r.GetSecretKeyRef(ctx, token.JwtRef(), token.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return slurmjwt.NewHS256Key(data), nil
+	}
+	controller, err := r.GetController(ctx, *token.Spec.ControllerRef, token.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetJwtSigningKey(ctx, controller)
+}
+
 func IsKeyMatch(key1, key2 types.NamespacedName) bool {
 	if key1.Namespace == key2.Namespace && key1.Name == key2.Name {
 		return true
@@ -5,10 +5,18 @@ package refresolver
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -443,6 +451,95 @@ func TestRefResolver_GetRestapisForController(t *testing.T) {
 	}
 }
 
+func TestRefResolver_GetPartitionsForController(t *testing.T) {
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx        context.Context
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "debug",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+						},
+					}).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "batch",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm1",
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetPartitionsForController(tt.args.ctx, tt.args.controller)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefResolver.GetPartitionsForController() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Items) != tt.want {
+				t.Errorf("RefResolver.GetPartitionsForController() = %v, want %v", len(got.Items), tt.want)
+			}
+		})
+	}
+}
+
 func TestRefResolver_GetControllersForAccounting(t *testing.T) {
 	type fields struct {
 		reader client.Reader
@@ -610,3 +707,419 @@ func TestRefResolver_GetSecretKeyRef(t *testing.T) {
 		})
 	}
 }
+
+func TestRefResolver_GetTokenJwtRef(t *testing.T) {
+	jwtKeyRef := testutils.NewJwtKeyRef("token")
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	jwksController := testutils.NewController("jwks", testutils.NewSlurmKeyRef("jwks"), testutils.NewJwtKeyRef("jwks"), nil)
+	jwksController.Spec.JwtSigningKeyRef = new(testutils.NewJwtKeyRef("jwks-es256"))
+	newToken := func(controllerName string) *slinkyv1beta1.Token {
+		token := &slinkyv1beta1.Token{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "token",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: slinkyv1beta1.TokenSpec{
+				JwtKeyRef: &jwtKeyRef,
+			},
+		}
+		if controllerName != "" {
+			token.Spec.ControllerRef = &corev1.LocalObjectReference{Name: controllerName}
+		}
+		return token
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx   context.Context
+		token *slinkyv1beta1.Token
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    corev1.SecretKeySelector
+		wantErr bool
+	}{
+		{
+			name: "jwtKeyRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken(""),
+			},
+			want: jwtKeyRef,
+		},
+		{
+			name: "controllerRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(controller).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			want: controller.AuthJwtRef(),
+		},
+		{
+			name: "controllerRef with jwtSigningKeyRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(jwksController).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("jwks"),
+			},
+			want: *jwksController.Spec.JwtSigningKeyRef,
+		},
+		{
+			name: "controllerRef not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetTokenJwtRef(tt.args.ctx, tt.args.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefResolver.GetTokenJwtRef() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("RefResolver.GetTokenJwtRef() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefResolver_GetConfigMapKeyRef(t *testing.T) {
+	selector := corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "configmap",
+		},
+		Key: "jwks.json",
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx       context.Context
+		selector  corev1.ConfigMapKeySelector
+		namespace string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				selector:  selector,
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+		{
+			name: "key not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: metav1.NamespaceDefault,
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				selector:  selector,
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+		{
+			name: "data",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Data: map[string]string{
+							"jwks.json": `{"keys":[]}`,
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				selector:  selector,
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []byte(`{"keys":[]}`),
+		},
+		{
+			name: "binaryData",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: metav1.NamespaceDefault,
+						},
+						BinaryData: map[string][]byte{
+							"jwks.json": []byte(`{"keys":[]}`),
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				selector:  selector,
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []byte(`{"keys":[]}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetConfigMapKeyRef(tt.args.ctx, tt.args.selector, tt.args.namespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefResolver.GetConfigMapKeyRef() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("RefResolver.GetConfigMapKeyRef() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefResolver_GetTokenSigningKey(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point, err := privateKey.PublicKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"key1","crv":"P-256","x":%q,"y":%q}]}`,
+		encode(point[1:33]), encode(point[33:]))
+
+	jwtKeyRef := testutils.NewJwtKeyRef("slurm")
+	signingKeyRef := testutils.NewJwtKeyRef("slurm-es256")
+	jwksRef := &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "slurm-jwks",
+		},
+		Key: "jwks.json",
+	}
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), jwtKeyRef, nil)
+	jwksController := controller.DeepCopy()
+	jwksController.Spec.JwtSigningKeyRef = &signingKeyRef
+	jwksController.Spec.JwksKeyRef = jwksRef
+	jwtKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtKeyRef.Name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			jwtKeyRef.Key: []byte("password1"),
+		},
+	}
+	signingKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      signingKeyRef.Name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			signingKeyRef.Key: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		},
+	}
+	sharedSecretSigningKeySecret := signingKeySecret.DeepCopy()
+	sharedSecretSigningKeySecret.Data[signingKeyRef.Key] = []byte("password1")
+	jwksConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwksRef.Name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string]string{
+			jwksRef.Key: jwks,
+		},
+	}
+	newToken := func(controllerName string) *slinkyv1beta1.Token {
+		token := &slinkyv1beta1.Token{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "token",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: slinkyv1beta1.TokenSpec{
+				JwtKeyRef: &jwtKeyRef,
+			},
+		}
+		if controllerName != "" {
+			token.Spec.ControllerRef = &corev1.LocalObjectReference{Name: controllerName}
+		}
+		return token
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx   context.Context
+		token *slinkyv1beta1.Token
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantAlg   string
+		wantKeyID string
+		wantErr   bool
+	}{
+		{
+			name: "jwtKeyRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(jwtKeySecret).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken(""),
+			},
+			wantAlg: "HS256",
+		},
+		{
+			name: "controllerRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(controller, jwtKeySecret).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantAlg: "HS256",
+		},
+		{
+			name: "controllerRef with jwtSigningKeyRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(jwksController, signingKeySecret, jwksConfigMap).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantAlg:   "ES256",
+			wantKeyID: "key1",
+		},
+		{
+			name: "controllerRef with jwtSigningKeyRef, not PEM",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(jwksController, sharedSecretSigningKeySecret, jwksConfigMap).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "controllerRef with jwtSigningKeyRef, JWKS not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(jwksController, signingKeySecret).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "controllerRef not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx:   context.TODO(),
+				token: newToken("slurm"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetTokenSigningKey(tt.args.ctx, tt.args.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefResolver.GetTokenSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Algorithm() != tt.wantAlg {
+				t.Errorf("RefResolver.GetTokenSigningKey() Algorithm = %v, want %v", got.Algorithm(), tt.wantAlg)
+			}
+			if got.KeyID() != tt.wantKeyID {
+				t.Errorf("RefResolver.GetTokenSigningKey() KeyID = %v, want %v", got.KeyID(), tt.wantKeyID)
+			}
+		})
+	}
+}
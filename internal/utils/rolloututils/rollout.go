@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package rolloututils
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// Rollout is the rollout state of a workload (e.g. StatefulSet, Deployment).
+type Rollout struct {
+	// Kind is the kind of the workload, used in condition messages.
+	Kind string
+
+	Generation         int64
+	ObservedGeneration int64
+
+	DesiredReplicas   int32
+	UpdatedReplicas   int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+}
+
+// FromStatefulSet returns the rollout of the StatefulSet, or nil if it is nil.
+func FromStatefulSet(sts *appsv1.StatefulSet) *Rollout {
+	if sts == nil {
+		return nil
+	}
+	return &Rollout{
+		Kind:               "StatefulSet",
+		Generation:         sts.Generation,
+		ObservedGeneration: sts.Status.ObservedGeneration,
+		DesiredReplicas:    ptr.Deref(sts.Spec.Replicas, 1),
+		UpdatedReplicas:    sts.Status.UpdatedReplicas,
+		ReadyReplicas:      sts.Status.ReadyReplicas,
+		AvailableReplicas:  sts.Status.AvailableReplicas,
+	}
+}
+
+// FromDeployment returns the rollout of the Deployment, or nil if it is nil.
+func FromDeployment(deployment *appsv1.Deployment) *Rollout {
+	if deployment == nil {
+		return nil
+	}
+	return &Rollout{
+		Kind:               "Deployment",
+		Generation:         deployment.Generation,
+		ObservedGeneration: deployment.Status.ObservedGeneration,
+		DesiredReplicas:    ptr.Deref(deployment.Spec.Replicas, 1),
+		UpdatedReplicas:    deployment.Status.UpdatedReplicas,
+		ReadyReplicas:      deployment.Status.ReadyReplicas,
+		AvailableReplicas:  deployment.Status.AvailableReplicas,
+	}
+}
+
+// IsComplete returns true if every replica has been updated to the latest
+// revision and is available.
+func (r *Rollout) IsComplete() bool {
+	return r.ObservedGeneration >= r.Generation &&
+		r.UpdatedReplicas >= r.DesiredReplicas &&
+		r.AvailableReplicas >= r.DesiredReplicas
+}
+
+// SetConditions sets the Ready, Progressing, and Degraded conditions from the
+// rollout. A nil rollout means that the workload has not been created yet.
+//
+//   - Ready is true when the rollout is complete.
+//   - Progressing is true while replicas are being updated.
+//   - Degraded is true while fewer replicas are available than desired, unless
+//     they are being updated.
+func SetConditions(conds *[]metav1.Condition, generation int64, rollout *Rollout) {
+	ready := metav1.Condition{
+		Type:               conditions.ConditionReady,
+		ObservedGeneration: generation,
+	}
+	progressing := metav1.Condition{
+		Type:               conditions.ConditionProgressing,
+		ObservedGeneration: generation,
+	}
+	degraded := metav1.Condition{
+		Type:               conditions.ConditionDegraded,
+		ObservedGeneration: generation,
+	}
+
+	switch {
+	case rollout == nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "NotCreated"
+		ready.Message = "The workload has not been created"
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "NotCreated"
+		progressing.Message = ready.Message
+		degraded.Status = metav1.ConditionFalse
+		degraded.Reason = "NotCreated"
+		degraded.Message = ready.Message
+	case rollout.IsComplete():
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "RolloutComplete"
+		ready.Message = fmt.Sprintf("%s has %d/%d replicas available",
+			rollout.Kind, rollout.AvailableReplicas, rollout.DesiredReplicas)
+		progressing.Status = metav1.ConditionFalse
+		progressing.Reason = "RolloutComplete"
+		progressing.Message = ready.Message
+		degraded.Status = metav1.ConditionFalse
+		degraded.Reason = "ReplicasAvailable"
+		degraded.Message = ready.Message
+	default:
+		updating := rollout.ObservedGeneration < rollout.Generation ||
+			rollout.UpdatedReplicas < rollout.DesiredReplicas
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "ReplicasUnavailable"
+		ready.Message = fmt.Sprintf("%s has %d/%d replicas available",
+			rollout.Kind, rollout.AvailableReplicas, rollout.DesiredReplicas)
+		if updating {
+			ready.Reason = "RollingOut"
+			progressing.Status = metav1.ConditionTrue
+			progressing.Reason = "RollingOut"
+			progressing.Message = fmt.Sprintf("%s has %d/%d replicas updated",
+				rollout.Kind, rollout.UpdatedReplicas, rollout.DesiredReplicas)
+			degraded.Status = metav1.ConditionFalse
+			degraded.Reason = "RollingOut"
+			degraded.Message = progressing.Message
+		} else {
+			progressing.Status = metav1.ConditionFalse
+			progressing.Reason = "RolloutComplete"
+			progressing.Message = fmt.Sprintf("%s has %d/%d replicas updated",
+				rollout.Kind, rollout.UpdatedReplicas, rollout.DesiredReplicas)
+			degraded.Status = metav1.ConditionTrue
+			degraded.Reason = "ReplicasUnavailable"
+			degraded.Message = ready.Message
+		}
+	}
+
+	meta.SetStatusCondition(conds, ready)
+	meta.SetStatusCondition(conds, progressing)
+	meta.SetStatusCondition(conds, degraded)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package rolloututils
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newStatefulSet(replicas int32, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(replicas),
+		},
+		Status: status,
+	}
+}
+
+func TestSetConditions(t *testing.T) {
+	type want struct {
+		ready       metav1.ConditionStatus
+		progressing metav1.ConditionStatus
+		degraded    metav1.ConditionStatus
+		reason      string
+	}
+	tests := []struct {
+		name    string
+		rollout *Rollout
+		want    want
+	}{
+		{
+			name:    "not created",
+			rollout: nil,
+			want: want{
+				ready:       metav1.ConditionFalse,
+				progressing: metav1.ConditionTrue,
+				degraded:    metav1.ConditionFalse,
+				reason:      "NotCreated",
+			},
+		},
+		{
+			name: "complete",
+			rollout: FromStatefulSet(newStatefulSet(2, appsv1.StatefulSetStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			})),
+			want: want{
+				ready:       metav1.ConditionTrue,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionFalse,
+				reason:      "RolloutComplete",
+			},
+		},
+		{
+			name: "generation not observed",
+			rollout: FromStatefulSet(newStatefulSet(2, appsv1.StatefulSetStatus{
+				ObservedGeneration: 1,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			})),
+			want: want{
+				ready:       metav1.ConditionFalse,
+				progressing: metav1.ConditionTrue,
+				degraded:    metav1.ConditionFalse,
+				reason:      "RollingOut",
+			},
+		},
+		{
+			name: "rolling out",
+			rollout: FromStatefulSet(newStatefulSet(2, appsv1.StatefulSetStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    1,
+				ReadyReplicas:      1,
+				AvailableReplicas:  1,
+			})),
+			want: want{
+				ready:       metav1.ConditionFalse,
+				progressing: metav1.ConditionTrue,
+				degraded:    metav1.ConditionFalse,
+				reason:      "RollingOut",
+			},
+		},
+		{
+			name: "replicas unavailable",
+			rollout: FromDeployment(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To[int32](3),
+				},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    3,
+					ReadyReplicas:      2,
+					AvailableReplicas:  2,
+				},
+			}),
+			want: want{
+				ready:       metav1.ConditionFalse,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionTrue,
+				reason:      "ReplicasUnavailable",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conds := []metav1.Condition{}
+			SetConditions(&conds, 1, tt.rollout)
+
+			ready := meta.FindStatusCondition(conds, conditions.ConditionReady)
+			progressing := meta.FindStatusCondition(conds, conditions.ConditionProgressing)
+			degraded := meta.FindStatusCondition(conds, conditions.ConditionDegraded)
+			if ready == nil || progressing == nil || degraded == nil {
+				t.Fatalf("SetConditions() = %v, want Ready, Progressing, and Degraded", conds)
+			}
+			if ready.Status != tt.want.ready {
+				t.Errorf("SetConditions() Ready = %v, want %v", ready.Status, tt.want.ready)
+			}
+			if ready.Reason != tt.want.reason {
+				t.Errorf("SetConditions() Ready reason = %v, want %v", ready.Reason, tt.want.reason)
+			}
+			if progressing.Status != tt.want.progressing {
+				t.Errorf("SetConditions() Progressing = %v, want %v", progressing.Status, tt.want.progressing)
+			}
+			if degraded.Status != tt.want.degraded {
+				t.Errorf("SetConditions() Degraded = %v, want %v", degraded.Status, tt.want.degraded)
+			}
+			if ready.ObservedGeneration != 1 {
+				t.Errorf("SetConditions() ObservedGeneration = %v, want %v", ready.ObservedGeneration, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,534 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmconf
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/set"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+// Parameter is a `slurm.conf` parameter.
+type Parameter struct {
+	Key   string
+	Value string
+	// Line is the line number of the parameter, starting at 1, when parsed.
+	Line int
+}
+
+func (p Parameter) String() string {
+	return fmt.Sprintf("%s=%s", p.Key, p.Value)
+}
+
+// FromSpec returns the `slurm.conf` parameters of the set SlurmConf fields.
+func FromSpec(spec slinkyv1beta1.SlurmConf) []Parameter {
+	params := []Parameter{}
+	addString := func(key, val string) {
+		if val != "" {
+			params = append(params, Parameter{Key: key, Value: val})
+		}
+	}
+	addList := func(key string, vals []string) {
+		if len(vals) > 0 {
+			params = append(params, Parameter{Key: key, Value: strings.Join(vals, ",")})
+		}
+	}
+	addInt32 := func(key string, val *int32) {
+		if val != nil {
+			params = append(params, Parameter{Key: key, Value: strconv.FormatInt(int64(*val), 10)})
+		}
+	}
+	addInt64 := func(key string, val *int64) {
+		if val != nil {
+			params = append(params, Parameter{Key: key, Value: strconv.FormatInt(*val, 10)})
+		}
+	}
+	addDuration := func(key string, val *metav1.Duration, unit time.Duration) {
+		if val != nil {
+			params = append(params, Parameter{Key: key, Value: strconv.FormatInt(int64(val.Duration/unit), 10)})
+		}
+	}
+
+	scheduling := spec.Scheduling
+	addString("SchedulerType", scheduling.SchedulerType)
+	addList("SchedulerParameters", scheduling.SchedulerParameters)
+	addString("PreemptType", scheduling.PreemptType)
+	addList("PreemptMode", scheduling.PreemptMode)
+	addInt32("MaxJobCount", scheduling.MaxJobCount)
+	addInt32("MaxArraySize", scheduling.MaxArraySize)
+	addInt32("ReturnToService", scheduling.ReturnToService)
+
+	priority := spec.Priority
+	addString("PriorityType", priority.PriorityType)
+	addDuration("PriorityDecayHalfLife", priority.DecayHalfLife, time.Minute)
+	addDuration("PriorityMaxAge", priority.MaxAge, time.Minute)
+	addInt32("PriorityWeightAge", priority.WeightAge)
+	addInt32("PriorityWeightAssoc", priority.WeightAssoc)
+	addInt32("PriorityWeightFairshare", priority.WeightFairshare)
+	addInt32("PriorityWeightJobSize", priority.WeightJobSize)
+	addInt32("PriorityWeightPartition", priority.WeightPartition)
+	addInt32("PriorityWeightQOS", priority.WeightQOS)
+	if len(priority.WeightTRES) > 0 {
+		tres := structutils.Keys(priority.WeightTRES)
+		slices.Sort(tres)
+		weights := make([]string, 0, len(tres))
+		for _, name := range tres {
+			weights = append(weights, fmt.Sprintf("%s=%d", name, priority.WeightTRES[name]))
+		}
+		addList("PriorityWeightTRES", weights)
+	}
+
+	selectConf := spec.Select
+	addString("SelectType", selectConf.SelectType)
+	addList("SelectTypeParameters", selectConf.SelectTypeParameters)
+	addInt64("DefMemPerCPU", selectConf.DefMemPerCPU)
+	addInt64("MaxMemPerCPU", selectConf.MaxMemPerCPU)
+	addInt64("DefMemPerGPU", selectConf.DefMemPerGPU)
+	addInt32("DefCpuPerGPU", selectConf.DefCpuPerGPU)
+
+	timeouts := spec.Timeouts
+	addDuration("SlurmctldTimeout", timeouts.SlurmctldTimeout, time.Second)
+	addDuration("SlurmdTimeout", timeouts.SlurmdTimeout, time.Second)
+	addDuration("MessageTimeout", timeouts.MessageTimeout, time.Second)
+	addDuration("InactiveLimit", timeouts.InactiveLimit, time.Second)
+	addDuration("KillWait", timeouts.KillWait, time.Second)
+	addDuration("MinJobAge", timeouts.MinJobAge, time.Second)
+	addDuration("WaitTime", timeouts.WaitTime, time.Second)
+	addDuration("UnkillableStepTimeout", timeouts.UnkillableStepTimeout, time.Second)
+
+	return params
+}
+
+// Parse returns the parameters of a `slurm.conf` snippet, in order.
+// Lines which define an entity (e.g. NodeName, PartitionName) are returned as
+// a single parameter of the entity key, and `Include` lines are skipped.
+func Parse(conf string) []Parameter {
+	params := []Parameter{}
+	for i, line := range strings.Split(conf, "\n") {
+		fields := splitFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.EqualFold(fields[0], "Include") {
+			continue
+		}
+		for _, field := range fields {
+			key, val, _ := strings.Cut(field, "=")
+			params = append(params, Parameter{Key: key, Value: val, Line: i + 1})
+			if IsEntityKey(key) {
+				break
+			}
+		}
+	}
+	return params
+}
+
+// Validate returns the problems of a `slurm.conf` which would prevent
+// slurmctld from starting: lines which are not key=value parameters, entities
+// which are defined more than once, and partitions whose nodes are not a
+// NodeSet or node defined in the config. Unknown parameters are returned as
+// warnings instead, as Slurm may know parameters which are not known here.
+// Parameters which are set more than once are not reported, as Slurm uses the
+// last value (e.g. extraConf overriding a parameter set by the operator).
+//
+// The entities (e.g. NodeSet=cpu) are defined apart from the config, by the
+// operator, so the config may reference them but not define them again.
+func Validate(conf string, entities ...Parameter) ([]string, []error) {
+	warns := []string{}
+	errs := []error{}
+	type entity struct {
+		key  string
+		name string
+	}
+	type partitionNodes struct {
+		partition string
+		nodes     string
+		line      int
+	}
+	defined := map[entity]int{}
+	nodeNames := set.New[string]()
+	partitions := []partitionNodes{}
+	hasHostlist := false
+	for _, param := range entities {
+		switch strings.ToLower(param.Key) {
+		case "nodeset":
+			nodeNames.Insert(param.Value)
+		case "partitionname":
+		default:
+			continue
+		}
+		defined[entity{key: strings.ToLower(param.Key), name: param.Value}] = 0
+	}
+	for i, line := range strings.Split(conf, "\n") {
+		lineNum := i + 1
+		fields := splitFields(line)
+		if len(fields) == 0 || strings.EqualFold(fields[0], "Include") {
+			continue
+		}
+		entityKey, _, _ := strings.Cut(fields[0], "=")
+		if !IsEntityKey(entityKey) {
+			entityKey = ""
+		}
+		for _, field := range fields {
+			key, val, ok := strings.Cut(field, "=")
+			if !ok || key == "" {
+				errs = append(errs, fmt.Errorf("line %d: %q is not a key=value parameter", lineNum, field))
+				continue
+			}
+			if entityKey == "" && !IsKnownKey(key) {
+				warns = append(warns, fmt.Sprintf("line %d: unknown parameter %q", lineNum, key))
+			}
+			if strings.EqualFold(entityKey, "PartitionName") && strings.EqualFold(key, "Nodes") {
+				partitions = append(partitions, partitionNodes{partition: fields[0], nodes: val, line: lineNum})
+			}
+		}
+		if entityKey == "" {
+			continue
+		}
+
+		_, name, _ := strings.Cut(fields[0], "=")
+		if name == "" {
+			errs = append(errs, fmt.Errorf("line %d: %s has no name", lineNum, entityKey))
+			continue
+		}
+		switch strings.ToLower(entityKey) {
+		case "nodename":
+			if strings.Contains(name, "[") {
+				hasHostlist = true
+			}
+			nodeNames.Insert(strings.Split(name, ",")...)
+		case "nodeset":
+			nodeNames.Insert(name)
+		case "partitionname":
+		default:
+			continue
+		}
+		if strings.EqualFold(name, "DEFAULT") {
+			continue
+		}
+		key := entity{key: strings.ToLower(entityKey), name: name}
+		if prev, ok := defined[key]; ok {
+			if prev == 0 {
+				errs = append(errs, fmt.Errorf("line %d: %s=%s is already defined by the operator", lineNum, entityKey, name))
+			} else {
+				errs = append(errs, fmt.Errorf("line %d: %s=%s is already defined on line %d", lineNum, entityKey, name, prev))
+			}
+			continue
+		}
+		defined[key] = lineNum
+	}
+
+	for _, partition := range partitions {
+		for node := range strings.SplitSeq(partition.nodes, ",") {
+			switch {
+			case node == "", strings.EqualFold(node, "ALL"), nodeNames.Has(node):
+			case hasHostlist, strings.Contains(node, "["):
+				// Hostlist expressions are resolved by Slurm.
+			default:
+				errs = append(errs, fmt.Errorf("line %d: %s references undefined NodeSet or node %q",
+					partition.line, partition.partition, node))
+			}
+		}
+	}
+
+	return warns, errs
+}
+
+// splitFields splits a `slurm.conf` line around whitespace, except within
+// double quotes, up to the first unquoted comment.
+func splitFields(line string) []string {
+	fields := []string{}
+	var field strings.Builder
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '#' && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+			}
+			return fields
+		case unicode.IsSpace(r) && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			if r == '"' {
+				quoted = !quoted
+			}
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// IsKnownKey reports if the key is a known `slurm.conf` parameter, ignoring case.
+func IsKnownKey(key string) bool {
+	return knownKeys.Has(strings.ToLower(key))
+}
+
+// IsEntityKey reports if the key begins a `slurm.conf` entity line, whose
+// other parameters configure the entity, ignoring case.
+func IsEntityKey(key string) bool {
+	return entityKeys.Has(strings.ToLower(key))
+}
+
+func lowerSet(keys ...string) set.Set[string] {
+	out := set.New[string]()
+	for _, key := range keys {
+		out.Insert(strings.ToLower(key))
+	}
+	return out
+}
+
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+var entityKeys = lowerSet(
+	"DownNodes",
+	"FrontendName",
+	"NodeName",
+	"NodeSet",
+	"PartitionName",
+)
+
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARAMETERS
+var knownKeys = entityKeys.Union(lowerSet(
+	"AccountingStorageBackupHost",
+	"AccountingStorageEnforce",
+	"AccountingStorageExternalHost",
+	"AccountingStorageHost",
+	"AccountingStorageParameters",
+	"AccountingStoragePass",
+	"AccountingStoragePort",
+	"AccountingStorageTRES",
+	"AccountingStorageType",
+	"AccountingStorageUser",
+	"AccountingStoreFlags",
+	"AcctGatherEnergyType",
+	"AcctGatherFilesystemType",
+	"AcctGatherInterconnectType",
+	"AcctGatherNodeFreq",
+	"AcctGatherProfileType",
+	"AllowSpecResourcesUsage",
+	"AuthAltParameters",
+	"AuthAltTypes",
+	"AuthInfo",
+	"AuthType",
+	"BatchStartTimeout",
+	"BcastExclude",
+	"BcastParameters",
+	"BurstBufferType",
+	"CertgenParameters",
+	"CertgenType",
+	"CertmgrParameters",
+	"CertmgrType",
+	"CliFilterParameters",
+	"CliFilterPlugins",
+	"ClusterName",
+	"CommunicationParameters",
+	"CompleteWait",
+	"CoreSpecPlugin",
+	"CpuFreqDef",
+	"CpuFreqGovernors",
+	"CredType",
+	"DataParserParameters",
+	"DebugFlags",
+	"DefCpuPerGPU",
+	"DefMemPerCPU",
+	"DefMemPerGPU",
+	"DefMemPerNode",
+	"DependencyParameters",
+	"DisableRootJobs",
+	"EioTimeout",
+	"EnforcePartLimits",
+	"Epilog",
+	"EpilogMsgTime",
+	"EpilogSlurmctld",
+	"EpilogTimeout",
+	"ExtSensorsFreq",
+	"ExtSensorsType",
+	"FairShareDampeningFactor",
+	"FederationParameters",
+	"FirstJobId",
+	"GetEnvTimeout",
+	"GpuFreqDef",
+	"GresTypes",
+	"GroupUpdateForce",
+	"GroupUpdateTime",
+	"HashPlugin",
+	"HealthCheckInterval",
+	"HealthCheckNodeState",
+	"HealthCheckProgram",
+	"HttpParserType",
+	"InactiveLimit",
+	"InteractiveStepOptions",
+	"JobAcctGatherFrequency",
+	"JobAcctGatherParams",
+	"JobAcctGatherType",
+	"JobCompHost",
+	"JobCompLoc",
+	"JobCompParams",
+	"JobCompPass",
+	"JobCompPort",
+	"JobCompType",
+	"JobCompUser",
+	"JobContainerType",
+	"JobCredentialPrivateKey",
+	"JobCredentialPublicCertificate",
+	"JobDefaults",
+	"JobFileAppend",
+	"JobRequeue",
+	"JobSubmitPlugins",
+	"KeepAliveTime",
+	"KillOnBadExit",
+	"KillWait",
+	"LaunchParameters",
+	"LaunchType",
+	"Licenses",
+	"LogTimeFormat",
+	"MailDomain",
+	"MailProg",
+	"MaxArraySize",
+	"MaxBatchRequeue",
+	"MaxDBDMsgs",
+	"MaxJobCount",
+	"MaxJobId",
+	"MaxMemPerCPU",
+	"MaxMemPerNode",
+	"MaxNodeCount",
+	"MaxStepCount",
+	"MaxTasksPerNode",
+	"MCSParameters",
+	"MCSPlugin",
+	"MessageTimeout",
+	"MetricsType",
+	"MinJobAge",
+	"MpiDefault",
+	"MpiParams",
+	"NamespaceType",
+	"NodeFeaturesPlugins",
+	"OverTimeLimit",
+	"PluginDir",
+	"PlugStackConfig",
+	"PowerParameters",
+	"PowerPlugin",
+	"PreemptExemptTime",
+	"PreemptMode",
+	"PreemptParameters",
+	"PreemptType",
+	"PrEpParameters",
+	"PrEpPlugins",
+	"PriorityCalcPeriod",
+	"PriorityDecayHalfLife",
+	"PriorityFavorSmall",
+	"PriorityFlags",
+	"PriorityMaxAge",
+	"PriorityParameters",
+	"PrioritySiteFactorParameters",
+	"PrioritySiteFactorPlugin",
+	"PriorityType",
+	"PriorityUsageResetPeriod",
+	"PriorityWeightAge",
+	"PriorityWeightAssoc",
+	"PriorityWeightFairshare",
+	"PriorityWeightJobSize",
+	"PriorityWeightPartition",
+	"PriorityWeightQOS",
+	"PriorityWeightTRES",
+	"PrivateData",
+	"ProctrackType",
+	"Prolog",
+	"PrologEpilogTimeout",
+	"PrologFlags",
+	"PrologSlurmctld",
+	"PrologTimeout",
+	"PropagatePrioProcess",
+	"PropagateResourceLimits",
+	"PropagateResourceLimitsExcept",
+	"RebootProgram",
+	"ReconfigFlags",
+	"RequeueExit",
+	"RequeueExitHold",
+	"ResumeFailProgram",
+	"ResumeProgram",
+	"ResumeRate",
+	"ResumeTimeout",
+	"ResvEpilog",
+	"ResvOverRun",
+	"ResvProlog",
+	"ReturnToService",
+	"RoutePlugin",
+	"SchedulerParameters",
+	"SchedulerTimeSlice",
+	"SchedulerType",
+	"ScronParameters",
+	"SelectType",
+	"SelectTypeParameters",
+	"SlurmctldAddr",
+	"SlurmctldDebug",
+	"SlurmctldHost",
+	"SlurmctldLogFile",
+	"SlurmctldParameters",
+	"SlurmctldPidFile",
+	"SlurmctldPort",
+	"SlurmctldPrimaryOffProg",
+	"SlurmctldPrimaryOnProg",
+	"SlurmctldSyslogDebug",
+	"SlurmctldTimeout",
+	"SlurmdDebug",
+	"SlurmdLogFile",
+	"SlurmdParameters",
+	"SlurmdPidFile",
+	"SlurmdPort",
+	"SlurmdSpoolDir",
+	"SlurmdSyslogDebug",
+	"SlurmdTimeout",
+	"SlurmdUser",
+	"SlurmSchedLogFile",
+	"SlurmSchedLogLevel",
+	"SlurmUser",
+	"SrunEpilog",
+	"SrunPortRange",
+	"SrunProlog",
+	"StateSaveLocation",
+	"SuspendExcNodes",
+	"SuspendExcParts",
+	"SuspendExcStates",
+	"SuspendProgram",
+	"SuspendRate",
+	"SuspendTime",
+	"SuspendTimeout",
+	"SwitchParameters",
+	"SwitchType",
+	"TaskEpilog",
+	"TaskPlugin",
+	"TaskPluginParam",
+	"TaskProlog",
+	"TCPTimeout",
+	"TLSParameters",
+	"TLSType",
+	"TmpFS",
+	"TopologyParam",
+	"TopologyPlugin",
+	"TrackWCKey",
+	"TreeWidth",
+	"UnkillableStepProgram",
+	"UnkillableStepTimeout",
+	"UrlParserType",
+	"UsePAM",
+	"VSizeFactor",
+	"WaitTime",
+	"X11Parameters",
+))
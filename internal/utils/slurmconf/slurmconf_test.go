@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestFromSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec slinkyv1beta1.SlurmConf
+		want []Parameter
+	}{
+		{
+			name: "empty",
+			spec: slinkyv1beta1.SlurmConf{},
+			want: []Parameter{},
+		},
+		{
+			name: "all groups",
+			spec: slinkyv1beta1.SlurmConf{
+				Scheduling: slinkyv1beta1.SlurmConfScheduling{
+					SchedulerType:       "sched/backfill",
+					SchedulerParameters: []string{"bf_continue", "bf_window=4320"},
+					ReturnToService:     ptr.To(int32(2)),
+				},
+				Priority: slinkyv1beta1.SlurmConfPriority{
+					PriorityType:  "priority/multifactor",
+					DecayHalfLife: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+					WeightTRES: map[string]int32{
+						"gres/gpu": 3000,
+						"cpu":      1000,
+					},
+				},
+				Select: slinkyv1beta1.SlurmConfSelect{
+					SelectType:           "select/cons_tres",
+					SelectTypeParameters: []string{"CR_Core_Memory"},
+					DefMemPerCPU:         ptr.To(int64(2048)),
+				},
+				Timeouts: slinkyv1beta1.SlurmConfTimeouts{
+					SlurmdTimeout: &metav1.Duration{Duration: 5*time.Minute + 500*time.Millisecond},
+				},
+			},
+			want: []Parameter{
+				{Key: "SchedulerType", Value: "sched/backfill"},
+				{Key: "SchedulerParameters", Value: "bf_continue,bf_window=4320"},
+				{Key: "ReturnToService", Value: "2"},
+				{Key: "PriorityType", Value: "priority/multifactor"},
+				{Key: "PriorityDecayHalfLife", Value: "10080"},
+				{Key: "PriorityWeightTRES", Value: "cpu=1000,gres/gpu=3000"},
+				{Key: "SelectType", Value: "select/cons_tres"},
+				{Key: "SelectTypeParameters", Value: "CR_Core_Memory"},
+				{Key: "DefMemPerCPU", Value: "2048"},
+				{Key: "SlurmdTimeout", Value: "300"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromSpec(tt.spec)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FromSpec() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		want []Parameter
+	}{
+		{
+			name: "empty",
+			conf: "",
+			want: []Parameter{},
+		},
+		{
+			name: "parameters, entities, comments",
+			conf: `# comment
+SchedulerType=sched/backfill # trailing
+Include /etc/slurm/extra.conf
+
+NodeName=foo CPUs=4 Features=bar
+PartitionName=all Nodes=ALL Default=YES
+MinJobAge=2 KillWait=30`,
+			want: []Parameter{
+				{Key: "SchedulerType", Value: "sched/backfill", Line: 2},
+				{Key: "NodeName", Value: "foo", Line: 5},
+				{Key: "PartitionName", Value: "all", Line: 6},
+				{Key: "MinJobAge", Value: "2", Line: 7},
+				{Key: "KillWait", Value: "30", Line: 7},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.conf)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsKnownKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "SchedulerType", want: true},
+		{key: "schedulertype", want: true},
+		{key: "PartitionName", want: true},
+		{key: "SchedulerTyp", want: false},
+		{key: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsKnownKey(tt.key); got != tt.want {
+				t.Errorf("IsKnownKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		conf      string
+		entities  []Parameter
+		wantWarns []string
+		want      []string
+	}{
+		{
+			name: "empty",
+			conf: "",
+			want: []string{},
+		},
+		{
+			name: "valid",
+			conf: `# comment
+ClusterName=slurm
+Include /etc/slurm/extra.conf
+SlurmctldParameters=enable_configless
+SlurmctldParameters=enable_configless,idle_on_node_suspend
+NodeSet=cpu Feature=cpu
+NodeName=foo CPUs=4
+NodeName=bar[1-4] CPUs=8
+DownNodes=foo Reason="under maintenance" State=DOWN
+PartitionName=DEFAULT MaxTime=60
+PartitionName=cpu Nodes=cpu,foo Default=YES
+PartitionName=all Nodes=ALL`,
+			want: []string{},
+		},
+		{
+			name: "malformed and unknown",
+			conf: `ClusterName=slurm
+SchedulerTyp=sched/backfill
+MinJobAge
+=30`,
+			wantWarns: []string{
+				`line 2: unknown parameter "SchedulerTyp"`,
+			},
+			want: []string{
+				`line 3: "MinJobAge" is not a key=value parameter`,
+				`line 4: "=30" is not a key=value parameter`,
+			},
+		},
+		{
+			name: "duplicate entities",
+			conf: `NodeSet=cpu Feature=cpu
+NodeSet=cpu Feature=gpu
+PartitionName=DEFAULT MaxTime=60
+PartitionName=DEFAULT MaxTime=30
+PartitionName=cpu Nodes=cpu
+PartitionName=cpu Nodes=cpu
+PartitionName=`,
+			want: []string{
+				`line 2: NodeSet=cpu is already defined on line 1`,
+				`line 6: PartitionName=cpu is already defined on line 5`,
+				`line 7: PartitionName has no name`,
+			},
+		},
+		{
+			name: "undefined partition nodes",
+			conf: `NodeSet=cpu Feature=cpu
+PartitionName=cpu Nodes=cpu,gpu
+PartitionName=hosts Nodes=gpu[1-2]`,
+			want: []string{
+				`line 2: PartitionName=cpu references undefined NodeSet or node "gpu"`,
+			},
+		},
+		{
+			name: "entities",
+			conf: `PartitionName=all Nodes=cpu,gpu
+NodeSet=cpu Feature=cpu
+PartitionName=gpu Nodes=gpu`,
+			entities: []Parameter{
+				{Key: "NodeSet", Value: "cpu"},
+				{Key: "NodeSet", Value: "gpu"},
+				{Key: "PartitionName", Value: "gpu"},
+			},
+			want: []string{
+				`line 2: NodeSet=cpu is already defined by the operator`,
+				`line 3: PartitionName=gpu is already defined by the operator`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := Validate(tt.conf, tt.entities...)
+			if diff := cmp.Diff(tt.wantWarns, warns, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Validate() warnings (-want,+got):\n%s", diff)
+			}
+			got := []string{}
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
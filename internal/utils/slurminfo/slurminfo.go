@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurminfo
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+// ControllerPing is the result of slurmrestd pinging a slurmctld.
+type ControllerPing struct {
+	Hostname   string
+	Pinged     string
+	Responding bool
+	Primary    bool
+	Mode       string
+}
+
+// PingControllers pings each slurmctld (e.g. primary, backup) through slurmrestd.
+func PingControllers(ctx context.Context, slurmClient slurmclient.Client) ([]ControllerPing, error) {
+	pingList := &slurmtypes.V0044ControllerPingList{}
+	if err := slurmClient.List(ctx, pingList); err != nil {
+		return nil, err
+	}
+
+	pings := make([]ControllerPing, 0, len(pingList.Items))
+	for _, item := range pingList.Items {
+		pings = append(pings, ControllerPing{
+			Hostname:   ptr.Deref(item.Hostname, ""),
+			Pinged:     ptr.Deref(item.Pinged, ""),
+			Responding: item.Responding,
+			Primary:    item.Primary,
+			Mode:       ptr.Deref(item.Mode, ""),
+		})
+	}
+	return pings, nil
+}
+
+// IsResponding returns true if any slurmctld is responding.
+func IsResponding(pings []ControllerPing) bool {
+	return slices.ContainsFunc(pings, func(ping ControllerPing) bool {
+		return ping.Responding
+	})
+}
+
+// GetPrimary returns the hostname of the slurmctld which is in control, or
+// empty if none is responding. A backup only takes control while every
+// slurmctld before it is down, so it is the first responding slurmctld in
+// failover order: the primary, then each backup by its mode (e.g. backup1).
+func GetPrimary(pings []ControllerPing) string {
+	var primary string
+	rank := -1
+	for _, ping := range pings {
+		if !ping.Responding {
+			continue
+		}
+		if r := failoverRank(ping); rank < 0 || r < rank {
+			primary = ping.Hostname
+			rank = r
+		}
+	}
+	return primary
+}
+
+// failoverRank returns the position of the slurmctld in SlurmctldHost order.
+func failoverRank(ping ControllerPing) int {
+	if ping.Primary || ping.Mode == "primary" {
+		return 0
+	}
+	if suffix, ok := strings.CutPrefix(ping.Mode, "backup"); ok {
+		if suffix == "" {
+			return 1
+		}
+		if n, err := strconv.Atoi(suffix); err == nil && n > 0 {
+			return n
+		}
+	}
+	return math.MaxInt
+}
+
+// GetVersions returns the sorted Slurm versions which slurmctld reports for
+// its nodes. There is more than one while the cluster is being upgraded.
+func GetVersions(ctx context.Context, slurmClient slurmclient.Client) ([]string, error) {
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		return nil, err
+	}
+
+	versions := set.New[string]()
+	for _, item := range nodeList.Items {
+		if version := ptr.Deref(item.Version, ""); version != "" {
+			versions.Insert(version)
+		}
+	}
+	return versions.SortedList(), nil
+}
+
+// slurmdbdProbeAccount always exists in slurmdbd.
+const slurmdbdProbeAccount = "root"
+
+// PingSlurmdbd returns an error if slurmdbd cannot be reached through slurmrestd.
+func PingSlurmdbd(ctx context.Context, slurmClient slurmclient.Client) error {
+	account := &slurmtypes.V0044Account{}
+	return slurmClient.Get(ctx, slurmobject.ObjectKey(slurmdbdProbeAccount), account)
+}
@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurminfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+func newListFailureClient() slurmclient.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, list slurmobject.ObjectList, opts ...slurmclient.ListOption) error {
+			return errors.New(http.StatusText(http.StatusInternalServerError))
+		},
+	}).Build()
+}
+
+func TestPingControllers(t *testing.T) {
+	tests := []struct {
+		name        string
+		slurmClient slurmclient.Client
+		want        []ControllerPing
+		wantErr     bool
+	}{
+		{
+			name: "primary and backup",
+			slurmClient: fake.NewClientBuilder().WithLists(&slurmtypes.V0044ControllerPingList{
+				Items: []slurmtypes.V0044ControllerPing{
+					{V0044ControllerPing: slurmapi.V0044ControllerPing{
+						Hostname:   ptr.To("slurm-controller-0"),
+						Pinged:     ptr.To("DOWN"),
+						Responding: false,
+						Primary:    true,
+						Mode:       ptr.To("primary"),
+					}},
+					{V0044ControllerPing: slurmapi.V0044ControllerPing{
+						Hostname:   ptr.To("slurm-controller-1"),
+						Pinged:     ptr.To("UP"),
+						Responding: true,
+						Mode:       ptr.To("backup1"),
+					}},
+				},
+			}).Build(),
+			want: []ControllerPing{
+				{Hostname: "slurm-controller-0", Pinged: "DOWN", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Pinged: "UP", Responding: true, Mode: "backup1"},
+			},
+		},
+		{
+			name: "missing fields",
+			slurmClient: fake.NewClientBuilder().WithLists(&slurmtypes.V0044ControllerPingList{
+				Items: []slurmtypes.V0044ControllerPing{
+					{V0044ControllerPing: slurmapi.V0044ControllerPing{Responding: true}},
+				},
+			}).Build(),
+			want: []ControllerPing{
+				{Responding: true},
+			},
+		},
+		{
+			name:        "no pings",
+			slurmClient: fake.NewClientBuilder().Build(),
+			want:        []ControllerPing{},
+		},
+		{
+			name:        "list failure",
+			slurmClient: newListFailureClient(),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PingControllers(context.TODO(), tt.slurmClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PingControllers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PingControllers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVersions(t *testing.T) {
+	newNode := func(name string, version *string) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{Name: ptr.To(name), Version: version}}
+	}
+	tests := []struct {
+		name        string
+		slurmClient slurmclient.Client
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name: "one version",
+			slurmClient: fake.NewClientBuilder().WithLists(&slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					newNode("slinky-0", ptr.To("25.05.3")),
+					newNode("slinky-1", ptr.To("25.05.3")),
+				},
+			}).Build(),
+			want: []string{"25.05.3"},
+		},
+		{
+			name: "upgrading",
+			slurmClient: fake.NewClientBuilder().WithLists(&slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					newNode("slinky-0", ptr.To("25.11.0")),
+					newNode("slinky-1", ptr.To("25.05.3")),
+					newNode("slinky-2", nil),
+					newNode("slinky-3", ptr.To("")),
+				},
+			}).Build(),
+			want: []string{"25.05.3", "25.11.0"},
+		},
+		{
+			name:        "no nodes",
+			slurmClient: fake.NewClientBuilder().Build(),
+			want:        []string{},
+		},
+		{
+			name:        "list failure",
+			slurmClient: newListFailureClient(),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetVersions(context.TODO(), tt.slurmClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPingSlurmdbd(t *testing.T) {
+	tests := []struct {
+		name        string
+		slurmClient slurmclient.Client
+		wantErr     bool
+	}{
+		{
+			name: "reachable",
+			slurmClient: fake.NewClientBuilder().WithObjects(&slurmtypes.V0044Account{
+				V0044Account: slurmapi.V0044Account{Name: slurmdbdProbeAccount},
+			}).Build(),
+		},
+		{
+			name:        "unreachable",
+			slurmClient: fake.NewClientBuilder().Build(),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := PingSlurmdbd(context.TODO(), tt.slurmClient); (err != nil) != tt.wantErr {
+				t.Errorf("PingSlurmdbd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsResponding(t *testing.T) {
+	tests := []struct {
+		name  string
+		pings []ControllerPing
+		want  bool
+	}{
+		{
+			name:  "empty",
+			pings: nil,
+			want:  false,
+		},
+		{
+			name: "primary responding",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: true, Primary: true},
+				{Hostname: "slurm-controller-1", Responding: false},
+			},
+			want: true,
+		},
+		{
+			name: "none responding",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsResponding(tt.pings); got != tt.want {
+				t.Errorf("IsResponding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPrimary(t *testing.T) {
+	tests := []struct {
+		name  string
+		pings []ControllerPing
+		want  string
+	}{
+		{
+			name:  "empty",
+			pings: nil,
+			want:  "",
+		},
+		{
+			name: "primary in control",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-1", Responding: true, Mode: "backup1"},
+				{Hostname: "slurm-controller-0", Responding: true, Primary: true, Mode: "primary"},
+			},
+			want: "slurm-controller-0",
+		},
+		{
+			name: "backup took over",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Responding: true, Mode: "backup1"},
+			},
+			want: "slurm-controller-1",
+		},
+		{
+			name: "second backup took over",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-2", Responding: true, Mode: "backup2"},
+				{Hostname: "slurm-controller-1", Responding: false, Mode: "backup1"},
+			},
+			want: "slurm-controller-2",
+		},
+		{
+			name: "none responding",
+			pings: []ControllerPing{
+				{Hostname: "slurm-controller-0", Responding: false, Primary: true, Mode: "primary"},
+				{Hostname: "slurm-controller-1", Responding: false, Mode: "backup1"},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPrimary(tt.pings); got != tt.want {
+				t.Errorf("GetPrimary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is a key which signs JWTs, by its signing method.
+type SigningKey struct {
+	method jwt.SigningMethod
+	key    any
+	keyID  string
+}
+
+// NewHS256Key returns the HS256 signing key of the shared secret.
+func NewHS256Key(key []byte) *SigningKey {
+	return &SigningKey{
+		method: jwt.SigningMethodHS256,
+		key:    key,
+	}
+}
+
+// ParseSigningKey returns the signing key of the PEM private key data, which
+// signs RS256 (RSA) or ES256 (ECDSA P-256) JWTs, with the `kid` of its public
+// key in the JWKS, by which Slurm verifies them. Use NewHS256Key for the HS256
+// shared secret.
+//
+// Ref: https://slurm.schedmd.com/jwt.html
+func ParseSigningKey(data, jwks []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode private key, must be PEM encoded RSA or ECDSA P-256")
+	}
+
+	out := &SigningKey{}
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		out.method = jwt.SigningMethodRS256
+		out.key = key
+	} else if key, err := jwt.ParseECPrivateKeyFromPEM(data); err == nil {
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s, must be P-256", key.Curve.Params().Name)
+		}
+		out.method = jwt.SigningMethodES256
+		out.key = key
+	} else {
+		return nil, fmt.Errorf("failed to parse private key, must be RSA or ECDSA: %w", err)
+	}
+
+	keyID, err := findKeyID(jwks, out.publicKey())
+	if err != nil {
+		return nil, err
+	}
+	out.keyID = keyID
+
+	return out, nil
+}
+
+// Algorithm returns the `alg` of the signing method (e.g. "RS256").
+func (k *SigningKey) Algorithm() string {
+	return k.method.Alg()
+}
+
+// KeyID returns the `kid` of the key, if it is asymmetric.
+func (k *SigningKey) KeyID() string {
+	return k.keyID
+}
+
+// keyFunc returns the key which verifies the JWTs. A JWT whose `kid` is not
+// the one of the key is invalid, as Slurm would not find the key to verify it.
+func (k *SigningKey) keyFunc(token *jwt.Token) (any, error) {
+	if kid, _ := token.Header["kid"].(string); kid != k.keyID {
+		return nil, fmt.Errorf("%w: kid %q is not %q", jwt.ErrTokenSignatureInvalid, kid, k.keyID)
+	}
+	return k.publicKey(), nil
+}
+
+// publicKey returns the key which verifies the JWTs.
+func (k *SigningKey) publicKey() crypto.PublicKey {
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		return &key.PublicKey
+	case *ecdsa.PrivateKey:
+		return &key.PublicKey
+	default:
+		return k.key
+	}
+}
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc7517
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc7518#section-6
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// findKeyID returns the `kid` of the public key in the JWKS.
+func findKeyID(jwks []byte, publicKey crypto.PublicKey) (string, error) {
+	if len(jwks) == 0 {
+		return "", errors.New("failed to find the public key, there is no JWKS")
+	}
+
+	keySet := jsonWebKeySet{}
+	if err := json.Unmarshal(jwks, &keySet); err != nil {
+		return "", fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	type equaler interface {
+		Equal(crypto.PublicKey) bool
+	}
+	for _, jwk := range keySet.Keys {
+		key, err := jwk.publicKey()
+		if err != nil || key == nil || jwk.Kid == "" {
+			continue
+		}
+		if pub, ok := publicKey.(equaler); ok && pub.Equal(key) {
+			return jwk.Kid, nil
+		}
+	}
+
+	return "", errors.New("failed to find the public key in the JWKS")
+}
+
+// publicKey returns the RSA or ECDSA P-256 public key of the JWK, or nil if it
+// is of another type.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		point := append([]byte{0x04}, append(x, y...)...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+)
+
+func newRsaKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return key, data
+}
+
+func newEcdsaKey(t *testing.T, curve elliptic.Curve) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	})
+	return key, data
+}
+
+func newJwks(t *testing.T, keys map[string]any) []byte {
+	encode := base64.RawURLEncoding.EncodeToString
+	keySet := jsonWebKeySet{}
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			keySet.Keys = append(keySet.Keys, jsonWebKey{
+				Kty: "RSA",
+				Kid: kid,
+				N:   encode(key.N.Bytes()),
+				E:   encode(big.NewInt(int64(key.E)).Bytes()),
+			})
+		case *ecdsa.PrivateKey:
+			point, err := key.PublicKey.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			keySet.Keys = append(keySet.Keys, jsonWebKey{
+				Kty: "EC",
+				Kid: kid,
+				Crv: "P-256",
+				X:   encode(point[1:33]),
+				Y:   encode(point[33:]),
+			})
+		}
+	}
+	data, err := json.Marshal(keySet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaKey, rsaData := newRsaKey(t)
+	ecdsaKey, ecdsaData := newEcdsaKey(t, elliptic.P256())
+	otherKey, otherData := newRsaKey(t)
+	_, p384Data := newEcdsaKey(t, elliptic.P384())
+	hs256Key := crypto.NewSigningKey()
+	jwks := newJwks(t, map[string]any{
+		"rsa":   rsaKey,
+		"ecdsa": ecdsaKey,
+	})
+	type args struct {
+		data []byte
+		jwks []byte
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantAlg   string
+		wantKeyID string
+		wantErr   bool
+	}{
+		{
+			name: "Not PEM",
+			args: args{
+				data: hs256Key,
+				jwks: jwks,
+			},
+			wantErr: true,
+		},
+		{
+			name: "RS256",
+			args: args{
+				data: rsaData,
+				jwks: jwks,
+			},
+			wantAlg:   "RS256",
+			wantKeyID: "rsa",
+		},
+		{
+			name: "ES256",
+			args: args{
+				data: ecdsaData,
+				jwks: jwks,
+			},
+			wantAlg:   "ES256",
+			wantKeyID: "ecdsa",
+		},
+		{
+			name: "Not in JWKS",
+			args: args{
+				data: otherData,
+				jwks: jwks,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Other key in JWKS",
+			args: args{
+				data: otherData,
+				jwks: newJwks(t, map[string]any{"other": otherKey}),
+			},
+			wantAlg:   "RS256",
+			wantKeyID: "other",
+		},
+		{
+			name: "No JWKS",
+			args: args{
+				data: rsaData,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported curve",
+			args: args{
+				data: p384Data,
+				jwks: jwks,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid PEM",
+			args: args{
+				data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("foo")}),
+				jwks: jwks,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSigningKey(tt.args.data, tt.args.jwks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Algorithm() != tt.wantAlg {
+				t.Errorf("ParseSigningKey() Algorithm = %v, want %v", got.Algorithm(), tt.wantAlg)
+			}
+			if got.KeyID() != tt.wantKeyID {
+				t.Errorf("ParseSigningKey() KeyID = %v, want %v", got.KeyID(), tt.wantKeyID)
+			}
+		})
+	}
+}
+
+func TestToken_NewSignedToken_WithKey(t *testing.T) {
+	rsaKey, rsaData := newRsaKey(t)
+	ecdsaKey, ecdsaData := newEcdsaKey(t, elliptic.P256())
+	jwks := newJwks(t, map[string]any{
+		"rsa":   rsaKey,
+		"ecdsa": ecdsaKey,
+	})
+	rsaSigningKey, err := ParseSigningKey(rsaData, jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSigningKey, err := ParseSigningKey(ecdsaData, jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rekeyedSigningKey, err := ParseSigningKey(rsaData, newJwks(t, map[string]any{"rsa2": rsaKey}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		signingKey *SigningKey
+		verifyKey  *SigningKey
+		wantKeyID  string
+		wantErr    bool
+	}{
+		{
+			name:       "RS256",
+			signingKey: rsaSigningKey,
+			verifyKey:  rsaSigningKey,
+			wantKeyID:  "rsa",
+		},
+		{
+			name:       "ES256",
+			signingKey: ecdsaSigningKey,
+			verifyKey:  ecdsaSigningKey,
+			wantKeyID:  "ecdsa",
+		},
+		{
+			name:       "Different signingKey",
+			signingKey: rsaSigningKey,
+			verifyKey:  ecdsaSigningKey,
+			wantErr:    true,
+		},
+		{
+			name:       "Different kid",
+			signingKey: rsaSigningKey,
+			verifyKey:  rekeyedSigningKey,
+			wantErr:    true,
+		},
+		{
+			name:       "HS256 signed with public key",
+			signingKey: NewHS256Key(x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)),
+			verifyKey:  rsaSigningKey,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenString, err := NewTokenWithKey(tt.signingKey).WithUsername("foo").NewSignedToken()
+			if err != nil {
+				t.Fatalf("Token.NewSignedToken() error = %v", err)
+			}
+			ok, err := VerifyTokenWithKey(tokenString, tt.verifyKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyTokenWithKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !ok {
+				t.Errorf("VerifyTokenWithKey() ok = %v, want true", ok)
+			}
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.Header["kid"] != tt.wantKeyID {
+				t.Errorf("Token kid = %v, want %v", token.Header["kid"], tt.wantKeyID)
+			}
+			claims, err := ParseTokenClaimsWithKey(tokenString, tt.verifyKey)
+			if err != nil {
+				t.Fatalf("ParseTokenClaimsWithKey() error = %v", err)
+			}
+			if claims["sun"] != "foo" {
+				t.Errorf("ParseTokenClaimsWithKey() sun = %v, want foo", claims["sun"])
+			}
+		})
+	}
+}
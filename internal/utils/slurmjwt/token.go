@@ -14,16 +14,20 @@ import (
 )
 
 type Token struct {
-	signingKey []byte
-	method     jwt.SigningMethod
+	signingKey *SigningKey
 	username   string
 	lifetime   time.Duration
 }
 
+// NewToken returns a token signed by the HS256 shared secret.
 func NewToken(signingKey []byte) *Token {
+	return NewTokenWithKey(NewHS256Key(signingKey))
+}
+
+// NewTokenWithKey returns a token signed by the signing key.
+func NewTokenWithKey(signingKey *SigningKey) *Token {
 	return &Token{
 		signingKey: signingKey,
-		method:     jwt.SigningMethodHS256,
 		username:   "slurm",
 		lifetime:   time.Hour,
 	}
@@ -63,9 +67,12 @@ func (t *Token) NewSignedToken() (string, error) {
 		SlurmUsername: t.username,
 	}
 
-	token := jwt.NewWithClaims(t.method, claims)
+	token := jwt.NewWithClaims(t.signingKey.method, claims)
+	if t.signingKey.keyID != "" {
+		token.Header["kid"] = t.signingKey.keyID
+	}
 
-	tokenString, err := token.SignedString(t.signingKey)
+	tokenString, err := token.SignedString(t.signingKey.key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -74,12 +81,15 @@ func (t *Token) NewSignedToken() (string, error) {
 }
 
 func ParseTokenClaims(tokenString string, signingKey []byte) (jwt.MapClaims, error) {
-	signingKeyFunc := func(token *jwt.Token) (any, error) {
-		return signingKey, nil
-	}
+	return ParseTokenClaimsWithKey(tokenString, NewHS256Key(signingKey))
+}
 
+// ParseTokenClaimsWithKey returns the claims of the token, verified by the
+// signing key.
+func ParseTokenClaimsWithKey(tokenString string, signingKey *SigningKey) (jwt.MapClaims, error) {
 	claims := jwt.MapClaims{}
-	_, err := jwt.ParseWithClaims(tokenString, claims, signingKeyFunc)
+	_, err := jwt.ParseWithClaims(tokenString, claims, signingKey.keyFunc,
+		jwt.WithValidMethods([]string{signingKey.Algorithm()}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JWT claims: %w", err)
 	}
@@ -88,11 +98,14 @@ func ParseTokenClaims(tokenString string, signingKey []byte) (jwt.MapClaims, err
 }
 
 func VerifyToken(tokenString string, signingKey []byte) (bool, error) {
-	signingKeyFunc := func(token *jwt.Token) (any, error) {
-		return signingKey, nil
-	}
+	return VerifyTokenWithKey(tokenString, NewHS256Key(signingKey))
+}
 
-	token, err := jwt.Parse(tokenString, signingKeyFunc)
+// VerifyTokenWithKey reports if the token is valid, as signed by the signing
+// key.
+func VerifyTokenWithKey(tokenString string, signingKey *SigningKey) (bool, error) {
+	token, err := jwt.Parse(tokenString, signingKey.keyFunc,
+		jwt.WithValidMethods([]string{signingKey.Algorithm()}))
 	if err != nil {
 		return false, fmt.Errorf("failed to parse JWT: %w", err)
 	}
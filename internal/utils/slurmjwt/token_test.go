@@ -53,7 +53,7 @@ func TestToken_NewSignedToken(t *testing.T) {
 				t.Errorf("Token.NewSignedToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			ok, err := VerifyToken(got, tr.signingKey)
+			ok, err := VerifyTokenWithKey(got, tr.signingKey)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("VerifyToken() = %v", err)
 				return
@@ -79,6 +79,18 @@ func NewSlurmKeySecret(ref corev1.SecretKeySelector) *corev1.Secret {
 	}
 }
 
+func NewSlurmKeyRotation(name string, ids ...string) *slinkyv1beta1.AuthKeyRotation {
+	rotation := &slinkyv1beta1.AuthKeyRotation{}
+	for _, id := range ids {
+		rotation.Keys = append(rotation.Keys, slinkyv1beta1.AuthKey{
+			ID:        id,
+			SecretRef: NewSlurmKeyRef(name + "-" + id),
+		})
+		rotation.ActiveKeyID = id
+	}
+	return rotation
+}
+
 func NewJwtKeyRef(name string) corev1.SecretKeySelector {
 	return corev1.SecretKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
@@ -278,3 +290,76 @@ func NewToken(name string, jwtKeySecret *corev1.Secret) *slinkyv1beta1.Token {
 		},
 	}
 }
+
+func NewSlurmAccount(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.SlurmAccount {
+	return &slinkyv1beta1.SlurmAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmAccountAPIVersion,
+			Kind:       slinkyv1beta1.SlurmAccountKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmAccountSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controller.Name,
+			},
+		},
+	}
+}
+
+func NewSlurmUser(name string, controller *slinkyv1beta1.Controller, accounts ...string) *slinkyv1beta1.SlurmUser {
+	return &slinkyv1beta1.SlurmUser{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmUserAPIVersion,
+			Kind:       slinkyv1beta1.SlurmUserKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmUserSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controller.Name,
+			},
+			Accounts: accounts,
+		},
+	}
+}
+
+func NewSlurmQOS(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.SlurmQOS {
+	return &slinkyv1beta1.SlurmQOS{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmQOSAPIVersion,
+			Kind:       slinkyv1beta1.SlurmQOSKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmQOSSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controller.Name,
+			},
+		},
+	}
+}
+
+func NewPartition(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.Partition {
+	return &slinkyv1beta1.Partition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.PartitionAPIVersion,
+			Kind:       slinkyv1beta1.PartitionKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.PartitionSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controller.Name,
+			},
+		},
+	}
+}
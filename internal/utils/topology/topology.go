@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/yaml"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+const (
+	// RootSwitch is the switch of a tree topology which all top-level switches are under.
+	RootSwitch = "root"
+
+	// switchSeparator joins the label values along the path of a switch, so
+	// switches of the same label value under different parents are distinct.
+	// It is never in a sanitized name, so paths do not collide.
+	switchSeparator = "_"
+)
+
+// invalidNameChars matches the characters which are not allowed in a Slurm
+// switch or block name, as they are separators in a topology spec (e.g. `:`,
+// `,`) or hostlist expressions (e.g. `[`), or the switch separator.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+// https://slurm.schedmd.com/topology.yaml.html
+type topologyConfig struct {
+	Topology       string       `json:"topology"`
+	ClusterDefault bool         `json:"cluster_default"`
+	Tree           *treeConfig  `json:"tree,omitempty"`
+	Block          *blockConfig `json:"block,omitempty"`
+}
+
+type treeConfig struct {
+	Switches []switchConfig `json:"switches"`
+}
+
+type switchConfig struct {
+	Switch   string `json:"switch"`
+	Children string `json:"children,omitempty"`
+}
+
+type blockConfig struct {
+	BlockSizes []int32       `json:"block_sizes,omitempty"`
+	Blocks     []blockRecord `json:"blocks"`
+}
+
+type blockRecord struct {
+	Block string `json:"block"`
+}
+
+// LabelKeys returns the keys of the Kubernetes node labels used by the topologies.
+func LabelKeys(topologies []slinkyv1beta1.ControllerTopology) []string {
+	keys := set.New[string]()
+	for _, topology := range topologies {
+		if topology.Tree != nil {
+			keys.Insert(topology.Tree.LabelKeys...)
+		}
+		if topology.Block != nil {
+			keys.Insert(topology.Block.LabelKey)
+		}
+	}
+	return keys.SortedList()
+}
+
+// NodeSpec returns the topology spec of the Kubernetes node (e.g.
+// "topo-switch:s1,topo-block:b1"). The `topology.slinky.slurm.net/spec`
+// annotation of the node takes precedence over the spec derived from its labels.
+func NodeSpec(node *corev1.Node, topologies []slinkyv1beta1.ControllerTopology) string {
+	if spec, ok := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]; ok {
+		return spec
+	}
+
+	specs := []string{}
+	for _, topology := range topologies {
+		var unit string
+		switch {
+		case topology.Tree != nil:
+			path := switchPath(node, topology.Tree.LabelKeys)
+			if len(path) == 0 {
+				continue
+			}
+			unit = path[len(path)-1]
+		case topology.Block != nil:
+			unit = blockName(node, topology.Block.LabelKey)
+		}
+		if unit == "" {
+			continue
+		}
+		specs = append(specs, topology.Name+":"+unit)
+	}
+	return strings.Join(specs, ",")
+}
+
+// BuildConfig returns the `topology.yaml` of the topologies, whose switches or
+// blocks are derived from the labels of the Kubernetes nodes. The Slurm nodes
+// are placed into them dynamically, by their topology spec.
+//
+// https://slurm.schedmd.com/topology.yaml.html
+func BuildConfig(topologies []slinkyv1beta1.ControllerTopology, nodes []corev1.Node) (string, error) {
+	configs := make([]topologyConfig, 0, len(topologies))
+	for _, topology := range topologies {
+		config := topologyConfig{
+			Topology:       topology.Name,
+			ClusterDefault: topology.ClusterDefault,
+		}
+		switch {
+		case topology.Tree != nil:
+			config.Tree = buildTree(topology.Tree, nodes)
+		case topology.Block != nil:
+			config.Block = buildBlock(topology.Block, nodes)
+		}
+		configs = append(configs, config)
+	}
+
+	out, err := yaml.Marshal(configs)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(out), nil
+}
+
+func buildTree(tree *slinkyv1beta1.ControllerTopologyTree, nodes []corev1.Node) *treeConfig {
+	children := map[string]set.Set[string]{
+		RootSwitch: set.New[string](),
+	}
+	for i := range nodes {
+		path := switchPath(&nodes[i], tree.LabelKeys)
+		parent := RootSwitch
+		for _, name := range path {
+			children[parent].Insert(name)
+			if _, ok := children[name]; !ok {
+				children[name] = set.New[string]()
+			}
+			parent = name
+		}
+	}
+
+	names := make([]string, 0, len(children))
+	for name := range children {
+		if name != RootSwitch {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{RootSwitch}, names...)
+
+	switches := make([]switchConfig, 0, len(names))
+	for _, name := range names {
+		switches = append(switches, switchConfig{
+			Switch:   name,
+			Children: strings.Join(children[name].SortedList(), ","),
+		})
+	}
+	return &treeConfig{Switches: switches}
+}
+
+func buildBlock(block *slinkyv1beta1.ControllerTopologyBlock, nodes []corev1.Node) *blockConfig {
+	names := set.New[string]()
+	for i := range nodes {
+		if name := blockName(&nodes[i], block.LabelKey); name != "" {
+			names.Insert(name)
+		}
+	}
+
+	blocks := make([]blockRecord, 0, names.Len())
+	for _, name := range names.SortedList() {
+		blocks = append(blocks, blockRecord{Block: name})
+	}
+	return &blockConfig{
+		BlockSizes: block.BlockSizes,
+		Blocks:     blocks,
+	}
+}
+
+// switchPath returns the switch names from the top-level switch to the leaf
+// switch of the node, or nil when the node does not have all labels.
+func switchPath(node *corev1.Node, labelKeys []string) []string {
+	path := make([]string, 0, len(labelKeys))
+	prefix := ""
+	for _, key := range labelKeys {
+		value := node.Labels[key]
+		if value == "" {
+			return nil
+		}
+		name := prefix + sanitizeName(value)
+		path = append(path, name)
+		prefix = name + switchSeparator
+	}
+	return path
+}
+
+// blockName returns the block name of the node, or empty when the node does not have the label.
+func blockName(node *corev1.Node, labelKey string) string {
+	value := node.Labels[labelKey]
+	if value == "" {
+		return ""
+	}
+	return sanitizeName(value)
+}
+
+func sanitizeName(value string) string {
+	return invalidNameChars.ReplaceAllString(value, "-")
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+const (
+	rackLabel = "example.com/rack"
+)
+
+func newNode(name string, labels, annotations map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
+
+var testTopologies = []slinkyv1beta1.ControllerTopology{
+	{
+		Name:           "topo-switch",
+		ClusterDefault: true,
+		Tree: &slinkyv1beta1.ControllerTopologyTree{
+			LabelKeys: []string{corev1.LabelTopologyZone, rackLabel},
+		},
+	},
+	{
+		Name: "topo-block",
+		Block: &slinkyv1beta1.ControllerTopologyBlock{
+			LabelKey:   corev1.LabelTopologyZone,
+			BlockSizes: []int32{2, 4},
+		},
+	},
+}
+
+func TestLabelKeys(t *testing.T) {
+	got := LabelKeys(testTopologies)
+	want := []string{rackLabel, corev1.LabelTopologyZone}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LabelKeys() (-want,+got):\n%s", diff)
+	}
+}
+
+func TestNodeSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		node       corev1.Node
+		topologies []slinkyv1beta1.ControllerTopology
+		want       string
+	}{
+		{
+			name: "no topologies",
+			node: newNode("node0", map[string]string{corev1.LabelTopologyZone: "a"}, nil),
+			want: "",
+		},
+		{
+			name: "annotation",
+			node: newNode("node0",
+				map[string]string{corev1.LabelTopologyZone: "a"},
+				map[string]string{slinkyv1beta1.AnnotationNodeTopologySpec: "topo-switch:s1"}),
+			topologies: testTopologies,
+			want:       "topo-switch:s1",
+		},
+		{
+			name: "all labels",
+			node: newNode("node0", map[string]string{
+				corev1.LabelTopologyZone: "us-east-1a",
+				rackLabel:                "r1",
+			}, nil),
+			topologies: testTopologies,
+			want:       "topo-switch:us-east-1a_r1,topo-block:us-east-1a",
+		},
+		{
+			name: "partial tree labels",
+			node: newNode("node0", map[string]string{
+				corev1.LabelTopologyZone: "us-east-1a",
+			}, nil),
+			topologies: testTopologies,
+			want:       "topo-block:us-east-1a",
+		},
+		{
+			name:       "no labels",
+			node:       newNode("node0", nil, nil),
+			topologies: testTopologies,
+			want:       "",
+		},
+		{
+			name: "invalid characters",
+			node: newNode("node0", map[string]string{
+				corev1.LabelTopologyZone: "a",
+				rackLabel:                "r:1",
+			}, nil),
+			topologies: testTopologies[:1],
+			want:       "topo-switch:a_r-1",
+		},
+		{
+			name: "separator in label value",
+			node: newNode("node0", map[string]string{
+				corev1.LabelTopologyZone: "a_r",
+				rackLabel:                "1",
+			}, nil),
+			topologies: testTopologies[:1],
+			want:       "topo-switch:a-r_1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NodeSpec(&tt.node, tt.topologies); got != tt.want {
+				t.Errorf("NodeSpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		topologies []slinkyv1beta1.ControllerTopology
+		nodes      []corev1.Node
+		want       string
+	}{
+		{
+			name:       "no nodes",
+			topologies: testTopologies,
+			want: `---
+- cluster_default: true
+  topology: topo-switch
+  tree:
+    switches:
+    - switch: root
+- block:
+    block_sizes:
+    - 2
+    - 4
+    blocks: []
+  cluster_default: false
+  topology: topo-block
+`,
+		},
+		{
+			name:       "nodes",
+			topologies: testTopologies,
+			nodes: []corev1.Node{
+				newNode("node0", map[string]string{corev1.LabelTopologyZone: "a", rackLabel: "r1"}, nil),
+				newNode("node1", map[string]string{corev1.LabelTopologyZone: "a", rackLabel: "r2"}, nil),
+				newNode("node2", map[string]string{corev1.LabelTopologyZone: "b", rackLabel: "r1"}, nil),
+				newNode("node3", map[string]string{corev1.LabelTopologyZone: "c"}, nil),
+				newNode("node4", nil, nil),
+			},
+			want: `---
+- cluster_default: true
+  topology: topo-switch
+  tree:
+    switches:
+    - children: a,b
+      switch: root
+    - children: a_r1,a_r2
+      switch: a
+    - switch: a_r1
+    - switch: a_r2
+    - children: b_r1
+      switch: b
+    - switch: b_r1
+- block:
+    block_sizes:
+    - 2
+    - 4
+    blocks:
+    - block: a
+    - block: b
+    - block: c
+  cluster_default: false
+  topology: topo-block
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildConfig(tt.topologies, tt.nodes)
+			if err != nil {
+				t.Fatalf("BuildConfig() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("BuildConfig() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
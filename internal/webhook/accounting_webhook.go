@@ -51,9 +51,14 @@ func (r *AccountingWebhook) ValidateUpdate(ctx context.Context, oldAccounting, n
 
 	warns, errs := r.validateAccounting(newAccounting)
 
+	// Keys may only be changed by way of a rotation.
 	if !apiequality.Semantic.DeepEqual(newAccounting.AuthJwtRef(), oldAccounting.AuthJwtRef()) {
 		errs = append(errs, errors.New("the value of JwtKeyRef or JwtHs256KeyRef cannot be modified after deployment"))
 	}
+	errs = append(errs, validateAuthKeyRotationUpdate("slurmKeyRotation",
+		oldAccounting.AuthSlurmRef(), newAccounting.AuthSlurmRef(),
+		oldAccounting.Spec.SlurmKeyRotation, newAccounting.Spec.SlurmKeyRotation,
+		oldAccounting.Status.SlurmKeyRotation)...)
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -74,5 +79,7 @@ func (r *AccountingWebhook) validateAccounting(accounting *slinkyv1beta1.Account
 		warns = append(warns, "ExternalIPs may not be set for accounting service")
 	}
 
+	errs = append(errs, validateAuthKeyRotation("slurmKeyRotation", accounting.Spec.SlurmKeyRotation)...)
+
 	return warns, errs
 }
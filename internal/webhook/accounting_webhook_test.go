@@ -33,6 +33,18 @@ var _ = Describe("Accounting Webhook", func() {
 			_, err := accountingWebhook.ValidateUpdate(ctx, newAccounting, newAccounting)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should deny an Update which removes the key of the rotation which signs", func() {
+			By("Returning an error")
+			oldAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			oldAccounting.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+
+			newAccounting := oldAccounting.DeepCopy()
+			newAccounting.Spec.SlurmKeyRotation.Keys = newAccounting.Spec.SlurmKeyRotation.Keys[:1]
+
+			_, err := accountingWebhook.ValidateUpdate(ctx, oldAccounting, newAccounting)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("When creating Accounting with Validating Webhook", func() {
@@ -8,23 +8,35 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmconf"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=delete;create;update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets;partitions,verbs=get;list;watch
 
 type ControllerWebhook struct {
 	client.Client
@@ -68,12 +80,23 @@ func (r *ControllerWebhook) ValidateUpdate(ctx context.Context, oldController, n
 	if newController.ClusterName() != oldController.ClusterName() {
 		errs = append(errs, errors.New("cannot change ClusterName after deployment"))
 	}
-	if !apiequality.Semantic.DeepEqual(newController.Spec.SlurmKeyRef.LocalObjectReference, oldController.Spec.SlurmKeyRef.LocalObjectReference) {
+	// Keys may only be changed by way of a rotation.
+	if newController.Spec.SlurmKeyRotation == nil && oldController.Spec.SlurmKeyRotation == nil &&
+		!apiequality.Semantic.DeepEqual(newController.AuthSlurmRef().LocalObjectReference, oldController.AuthSlurmRef().LocalObjectReference) {
 		errs = append(errs, errors.New("cannot change SlurmKeyRef after deployment"))
 	}
 	if !apiequality.Semantic.DeepEqual(newController.AuthJwtRef(), oldController.AuthJwtRef()) {
 		errs = append(errs, errors.New("the value of JwtKeyRef or JwtHs256KeyRef cannot be modified after deployment"))
 	}
+	// Omitted keys are shared by the Accounting, so changing it would swap them.
+	if (newController.IsAuthSlurmRefOmitted() || newController.IsAuthJwtRefOmitted()) &&
+		!apiequality.Semantic.DeepEqual(newController.Spec.AccountingRef, oldController.Spec.AccountingRef) {
+		errs = append(errs, errors.New("cannot change AccountingRef after deployment, when SlurmKeyRef or JwtKeyRef is omitted"))
+	}
+	errs = append(errs, validateAuthKeyRotationUpdate("slurmKeyRotation",
+		oldController.AuthSlurmRef(), newController.AuthSlurmRef(),
+		oldController.Spec.SlurmKeyRotation, newController.Spec.SlurmKeyRotation,
+		oldController.Status.SlurmKeyRotation)...)
 
 	// We use volumeClaimTemplates to handle the controller savestate PVC.
 	// StatefulSet does not allow update of that field.
@@ -81,6 +104,11 @@ func (r *ControllerWebhook) ValidateUpdate(ctx context.Context, oldController, n
 		errs = append(errs, errors.New("cannot change persistence.enabled after deployment"))
 	}
 
+	// The service cannot be changed to or from headless.
+	if newController.Spec.HighAvailability.Enabled != oldController.Spec.HighAvailability.Enabled {
+		errs = append(errs, errors.New("cannot change highAvailability.enabled after deployment"))
+	}
+
 	return warns, utilerrors.NewAggregate(errs)
 }
 
@@ -133,6 +161,8 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 		for _, file := range configFiles {
 			if slices.Contains(denyConfigFiles, file) {
 				errs = append(errs, fmt.Errorf("the configFile is reserved for slurm-operator use: %s", file))
+			} else if len(controller.Spec.Topology) > 0 && slices.Contains(topologyConfigFiles, file) {
+				errs = append(errs, fmt.Errorf("the configFile cannot be used with topology, which generates it: %s", file))
 			} else if !slices.Contains(knownConfigFiles, file) {
 				warns = append(warns, fmt.Sprintf("the configFile is unknown to Slurm, make sure to include it in another config file otherwise it is ignored: %s", file))
 			}
@@ -144,5 +174,411 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 		warns = append(warns, "ExternalIPs may not be set for controller service")
 	}
 
+	errs = append(errs, validateHighAvailability(controller)...)
+	errs = append(errs, validateTopology(controller.Spec.Topology)...)
+	errs = append(errs, validateGres(controller.Spec.Gres)...)
+	errs = append(errs, validateAuthKeyRotation("slurmKeyRotation", controller.Spec.SlurmKeyRotation)...)
+	errs = append(errs, r.validateAccountingKeys(ctx, controller)...)
+
+	slurmConfWarns, slurmConfErrs := validateSlurmConf(controller.Spec.SlurmConf)
+	warns = append(warns, slurmConfWarns...)
+	errs = append(errs, slurmConfErrs...)
+
+	// The ExtraConf of an external Controller is ignored.
+	if !controller.Spec.External {
+		entities, err := r.slurmConfEntities(ctx, controller)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		extraConfWarns, extraConfErrs := validateExtraConf(controller.Spec.ExtraConf, controller.Spec.SlurmConf, entities)
+		warns = append(warns, extraConfWarns...)
+		errs = append(errs, extraConfErrs...)
+	}
+
+	return warns, errs
+}
+
+// slurmConfEntities returns the NodeSets and partitions which the operator
+// defines in the slurm.conf of the Controller, apart from its ExtraConf.
+func (r *ControllerWebhook) slurmConfEntities(ctx context.Context, controller *slinkyv1beta1.Controller) ([]slurmconf.Parameter, error) {
+	refResolver := refresolver.New(r.Client)
+	nodesetList, err := refResolver.GetNodeSetsForController(ctx, controller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NodeSets: %w", err)
+	}
+	partitionList, err := refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Partitions: %w", err)
+	}
+
+	entities := []slurmconf.Parameter{}
+	for _, nodeset := range nodesetList.Items {
+		name := common.GetSlurmNodeSetName(&nodeset)
+		entities = append(entities, slurmconf.Parameter{Key: "NodeSet", Value: name})
+		if nodeset.Spec.Partition.Enabled {
+			entities = append(entities, slurmconf.Parameter{Key: "PartitionName", Value: name})
+		}
+	}
+	for _, partition := range partitionList.Items {
+		entities = append(entities, slurmconf.Parameter{Key: "PartitionName", Value: partition.PartitionName()})
+	}
+	return entities, nil
+}
+
+// validateAccountingKeys checks that the Controller omits the keys which its
+// Accounting omits, as the Accounting generates them for the Controllers which
+// reference it.
+func (r *ControllerWebhook) validateAccountingKeys(ctx context.Context, controller *slinkyv1beta1.Controller) []error {
+	if controller.Spec.External || controller.Spec.AccountingRef == nil {
+		return nil
+	}
+
+	accounting, err := refresolver.New(r.Client).GetAccounting(ctx, *controller.Spec.AccountingRef, controller.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return []error{err}
+	}
+	if accounting.Spec.External {
+		return nil
+	}
+
+	var errs []error
+	if !controller.IsAuthSlurmRefOmitted() && accounting.IsAuthSlurmRefOmitted() {
+		errs = append(errs, fmt.Errorf("slurmKeyRef must be omitted, as Accounting (%s) generates its key", klog.KObj(accounting)))
+	}
+	if !controller.IsAuthJwtRefOmitted() && accounting.IsAuthJwtRefOmitted() {
+		errs = append(errs, fmt.Errorf("jwtKeyRef must be omitted, as Accounting (%s) generates its key", klog.KObj(accounting)))
+	}
+	return errs
+}
+
+// validateHighAvailability checks that the primary and backup slurmctld can
+// share their StateSaveLocation, and be addressed by pod.
+func validateHighAvailability(controller *slinkyv1beta1.Controller) []error {
+	if !controller.IsHighAvailability() {
+		return nil
+	}
+	var errs []error
+
+	persistence := controller.Spec.Persistence
+	if !ptr.Deref(persistence.Enabled, defaults.DefaultControllerPersistenceEnabled) || persistence.ExistingClaim == "" {
+		errs = append(errs, errors.New("highAvailability requires persistence.existingClaim, a volume shared by the primary and backup slurmctld"))
+	}
+
+	service := controller.Spec.Service
+	if serviceType := service.ServiceSpecWrapper.Type; serviceType != "" && serviceType != corev1.ServiceTypeClusterIP {
+		errs = append(errs, fmt.Errorf("highAvailability requires a service of type ClusterIP, got %s", serviceType))
+	}
+	if service.NodePort != 0 {
+		errs = append(errs, errors.New("highAvailability does not support a service nodePort"))
+	}
+
+	return errs
+}
+
+// topologyConfigFiles are the config files which define the Slurm topology.
+// Ref: https://slurm.schedmd.com/topology.html
+var topologyConfigFiles = []string{
+	"topology.conf",
+	"topology.yaml",
+}
+
+// validateTopology checks that each topology is either a tree or a block, of
+// valid label keys, and that at most one is the cluster default.
+func validateTopology(topologies []slinkyv1beta1.ControllerTopology) []error {
+	var errs []error
+
+	validateLabelKey := func(name, key string) {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Errorf("topology %q label key %q: %s", name, key, msg))
+		}
+	}
+
+	clusterDefaults := 0
+	for _, topology := range topologies {
+		if strings.ContainsAny(topology.Name, ":,") {
+			errs = append(errs, fmt.Errorf("topology %q: name must not contain ':' or ','", topology.Name))
+		}
+		if topology.ClusterDefault {
+			clusterDefaults++
+		}
+		switch {
+		case topology.Tree != nil && topology.Block != nil:
+			errs = append(errs, fmt.Errorf("topology %q: only one of tree or block may be set", topology.Name))
+		case topology.Tree != nil:
+			for _, key := range topology.Tree.LabelKeys {
+				validateLabelKey(topology.Name, key)
+			}
+		case topology.Block != nil:
+			validateLabelKey(topology.Name, topology.Block.LabelKey)
+		default:
+			errs = append(errs, fmt.Errorf("topology %q: one of tree or block must be set", topology.Name))
+		}
+	}
+	if clusterDefaults > 1 {
+		errs = append(errs, errors.New("at most one topology may be the clusterDefault"))
+	}
+
+	return errs
+}
+
+// validateGres checks that each GRES is mapped from an extended resource, by
+// a name and type which are valid in a GRES spec (e.g. "gpu:h100:8").
+func validateGres(gres slinkyv1beta1.ControllerGres) []error {
+	var errs []error
+
+	specs := map[string]bool{}
+	for _, resource := range gres.Resources {
+		name := string(resource.ResourceName)
+		if !isExtendedResourceName(resource.ResourceName) {
+			errs = append(errs, fmt.Errorf("gres resourceName %q: must be an extended resource (e.g. nvidia.com/gpu)", name))
+		}
+		if strings.ContainsAny(resource.Name, ":,= ") || strings.ContainsAny(resource.Type, ":,= ") {
+			errs = append(errs, fmt.Errorf("gres resourceName %q: name and type must not contain ':', ',', '=' or whitespace", name))
+		}
+		spec := resource.Name + ":" + resource.Type
+		if specs[spec] {
+			errs = append(errs, fmt.Errorf("gres resourceName %q: name %q and type %q are already mapped", name, resource.Name, resource.Type))
+		}
+		specs[spec] = true
+	}
+
+	return errs
+}
+
+// isExtendedResourceName returns true if the resource name is a fully
+// qualified name outside of the `kubernetes.io` domain.
+// Ref: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/#extended-resources
+func isExtendedResourceName(name corev1.ResourceName) bool {
+	if !strings.Contains(string(name), "/") || strings.Contains(string(name), corev1.ResourceDefaultNamespacePrefix) {
+		return false
+	}
+	if strings.HasPrefix(string(name), corev1.DefaultResourceRequestsPrefix) {
+		return false
+	}
+	return len(validation.IsQualifiedName(string(name))) == 0
+}
+
+// validateAuthKeyRotation checks that the active key is one of the keys.
+func validateAuthKeyRotation(name string, rotation *slinkyv1beta1.AuthKeyRotation) []error {
+	if rotation == nil {
+		return nil
+	}
+	if rotation.Key(rotation.ActiveKeyID) == nil {
+		return []error{fmt.Errorf("%s activeKeyId %q: must be the id of one of the keys", name, rotation.ActiveKeyID)}
+	}
+	return nil
+}
+
+// validateAuthKeyRotationUpdate checks that the key which signs stays accepted
+// as the rotation is added, changed, or removed. The oldRef and newRef are the
+// keys when not rotated.
+func validateAuthKeyRotationUpdate(
+	name string,
+	oldRef, newRef corev1.SecretKeySelector,
+	oldRotation, newRotation *slinkyv1beta1.AuthKeyRotation,
+	status *slinkyv1beta1.AuthKeyRotationStatus,
+) []error {
+	var errs []error
+
+	switch {
+	case oldRotation == nil && newRotation == nil:
+	case oldRotation == nil:
+		if key := newRotation.Key(newRotation.ActiveKeyID); key != nil && !apiequality.Semantic.DeepEqual(key.SecretRef, oldRef) {
+			errs = append(errs, fmt.Errorf("%s activeKeyId %q: must be the current key when the rotation is added", name, newRotation.ActiveKeyID))
+		}
+	case newRotation == nil:
+		if !apiequality.Semantic.DeepEqual(newRef, oldRotation.ActiveKeyRef(status)) {
+			errs = append(errs, fmt.Errorf("%s: the key must be the one which signs when the rotation is removed", name))
+		}
+	default:
+		signingKeyID := oldRotation.SigningKeyID(status)
+		for _, oldKey := range oldRotation.Keys {
+			newKey := newRotation.Key(oldKey.ID)
+			switch {
+			case newKey == nil && oldKey.ID == signingKeyID:
+				errs = append(errs, fmt.Errorf("%s key %q: cannot be removed while it signs", name, oldKey.ID))
+			case newKey != nil && !apiequality.Semantic.DeepEqual(newKey.SecretRef, oldKey.SecretRef):
+				errs = append(errs, fmt.Errorf("%s key %q: cannot change the secretRef, add a key instead", name, oldKey.ID))
+			}
+		}
+	}
+
+	return errs
+}
+
+// selectTypeResources are the SelectTypeParameters which set the consumable
+// resource, of which only one may be used.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectTypeParameters
+var selectTypeResources = []string{
+	"CR_CPU",
+	"CR_CPU_Memory",
+	"CR_Core",
+	"CR_Core_Memory",
+	"CR_Memory",
+	"CR_Socket",
+	"CR_Socket_Memory",
+}
+
+// validateSlurmConf checks the typed slurm.conf fields beyond what the CRD
+// schema can express.
+func validateSlurmConf(slurmConf slinkyv1beta1.SlurmConf) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	for _, list := range []struct {
+		field   string
+		options []string
+	}{
+		{"slurmConf.scheduling.schedulerParameters", slurmConf.Scheduling.SchedulerParameters},
+		{"slurmConf.select.selectTypeParameters", slurmConf.Select.SelectTypeParameters},
+	} {
+		for _, option := range list.options {
+			if option == "" || strings.ContainsAny(option, ", \t\n") {
+				errs = append(errs, fmt.Errorf("%s option %q must not be empty or contain commas or whitespace", list.field, option))
+			}
+		}
+	}
+
+	preemptMode := slurmConf.Scheduling.PreemptMode
+	if slices.Contains(preemptMode, "OFF") && len(preemptMode) > 1 {
+		errs = append(errs, errors.New("slurmConf.scheduling.preemptMode OFF cannot be combined with other modes"))
+	}
+
+	resources := []string{}
+	for _, param := range slurmConf.Select.SelectTypeParameters {
+		if slices.ContainsFunc(selectTypeResources, func(r string) bool { return strings.EqualFold(r, param) }) {
+			resources = append(resources, param)
+		}
+	}
+	if len(resources) > 1 {
+		errs = append(errs, fmt.Errorf("slurmConf.select.selectTypeParameters may only have one consumable resource, got: %s", strings.Join(resources, ",")))
+	}
+
+	sel := slurmConf.Select
+	if sel.DefMemPerCPU != nil && sel.MaxMemPerCPU != nil && *sel.DefMemPerCPU > *sel.MaxMemPerCPU {
+		errs = append(errs, fmt.Errorf("slurmConf.select.defMemPerCPU (%d) must not exceed maxMemPerCPU (%d)", *sel.DefMemPerCPU, *sel.MaxMemPerCPU))
+	}
+
+	priority := slurmConf.Priority
+	if priority.PriorityType == "priority/basic" {
+		weights := []*int32{
+			priority.WeightAge,
+			priority.WeightAssoc,
+			priority.WeightFairshare,
+			priority.WeightJobSize,
+			priority.WeightPartition,
+			priority.WeightQOS,
+		}
+		if slices.ContainsFunc(weights, func(w *int32) bool { return w != nil }) || len(priority.WeightTRES) > 0 {
+			warns = append(warns, "slurmConf.priority weights are ignored by priority/basic")
+		}
+	}
+	for tres, weight := range priority.WeightTRES {
+		if tres == "" || strings.ContainsAny(tres, ",= ") {
+			errs = append(errs, fmt.Errorf("slurmConf.priority.weightTRES key %q is not a valid TRES name", tres))
+		}
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("slurmConf.priority.weightTRES %s must not be negative", tres))
+		}
+	}
+
+	timeouts := slurmConf.Timeouts
+	for _, limit := range []struct {
+		field    string
+		duration *metav1.Duration
+		unit     time.Duration
+	}{
+		{"slurmConf.priority.decayHalfLife", priority.DecayHalfLife, time.Minute},
+		{"slurmConf.priority.maxAge", priority.MaxAge, time.Minute},
+		{"slurmConf.timeouts.slurmctldTimeout", timeouts.SlurmctldTimeout, time.Second},
+		{"slurmConf.timeouts.slurmdTimeout", timeouts.SlurmdTimeout, time.Second},
+		{"slurmConf.timeouts.messageTimeout", timeouts.MessageTimeout, time.Second},
+		{"slurmConf.timeouts.inactiveLimit", timeouts.InactiveLimit, time.Second},
+		{"slurmConf.timeouts.killWait", timeouts.KillWait, time.Second},
+		{"slurmConf.timeouts.minJobAge", timeouts.MinJobAge, time.Second},
+		{"slurmConf.timeouts.waitTime", timeouts.WaitTime, time.Second},
+		{"slurmConf.timeouts.unkillableStepTimeout", timeouts.UnkillableStepTimeout, time.Second},
+	} {
+		if limit.duration == nil {
+			continue
+		}
+		if d := limit.duration.Duration; d < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %v", limit.field, d))
+		} else if d%limit.unit != 0 {
+			warns = append(warns, fmt.Sprintf("%s %v is rounded down to a multiple of %v", limit.field, d, limit.unit))
+		}
+	}
+	if d := timeouts.MessageTimeout; d != nil && d.Duration > 100*time.Second {
+		warns = append(warns, "slurmConf.timeouts.messageTimeout above 100s is not recommended by Slurm")
+	}
+
+	return warns, errs
+}
+
+// operatorConfKeys are the slurm.conf parameters set by the operator, which
+// ExtraConf overrides.
+var operatorConfKeys = []string{
+	"AccountingStorageHost",
+	"AccountingStoragePort",
+	"AccountingStorageType",
+	"AuthAltTypes",
+	"AuthType",
+	"ClusterName",
+	"CredType",
+	"SlurmctldHost",
+	"SlurmctldLogFile",
+	"SlurmctldPort",
+	"SlurmdLogFile",
+	"SlurmdPort",
+	"SlurmdSpoolDir",
+	"SlurmdUser",
+	"SlurmSchedLogFile",
+	"SlurmUser",
+	"StateSaveLocation",
+}
+
+// validateExtraConf validates the ExtraConf parameters as the Controller
+// reconciler validates the rendered slurm.conf, given the entities which the
+// operator defines, so what the reconciler would not roll out is denied. It
+// also lints them for keys which are duplicated or also set by the operator or
+// SlurmConf.
+func validateExtraConf(extraConf string, slurmConf slinkyv1beta1.SlurmConf, entities []slurmconf.Parameter) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	validateWarns, validateErrs := slurmconf.Validate(extraConf, entities...)
+	for _, warn := range validateWarns {
+		warns = append(warns, "extraConf "+warn)
+	}
+	for _, err := range validateErrs {
+		errs = append(errs, fmt.Errorf("extraConf %w", err))
+	}
+
+	typedKeys := map[string]bool{}
+	for _, param := range slurmconf.FromSpec(slurmConf) {
+		typedKeys[strings.ToLower(param.Key)] = true
+	}
+
+	seen := map[string]int{}
+	for _, param := range slurmconf.Parse(extraConf) {
+		key := strings.ToLower(param.Key)
+		switch {
+		case typedKeys[key]:
+			errs = append(errs, fmt.Errorf("extraConf line %d: %s is already set by slurmConf", param.Line, param.Key))
+		case slices.ContainsFunc(operatorConfKeys, func(k string) bool { return strings.EqualFold(k, key) }):
+			warns = append(warns, fmt.Sprintf("extraConf line %d: %s overrides the value set by the operator", param.Line, param.Key))
+		}
+		if slurmconf.IsEntityKey(key) {
+			continue
+		}
+		if line, ok := seen[key]; ok {
+			warns = append(warns, fmt.Sprintf("extraConf line %d: %s is duplicated, overriding line %d", param.Line, param.Key, line))
+		}
+		seen[key] = param.Line
+	}
+
 	return warns, errs
 }
@@ -4,12 +4,26 @@
 package webhook
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmconf"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -37,6 +51,234 @@ var _ = Describe("Controller Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(ContainElement("ExternalIPs may not be set for controller service"))
 		})
+
+		It("Should admit typed slurmConf fields", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.SlurmConf.Scheduling.SchedulerParameters = []string{"bf_continue", "bf_window=4320"}
+			controller.Spec.SlurmConf.Select.SelectTypeParameters = []string{"CR_Core_Memory"}
+			controller.Spec.SlurmConf.Timeouts.SlurmdTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+
+			warnings, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny multiple consumable resources in selectTypeParameters", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.SlurmConf.Select.SelectTypeParameters = []string{"CR_Core", "CR_Socket_Memory"}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if defMemPerCPU exceeds maxMemPerCPU", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.SlurmConf.Select.DefMemPerCPU = ptr.To(int64(4096))
+			controller.Spec.SlurmConf.Select.MaxMemPerCPU = ptr.To(int64(2048))
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit highAvailability with an existing claim", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.HighAvailability.Enabled = true
+			controller.Spec.Persistence.Enabled = ptr.To(true)
+			controller.Spec.Persistence.ExistingClaim = "statesave"
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny highAvailability without an existing claim", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.HighAvailability.Enabled = true
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny highAvailability with a NodePort service", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.HighAvailability.Enabled = true
+			controller.Spec.Persistence.Enabled = ptr.To(true)
+			controller.Spec.Persistence.ExistingClaim = "statesave"
+			controller.Spec.Service.ServiceSpecWrapper.Type = corev1.ServiceTypeNodePort
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a tree and a block topology", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+				{
+					Name:           "topo-switch",
+					ClusterDefault: true,
+					Tree: &slinkyv1beta1.ControllerTopologyTree{
+						LabelKeys: []string{corev1.LabelTopologyZone, "example.com/rack"},
+					},
+				},
+				{
+					Name: "topo-block",
+					Block: &slinkyv1beta1.ControllerTopologyBlock{
+						LabelKey: corev1.LabelTopologyZone,
+					},
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a topology with both tree and block", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+				{
+					Name: "topo",
+					Tree: &slinkyv1beta1.ControllerTopologyTree{
+						LabelKeys: []string{corev1.LabelTopologyZone},
+					},
+					Block: &slinkyv1beta1.ControllerTopologyBlock{
+						LabelKey: corev1.LabelTopologyZone,
+					},
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny multiple clusterDefault topologies", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+				{
+					Name:           "topo-a",
+					ClusterDefault: true,
+					Block:          &slinkyv1beta1.ControllerTopologyBlock{LabelKey: corev1.LabelTopologyZone},
+				},
+				{
+					Name:           "topo-b",
+					ClusterDefault: true,
+					Block:          &slinkyv1beta1.ControllerTopologyBlock{LabelKey: corev1.LabelTopologyRegion},
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a topology with an invalid label key", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology = []slinkyv1beta1.ControllerTopology{
+				{
+					Name:  "topo-block",
+					Block: &slinkyv1beta1.ControllerTopologyBlock{LabelKey: "not a label"},
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit gres of extended resources", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Gres = slinkyv1beta1.ControllerGres{
+				Resources: []slinkyv1beta1.ControllerGresResource{
+					{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "h100"},
+					{ResourceName: "example.com/nic", Name: "nic"},
+				},
+				AutoDetect: "nvidia",
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny gres of a native resource", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Gres.Resources = []slinkyv1beta1.ControllerGresResource{
+				{ResourceName: corev1.ResourceCPU, Name: "cpu"},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny gres with an invalid or duplicated name", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Gres.Resources = []slinkyv1beta1.ControllerGresResource{
+				{ResourceName: "example.com/gpu", Name: "gpu:h100"},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+
+			controller.Spec.Gres.Resources = []slinkyv1beta1.ControllerGresResource{
+				{ResourceName: "example.com/gpu", Name: "gpu"},
+				{ResourceName: "example.org/gpu", Name: "gpu"},
+			}
+
+			_, err = controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a key rotation whose active key is not a key", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+			controller.Spec.SlurmKeyRotation.ActiveKeyID = "key3"
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny keys which the referenced Accounting generates", func(ctx SpecContext) {
+			accounting := testutils.NewAccounting("generated", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, testutils.NewPasswordRef("generated"))
+			accounting.Spec.JwtKeyRef = nil
+			Expect(k8sClient.Create(ctx, accounting)).To(Succeed())
+			DeferCleanup(func(ctx SpecContext) {
+				Expect(k8sClient.Delete(ctx, accounting)).To(Succeed())
+			})
+			webhook := ControllerWebhook{Client: k8sClient}
+
+			controller := testutils.NewController("clustername", testutils.NewSlurmKeyRef("test"), corev1.SecretKeySelector{}, accounting)
+			controller.Spec.JwtKeyRef = nil
+
+			_, err := webhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+
+			controller.Spec.SlurmKeyRef = corev1.SecretKeySelector{}
+
+			_, err = webhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if extraConf sets a slurmConf parameter", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.SlurmConf.Scheduling.SchedulerType = "sched/backfill"
+			controller.Spec.ExtraConf = "SchedulerType=sched/builtin"
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn about unknown and duplicated extraConf parameters", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.ExtraConf = strings.Join([]string{
+				"SchedulerTyp=sched/backfill",
+				"MinJobAge=2",
+				"MinJobAge=30",
+				"PartitionName=a Nodes=ALL",
+				"PartitionName=b Nodes=ALL",
+			}, "\n")
+
+			warnings, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				`extraConf line 1: unknown parameter "SchedulerTyp"`,
+				"extraConf line 3: MinJobAge is duplicated, overriding line 2",
+			))
+		})
 	})
 
 	Context("When Updating a Controller with Validating Webhook", func() {
@@ -70,6 +312,136 @@ var _ = Describe("Controller Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should reject replacing the generated SlurmKeyRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Status.SlurmKeyRef = new(oldController.AuthSlurmGeneratedRef())
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRef = testutils.NewSlurmKeyRef("test")
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+
+			newController.Spec.SlurmKeyRef = oldController.AuthSlurmGeneratedRef()
+
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject changes to AccountingRef when keys are omitted", func(ctx SpecContext) {
+			accounting := testutils.NewAccounting("accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Status.SlurmKeyRef = new(oldController.AuthSlurmGeneratedRef())
+
+			newController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, accounting)
+			newController.Status = oldController.Status
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+
+			oldController = testutils.NewController("cluster", testutils.NewSlurmKeyRef("test"), corev1.SecretKeySelector{}, nil)
+			newController = testutils.NewController("cluster", testutils.NewSlurmKeyRef("test"), corev1.SecretKeySelector{}, accounting)
+
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit a key rotation which is added with the generated key", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Status.SlurmKeyRef = new(oldController.AuthSlurmGeneratedRef())
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+			newController.Spec.SlurmKeyRotation.Keys[0].SecretRef = oldController.AuthSlurmGeneratedRef()
+			newController.Spec.SlurmKeyRotation.ActiveKeyID = "key1"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit a key rotation which is added with the current key", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", testutils.NewSlurmKeyRef("test-key1"), corev1.SecretKeySelector{}, nil)
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+			newController.Spec.SlurmKeyRotation.ActiveKeyID = "key1"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+
+			newController.Spec.SlurmKeyRotation.ActiveKeyID = "key2"
+
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit activating a key of the rotation", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key2", "key1")
+			oldController.Status.SlurmKeyRotation = &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID: "key1",
+				KeyIDs:      []string{"key1", "key2"},
+			}
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation.ActiveKeyID = "key2"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject removing the key of the rotation which signs", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+			oldController.Status.SlurmKeyRotation = &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID: "key1",
+				KeyIDs:      []string{"key1", "key2"},
+			}
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation.Keys = newController.Spec.SlurmKeyRotation.Keys[1:]
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+
+			oldController.Status.SlurmKeyRotation.ActiveKeyID = "key2"
+
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject changing the secretRef of a key of the rotation", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1")
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation.Keys[0].SecretRef = testutils.NewSlurmKeyRef("other")
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject removing a key rotation unless the key signs", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.SlurmKeyRotation = testutils.NewSlurmKeyRotation("test", "key1", "key2")
+			oldController.Status.SlurmKeyRotation = &slinkyv1beta1.AuthKeyRotationStatus{
+				ActiveKeyID: "key1",
+				KeyIDs:      []string{"key1", "key2"},
+			}
+
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation = nil
+			newController.Spec.SlurmKeyRef = testutils.NewSlurmKeyRef("test-key2")
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+
+			newController.Spec.SlurmKeyRef = testutils.NewSlurmKeyRef("test-key1")
+
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("Should reject changes to controller.persistence.enabled", func(ctx SpecContext) {
 			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 
@@ -80,6 +452,18 @@ var _ = Describe("Controller Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should reject changes to controller.highAvailability.enabled", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.Persistence.Enabled = ptr.To(true)
+			oldController.Spec.Persistence.ExistingClaim = "statesave"
+
+			newController := oldController.DeepCopy()
+			newController.Spec.HighAvailability.Enabled = true
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should admit if changes pass validation", func(ctx SpecContext) {
 			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			newController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
@@ -100,3 +484,77 @@ var _ = Describe("Controller Webhook", func() {
 		})
 	})
 })
+
+// Test_validateExtraConf checks that the webhook denies exactly the ExtraConf
+// with which the Controller reconciler would hold the config, by validating
+// the slurm.conf rendered by the builder.
+func Test_validateExtraConf(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	nodeset := testutils.NewNodeset("cpu", controller, 1)
+	nodeset.Spec.Partition.Enabled = true
+	nodesetName := common.GetSlurmNodeSetName(nodeset)
+	partition := testutils.NewPartition("all", controller)
+	tests := []struct {
+		name      string
+		extraConf string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			extraConf: fmt.Sprintf("MinJobAge=30\nPartitionName=debug Nodes=%s", nodesetName),
+		},
+		{
+			name:      "unknown parameter",
+			extraConf: "SchedulerNewOption=1",
+		},
+		{
+			name:      "malformed",
+			extraConf: "MinJobAge",
+			wantErr:   true,
+		},
+		{
+			name:      "NodeSet defined by the operator",
+			extraConf: fmt.Sprintf("NodeSet=%s Feature=gpu", nodesetName),
+			wantErr:   true,
+		},
+		{
+			name:      "Partition defined by the operator",
+			extraConf: fmt.Sprintf("PartitionName=%s Nodes=ALL", partition.PartitionName()),
+			wantErr:   true,
+		},
+		{
+			name:      "undefined partition nodes",
+			extraConf: "PartitionName=debug Nodes=gpu",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := controller.DeepCopy()
+			controller.Spec.ExtraConf = tt.extraConf
+			c := fake.NewClientBuilder().
+				WithObjects(controller, nodeset.DeepCopy(), partition.DeepCopy()).
+				Build()
+
+			r := &ControllerWebhook{Client: c}
+			entities, err := r.slurmConfEntities(context.TODO(), controller)
+			if err != nil {
+				t.Fatalf("slurmConfEntities() error = %v", err)
+			}
+			_, errs := validateExtraConf(controller.Spec.ExtraConf, controller.Spec.SlurmConf, entities)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateExtraConf() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+
+			config, err := controllerbuilder.New(c).BuildControllerConfig(controller)
+			if err != nil {
+				t.Fatalf("BuildControllerConfig() error = %v", err)
+			}
+			_, reconcileErrs := slurmconf.Validate(config.Data[controllerbuilder.SlurmConfFile])
+			if (len(reconcileErrs) > 0) != tt.wantErr {
+				t.Errorf("slurmconf.Validate() errors = %v, wantErr %v", reconcileErrs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -14,12 +15,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=delete;create;update
@@ -92,6 +95,33 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	if partition := nodeset.Spec.UpdateStrategy.RollingUpdate.Partition; partition != nil {
+		if *partition < 0 {
+			errs = append(errs, fmt.Errorf("rollingUpdate.partition must be >= 0, got %d", *partition))
+		}
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			warns = append(warns, "rollingUpdate.partition is ignored when scalingMode is DaemonSet")
+		}
+	}
+
+	if canary := nodeset.Spec.UpdateStrategy.RollingUpdate.Canary; canary.Enabled {
+		if canary.Replicas < 1 {
+			errs = append(errs, fmt.Errorf("rollingUpdate.canary.replicas must be > 0, got %d", canary.Replicas))
+		}
+		if canary.SoakTime.Duration < time.Minute {
+			errs = append(errs, errors.New("rollingUpdate.canary.soakTime must be at least 1 minute"))
+		}
+		if t := nodeset.Spec.UpdateStrategy.Type; t != "" && t != slinkyv1beta1.RollingUpdateNodeSetStrategyType {
+			warns = append(warns, "rollingUpdate.canary is ignored when updateStrategy.type is not RollingUpdate")
+		}
+	}
+
+	if rollback := nodeset.Spec.UpdateStrategy.Rollback; rollback.Enabled {
+		if rollback.ProgressDeadline.Duration < time.Minute {
+			errs = append(errs, errors.New("updateStrategy.rollback.progressDeadline must be at least 1 minute"))
+		}
+	}
+
 	zeroDuration := metav1.Duration{}
 	if duration := nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration; duration != zeroDuration {
 		if duration.Duration < time.Minute {
@@ -103,6 +133,76 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		errs = append(errs, errors.New("ssh.sssdConfRef.name must not be empty when ssh is enabled"))
 	}
 
+	if autoscaling := nodeset.Spec.Autoscaling; autoscaling.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, errors.New("autoscaling cannot be enabled when scalingMode is DaemonSet"))
+		}
+		if autoscaling.MaxReplicas < 1 {
+			errs = append(errs, fmt.Errorf("autoscaling.maxReplicas must be > 0, got %d", autoscaling.MaxReplicas))
+		}
+		if autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			errs = append(errs, fmt.Errorf("autoscaling.minReplicas (%d) must not be greater than autoscaling.maxReplicas (%d)",
+				autoscaling.MinReplicas, autoscaling.MaxReplicas))
+		}
+		if !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("autoscaling cannot be enabled when partition is disabled, only pending jobs targeting the NodeSet partition are considered"))
+		}
+	}
+
+	if powerSave := nodeset.Spec.PowerSave; powerSave.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, errors.New("powerSave cannot be enabled when scalingMode is DaemonSet"))
+		}
+		if nodeset.Spec.Autoscaling.Enabled {
+			errs = append(errs, errors.New("powerSave and autoscaling cannot both be enabled"))
+		}
+		if nodeset.Spec.Template.PodSpecWrapper.HostNetwork {
+			errs = append(errs, errors.New("powerSave cannot be enabled when template.spec.hostNetwork is true, Slurm node names must be known before pods are scheduled"))
+		}
+		if !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("powerSave requires partition.enabled, SuspendTime is configured on the NodeSet partition"))
+		}
+		if powerSave.MaxNodes < 1 {
+			errs = append(errs, fmt.Errorf("powerSave.maxNodes must be > 0, got %d", powerSave.MaxNodes))
+		}
+		if powerSave.SuspendTime.Duration < time.Minute {
+			errs = append(errs, errors.New("powerSave.suspendTime must be at least 1 minute"))
+		}
+		if powerSave.ResumeTimeout.Duration < time.Minute {
+			errs = append(errs, errors.New("powerSave.resumeTimeout must be at least 1 minute"))
+		}
+	}
+
+	if remediation := nodeset.Spec.Remediation; remediation.Enabled {
+		for _, condType := range remediation.Conditions {
+			if !strings.HasPrefix(string(condType), slurmconditions.PodStatePrefix) {
+				errs = append(errs, fmt.Errorf("remediation.conditions must be Slurm node state conditions (%s*), got %q",
+					slurmconditions.PodStatePrefix, condType))
+			}
+		}
+		if remediation.UnhealthyDuration.Duration < time.Minute {
+			errs = append(errs, errors.New("remediation.unhealthyDuration must be at least 1 minute"))
+		}
+		if mu := remediation.MaxUnhealthy; mu != nil && mu.Type == intstr.Int && mu.IntVal < 0 {
+			errs = append(errs, fmt.Errorf("remediation.maxUnhealthy must be >= 0, got %d", mu.IntVal))
+		}
+		if remediation.MaxRemediations < 1 {
+			errs = append(errs, fmt.Errorf("remediation.maxRemediations must be > 0, got %d", remediation.MaxRemediations))
+		}
+		if remediation.Window.Duration < time.Minute {
+			errs = append(errs, errors.New("remediation.window must be at least 1 minute"))
+		}
+		if remediation.RecreateOnDifferentNode && nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			warns = append(warns, "remediation.recreateOnDifferentNode is ignored when scalingMode is DaemonSet")
+		}
+	}
+
+	for _, key := range nodeset.Spec.NodeFeatures.LabelKeys {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Errorf("nodeFeatures.labelKeys %q: %s", key, msg))
+		}
+	}
+
 	hostname := nodeset.Spec.Template.PodSpecWrapper.Hostname
 	if hostname != "" {
 		for _, msg := range apivalidation.NameIsDNSSubdomain(hostname, true) {
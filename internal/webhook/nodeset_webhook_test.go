@@ -4,6 +4,8 @@
 package webhook
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -12,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -69,6 +72,149 @@ var _ = Describe("NodeSet Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny if autoscaling minReplicas is greater than maxReplicas", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 4
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if autoscaling is enabled in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if autoscaling is enabled without a partition", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if powerSave is enabled without a partition", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.PowerSave = slinkyv1beta1.NodeSetPowerSave{
+				Enabled:       true,
+				MaxNodes:      4,
+				SuspendTime:   metav1.Duration{Duration: 10 * time.Minute},
+				ResumeTimeout: metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if powerSave and autoscaling are both enabled", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+			nodeset.Spec.PowerSave = slinkyv1beta1.NodeSetPowerSave{
+				Enabled:       true,
+				MaxNodes:      4,
+				SuspendTime:   metav1.Duration{Duration: 10 * time.Minute},
+				ResumeTimeout: metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if powerSave is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave = slinkyv1beta1.NodeSetPowerSave{
+				Enabled:       true,
+				MaxNodes:      4,
+				SuspendTime:   metav1.Duration{Duration: 10 * time.Minute},
+				ResumeTimeout: metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the rolling update canary soakTime is too short", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Canary = slinkyv1beta1.NodeSetCanary{
+				Enabled:  true,
+				Replicas: 1,
+				SoakTime: metav1.Duration{Duration: 30 * time.Second},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if the rolling update partition and canary are configured", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 4)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Canary = slinkyv1beta1.NodeSetCanary{
+				Enabled:  true,
+				Replicas: 1,
+				SoakTime: metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the rollback progressDeadline is too short", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.Rollback = slinkyv1beta1.NodeSetRollback{
+				Enabled:          true,
+				ProgressDeadline: metav1.Duration{Duration: 30 * time.Second},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the remediation conditions are not Slurm node states", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Remediation = slinkyv1beta1.NodeSetRemediation{
+				Enabled:           true,
+				Conditions:        []corev1.PodConditionType{corev1.PodReady},
+				UnhealthyDuration: metav1.Duration{Duration: 5 * time.Minute},
+				MaxRemediations:   1,
+				Window:            metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the node feature label keys are invalid", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.NodeFeatures.LabelKeys = []string{"node.kubernetes.io/instance-type", "not a label"}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should admit if all required fields are provided", func(ctx SpecContext) {
 			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions,verbs=delete;create;update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
+
+type PartitionWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var partitionlog = logf.Log.WithName("partition-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *PartitionWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.Partition{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-partition,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=partitions,verbs=create;update,versions=v1beta1,name=partition-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.Partition] = &PartitionWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateCreate(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate create", "partition", klog.KObj(partition))
+
+	warns, errs := r.validatePartition(ctx, partition)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateUpdate(ctx context.Context, oldPartition, newPartition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate update", "newPartition", klog.KObj(newPartition))
+
+	warns, errs := r.validatePartition(ctx, newPartition)
+
+	if !apiequality.Semantic.DeepEqual(newPartition.Spec.ControllerRef, oldPartition.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateDelete(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate delete", "partition", klog.KObj(partition))
+
+	return nil, nil
+}
+
+// partitionConfigReservedKeys are the partition options which cannot be set by
+// config, because they are set by the operator.
+var partitionConfigReservedKeys = []string{
+	"PartitionName",
+	"Nodes",
+}
+
+func (r *PartitionWebhook) validatePartition(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	spec := partition.Spec
+
+	if spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	name := partition.PartitionName()
+	if strings.EqualFold(name, "DEFAULT") {
+		errs = append(errs, fmt.Errorf("name %q is reserved by Slurm", name))
+	}
+	if strings.ContainsAny(name, " \t=,#") {
+		errs = append(errs, fmt.Errorf("name %q must not contain whitespace, '=', ',' or '#'", name))
+	}
+	if spec.ControllerRef.Name != "" {
+		errs = append(errs, r.validatePartitionName(ctx, partition)...)
+	}
+
+	if spec.NodeSetSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.NodeSetSelector); err != nil {
+			errs = append(errs, fmt.Errorf("nodeSetSelector is invalid: %w", err))
+		}
+	}
+
+	for _, limit := range []struct {
+		field    string
+		duration *metav1.Duration
+	}{
+		{"maxTime", spec.MaxTime},
+		{"defaultTime", spec.DefaultTime},
+	} {
+		if limit.duration == nil {
+			continue
+		}
+		if d := limit.duration.Duration; d < time.Minute {
+			errs = append(errs, fmt.Errorf("%s must be at least 1m, got %v", limit.field, d))
+		} else if d%time.Minute != 0 {
+			warns = append(warns, fmt.Sprintf("%s %v is rounded down to whole minutes", limit.field, d))
+		}
+	}
+	if spec.MaxTime != nil && spec.DefaultTime != nil && spec.DefaultTime.Duration > spec.MaxTime.Duration {
+		errs = append(errs, fmt.Errorf("defaultTime (%v) must not exceed maxTime (%v)", spec.DefaultTime.Duration, spec.MaxTime.Duration))
+	}
+
+	errs = append(errs, validateSlurmdbNames("allowAccounts", spec.AllowAccounts...)...)
+	if spec.QOS != "" {
+		errs = append(errs, validateSlurmdbNames("qos", spec.QOS)...)
+	}
+
+	for _, option := range strings.Fields(spec.Config) {
+		key, _, _ := strings.Cut(option, "=")
+		for _, reserved := range partitionConfigReservedKeys {
+			if strings.EqualFold(key, reserved) {
+				errs = append(errs, fmt.Errorf("config must not set %s, it is set by the operator", reserved))
+			}
+		}
+	}
+
+	return warns, errs
+}
+
+// validatePartitionName rejects a partition name which is already used by
+// another Partition, or by a NodeSet partition, of the same Controller.
+func (r *PartitionWebhook) validatePartitionName(ctx context.Context, partition *slinkyv1beta1.Partition) []error {
+	var errs []error
+
+	name := partition.PartitionName()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      partition.Spec.ControllerRef.Name,
+			Namespace: partition.Namespace,
+		},
+	}
+	refResolver := refresolver.New(r.Client)
+
+	partitionList, err := refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		return append(errs, fmt.Errorf("failed to list Partitions: %w", err))
+	}
+	for _, other := range partitionList.Items {
+		if other.Name == partition.Name {
+			continue
+		}
+		if other.PartitionName() == name {
+			errs = append(errs, fmt.Errorf("name %q is already used by Partition %q", name, other.Name))
+		}
+	}
+
+	nodesetList, err := refResolver.GetNodeSetsForController(ctx, controller)
+	if err != nil {
+		return append(errs, fmt.Errorf("failed to list NodeSets: %w", err))
+	}
+	for _, nodeset := range nodesetList.Items {
+		if !nodeset.Spec.Partition.Enabled {
+			continue
+		}
+		if common.GetSlurmNodeSetName(&nodeset) == name {
+			errs = append(errs, fmt.Errorf("name %q is already used by the partition of NodeSet %q", name, nodeset.Name))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Partition Webhook", func() {
+	Context("When creating Partition under Validating Webhook", func() {
+		It("Should admit a Create for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.NodeSetSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "debug"},
+			}
+			partition.Spec.MaxTime = &metav1.Duration{Duration: time.Hour}
+			partition.Spec.DefaultTime = &metav1.Duration{Duration: 10 * time.Minute}
+			partition.Spec.PriorityTier = ptr.To(int32(10))
+			partition.Spec.AllowAccounts = []string{"science"}
+			partition.Spec.QOS = "high"
+
+			warns, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("Should deny if defaultTime exceeds maxTime", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.MaxTime = &metav1.Duration{Duration: 10 * time.Minute}
+			partition.Spec.DefaultTime = &metav1.Duration{Duration: time.Hour}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if maxTime is less than a minute", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.MaxTime = &metav1.Duration{Duration: 30 * time.Second}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if maxTime is not whole minutes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.MaxTime = &metav1.Duration{Duration: 90 * time.Second}
+
+			warns, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(HaveLen(1))
+		})
+
+		It("Should deny if the nodeSetSelector is invalid", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.NodeSetSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: "Bogus"},
+				},
+			}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the partition name is reserved", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.Name = "DEFAULT"
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the partition name is used by another Partition", func(ctx SpecContext) {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			other := testutils.NewPartition("gpu", controller)
+			Expect(k8sClient.Create(ctx, other)).To(Succeed())
+			DeferCleanup(func(ctx SpecContext) {
+				Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+			})
+			webhook := PartitionWebhook{Client: k8sClient}
+
+			partition := testutils.NewPartition("gpu-large", controller)
+			partition.Spec.Name = "gpu"
+
+			_, err := webhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+
+			By("Admitting the same name for another Controller")
+			controller2 := testutils.NewController("cluster2", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition = testutils.NewPartition("gpu-large", controller2)
+			partition.Spec.Name = "gpu"
+
+			_, err = webhook.ValidateCreate(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the partition name is used by a NodeSet partition", func(ctx SpecContext) {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("cpu", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			Expect(k8sClient.Create(ctx, nodeset)).To(Succeed())
+			DeferCleanup(func(ctx SpecContext) {
+				Expect(k8sClient.Delete(ctx, nodeset)).To(Succeed())
+			})
+			webhook := PartitionWebhook{Client: k8sClient}
+
+			partition := testutils.NewPartition("cpu", controller)
+
+			_, err := webhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if config sets Nodes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.Config = "State=UP nodes=foo"
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if an allowed account name contains a comma", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+			partition.Spec.AllowAccounts = []string{"science,physics"}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating Partition under Validating Webhook", func() {
+		It("Should admit an Update for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldPartition := testutils.NewPartition("debug", controller)
+			newPartition := testutils.NewPartition("debug", controller)
+			newPartition.Spec.Default = true
+
+			_, err := partitionWebhook.ValidateUpdate(ctx, oldPartition, newPartition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the controllerRef changes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller2 := testutils.NewController("cluster2", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldPartition := testutils.NewPartition("debug", controller)
+			newPartition := testutils.NewPartition("debug", controller2)
+
+			_, err := partitionWebhook.ValidateUpdate(ctx, oldPartition, newPartition)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When deleting Partition under Validating Webhook", func() {
+		It("Should admit a Delete for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("debug", controller)
+
+			_, err := partitionWebhook.ValidateDelete(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
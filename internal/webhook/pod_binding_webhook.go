@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -19,6 +20,8 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topology"
 )
 
 type PodBindingWebhook struct {
@@ -37,6 +40,7 @@ func (r *PodBindingWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;update;patch;watch
 // +kubebuilder:rbac:groups="",resources=pods/binding,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers;nodesets,verbs=get;list;watch
 // +kubebuilder:webhook:path=/mutate--v1-binding,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups="",resources=pods/binding,verbs=create,versions=v1,name=podsbinding-v1.kb.io,admissionReviewVersions=v1
 
 var _ admission.Defaulter[*corev1.Binding] = &PodBindingWebhook{}
@@ -66,7 +70,12 @@ func (r *PodBindingWebhook) Default(ctx context.Context, binding *corev1.Binding
 		return err
 	}
 
-	topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+	topologies, err := r.getTopologies(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	topologySpec := topology.NodeSpec(node, topologies)
 	mutateFn := func(pod *corev1.Pod) error {
 		pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
 		return nil
@@ -83,3 +92,30 @@ func (r *PodBindingWebhook) Default(ctx context.Context, binding *corev1.Binding
 
 	return nil
 }
+
+// getTopologies returns the topologies of the Controller of the NodeSet of the pod.
+func (r *PodBindingWebhook) getTopologies(ctx context.Context, pod *corev1.Pod) ([]slinkyv1beta1.ControllerTopology, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != slinkyv1beta1.NodeSetKind {
+		return nil, nil
+	}
+
+	nodeset := &slinkyv1beta1.NodeSet{}
+	nodesetKey := types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}
+	if err := r.Get(ctx, nodesetKey, nodeset); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	controller, err := refresolver.New(r.Client).GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return controller.Spec.Topology, nil
+}
@@ -10,6 +10,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -19,6 +21,7 @@ import (
 )
 
 func TestPodBindingWebhook_Default(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
 	workerPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "worker-0",
@@ -57,6 +60,47 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 		},
 	}
 
+	nodeWithZone := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-3",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+			},
+		},
+	}
+
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			Topology: []slinkyv1beta1.ControllerTopology{
+				{
+					Name: "topo-block",
+					Block: &slinkyv1beta1.ControllerTopologyBlock{
+						LabelKey: corev1.LabelTopologyZone,
+					},
+				},
+			},
+		},
+	}
+
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slinky",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: corev1.LocalObjectReference{Name: controller.Name},
+		},
+	}
+
+	nodesetPod := workerPod.DeepCopy()
+	nodesetPod.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(nodeset, slinkyv1beta1.NodeSetGVK),
+	}
+
 	type args struct {
 		ctx     context.Context
 		binding *corev1.Binding
@@ -103,6 +147,40 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 			wantTopology:  "",
 			checkTopology: true,
 		},
+		{
+			name:   "NodeSet pod gets topology from node labels",
+			client: fake.NewFakeClient(nodesetPod.DeepCopy(), nodeset.DeepCopy(), controller.DeepCopy(), nodeWithZone.DeepCopy()),
+			args: args{
+				ctx: context.TODO(),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodesetPod.Name,
+						Namespace: nodesetPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithZone.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "topo-block:zone-a",
+			checkTopology: true,
+		},
+		{
+			name:   "NodeSet pod gets topology from node annotation over labels",
+			client: fake.NewFakeClient(nodesetPod.DeepCopy(), nodeset.DeepCopy(), controller.DeepCopy(), nodeWithTopology.DeepCopy()),
+			args: args{
+				ctx: context.TODO(),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodesetPod.Name,
+						Namespace: nodesetPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithTopology.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "topo-switch:s2,topo-block:b2",
+			checkTopology: true,
+		},
 		{
 			name:   "Non-worker pod is skipped",
 			client: fake.NewFakeClient(nonWorkerPod.DeepCopy()),
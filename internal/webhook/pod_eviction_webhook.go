@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// evictionRetrySeconds is how long a denied eviction should wait before it is
+// retried (e.g. by `kubectl drain`).
+const evictionRetrySeconds = 10
+
+// PodEvictionWebhook holds the eviction of worker pods until their Slurm node
+// is drained. Each denied eviction cordons the pod, drains its Slurm node, and
+// records the eviction time, so that the NodeSet controller can undo the drain
+// if the evictions stop (e.g. `kubectl drain` was aborted).
+type PodEvictionWebhook struct {
+	client.Client
+	SlurmControl slurmcontrol.SlurmControlInterface
+}
+
+// log is for logging in this package.
+var evictionlog = logf.Log.WithName("eviction-resource")
+
+func (r *PodEvictionWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &policyv1.Eviction{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;update;patch;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers;restapis,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:webhook:path=/validate-policy-v1-eviction,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,sideEffects=NoneOnDryRun,groups="",resources=pods/eviction,verbs=create,versions=v1,name=podseviction-v1.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*policyv1.Eviction] = &PodEvictionWebhook{}
+
+// ValidateCreate implements admission.Validator.
+func (r *PodEvictionWebhook) ValidateCreate(ctx context.Context, eviction *policyv1.Eviction) (admission.Warnings, error) {
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKeyFromObject(eviction)
+	if err := r.Get(ctx, podKey, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not fetch pod for eviction: %w", err)
+	}
+
+	if pod.Labels[labels.AppLabel] != labels.WorkerApp {
+		evictionlog.V(1).Info("ignoring pod", "pod", klog.KObj(pod))
+		return nil, nil
+	}
+	// There is no Slurm node to drain.
+	if podutils.IsTerminating(pod) || !slurmconditions.IsNodeRegistered(&pod.Status) {
+		return nil, nil
+	}
+
+	if slurmconditions.IsNodeDrained(&pod.Status) {
+		evictionlog.Info("allow eviction, Slurm node is drained", "pod", klog.KObj(pod))
+		return nil, nil
+	}
+	deadline, _ := structutils.GetTimeFromAnnotations(pod.Annotations, slinkyv1beta1.AnnotationPodDeadline)
+	if !deadline.IsZero() && time.Now().After(deadline) {
+		evictionlog.Info("allow eviction, Slurm node deadline has passed", "pod", klog.KObj(pod), "deadline", deadline)
+		return nil, nil
+	}
+
+	if !isDryRun(ctx) {
+		if err := r.cordonAndDrain(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := fmt.Sprintf("Cannot evict pod %s until its Slurm node %s is drained",
+		klog.KObj(pod), nodesetutils.GetSlurmNodeName(pod))
+	if !deadline.IsZero() {
+		msg = fmt.Sprintf("%s, or its running jobs reach their deadline (%s)", msg, deadline.Format(time.RFC3339))
+	}
+	// TooManyRequests makes the eviction be retried, as with a PodDisruptionBudget.
+	return nil, apierrors.NewTooManyRequests(msg, evictionRetrySeconds)
+}
+
+// cordonAndDrain cordons the pod for eviction, and drains its Slurm node.
+//
+// The eviction time is recorded only when the webhook owns the cordon, so that
+// the NodeSet controller never uncordons a pod cordoned for another reason.
+func (r *PodEvictionWebhook) cordonAndDrain(ctx context.Context, pod *corev1.Pod) error {
+	if !podutils.IsPodCordon(pod) || podutils.IsPodEviction(pod) {
+		evictionlog.Info("cordon pod for eviction", "pod", klog.KObj(pod))
+		mutateFn := func(pod *corev1.Pod) error {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+			pod.Annotations[slinkyv1beta1.AnnotationPodEviction] = time.Now().Format(time.RFC3339)
+			return nil
+		}
+		if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+			return fmt.Errorf("failed to cordon pod for eviction: %w", err)
+		}
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != slinkyv1beta1.NodeSetKind {
+		return nil
+	}
+	nodeset := &slinkyv1beta1.NodeSet{}
+	nodesetKey := types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}
+	if err := r.Get(ctx, nodesetKey, nodeset); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not fetch nodeset for eviction: %w", err)
+	}
+
+	reason := fmt.Sprintf("Pod (%s) is pending eviction", klog.KObj(pod))
+	if err := r.SlurmControl.MakeNodeDrain(ctx, nodeset, pod, reason, false); err != nil {
+		// The eviction is denied regardless, and the NodeSet controller drains the cordoned pod.
+		evictionlog.Error(err, "failed to drain Slurm node for eviction", "pod", klog.KObj(pod))
+	}
+
+	return nil
+}
+
+// ValidateUpdate implements admission.Validator.
+func (r *PodEvictionWebhook) ValidateUpdate(ctx context.Context, oldEviction, newEviction *policyv1.Eviction) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator.
+func (r *PodEvictionWebhook) ValidateDelete(ctx context.Context, eviction *policyv1.Eviction) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// isDryRun returns true if the admission request is a dry run, which must not
+// have side effects.
+func isDryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	return ptr.Deref(req.DryRun, false)
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// fakeSlurmControl records the reasons of the Slurm nodes drained through it.
+type fakeSlurmControl struct {
+	slurmcontrol.SlurmControlInterface
+	drained map[string]string
+}
+
+func (f *fakeSlurmControl) MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string, overrideReason bool) error {
+	f.drained[pod.Name] = reason
+	return nil
+}
+
+func TestPodEvictionWebhook_ValidateCreate(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+		},
+	}
+	newWorkerPod := func(annotations map[string]string, conditions ...corev1.PodConditionType) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker-0",
+				Namespace: corev1.NamespaceDefault,
+				Labels: map[string]string{
+					labels.AppLabel: labels.WorkerApp,
+				},
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(nodeset, slinkyv1beta1.NodeSetGVK),
+				},
+			},
+		}
+		for _, condType := range conditions {
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:   condType,
+				Status: corev1.ConditionTrue,
+			})
+		}
+		return pod
+	}
+	nonWorkerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-0",
+			Namespace: corev1.NamespaceDefault,
+			Labels: map[string]string{
+				labels.AppLabel: "nginx",
+			},
+		},
+	}
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-0",
+			Namespace: corev1.NamespaceDefault,
+		},
+	}
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name         string
+		client       client.Client
+		wantDenied   bool
+		wantCordon   bool
+		wantEviction bool
+		wantDrain    bool
+	}{
+		{
+			name:   "Pod not found is allowed",
+			client: fake.NewFakeClient(),
+		},
+		{
+			name:   "Non-worker pod is allowed",
+			client: fake.NewFakeClient(nonWorkerPod.DeepCopy()),
+		},
+		{
+			name:   "Unregistered Slurm node is allowed",
+			client: fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(nil)),
+		},
+		{
+			name:         "Busy Slurm node is cordoned, drained, and denied",
+			client:       fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(nil, slurmconditions.PodConditionAllocated)),
+			wantDenied:   true,
+			wantCordon:   true,
+			wantEviction: true,
+			wantDrain:    true,
+		},
+		{
+			name: "Slurm node cordoned for a previous eviction is drained and denied",
+			client: fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(map[string]string{
+				slinkyv1beta1.AnnotationPodCordon:   "true",
+				slinkyv1beta1.AnnotationPodEviction: past,
+			}, slurmconditions.PodConditionAllocated, slurmconditions.PodConditionDrain)),
+			wantDenied:   true,
+			wantCordon:   true,
+			wantEviction: true,
+			wantDrain:    true,
+		},
+		{
+			name: "Draining Slurm node is denied until its deadline",
+			client: fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(map[string]string{
+				slinkyv1beta1.AnnotationPodCordon:   "true",
+				slinkyv1beta1.AnnotationPodDeadline: future,
+			}, slurmconditions.PodConditionAllocated, slurmconditions.PodConditionDrain)),
+			wantDenied: true,
+			wantCordon: true,
+			wantDrain:  true,
+		},
+		{
+			name: "Draining Slurm node is allowed after its deadline",
+			client: fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(map[string]string{
+				slinkyv1beta1.AnnotationPodCordon:   "true",
+				slinkyv1beta1.AnnotationPodDeadline: past,
+			}, slurmconditions.PodConditionAllocated, slurmconditions.PodConditionDrain)),
+			wantCordon: true,
+		},
+		{
+			name: "Drained Slurm node is allowed",
+			client: fake.NewFakeClient(nodeset.DeepCopy(), newWorkerPod(map[string]string{
+				slinkyv1beta1.AnnotationPodCordon: "true",
+			}, slurmconditions.PodConditionIdle, slurmconditions.PodConditionDrain)),
+			wantCordon: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			slurmControl := &fakeSlurmControl{drained: map[string]string{}}
+			r := &PodEvictionWebhook{Client: tt.client, SlurmControl: slurmControl}
+			_, err := r.ValidateCreate(ctx, eviction.DeepCopy())
+			if gotDenied := apierrors.IsTooManyRequests(err); gotDenied != tt.wantDenied {
+				t.Errorf("PodEvictionWebhook.ValidateCreate() error = %v, wantDenied %v", err, tt.wantDenied)
+			}
+			if !tt.wantDenied && err != nil {
+				t.Errorf("PodEvictionWebhook.ValidateCreate() error = %v", err)
+			}
+			if reason, got := slurmControl.drained[eviction.Name]; got != tt.wantDrain {
+				t.Errorf("MakeNodeDrain() called = %v, want %v", got, tt.wantDrain)
+			} else if got && reason != "Pod (default/worker-0) is pending eviction" {
+				t.Errorf("MakeNodeDrain() reason = %q", reason)
+			}
+
+			pod := &corev1.Pod{}
+			if err := tt.client.Get(ctx, client.ObjectKeyFromObject(eviction), pod); err != nil {
+				return
+			}
+			if pod.Labels[labels.AppLabel] != labels.WorkerApp {
+				return
+			}
+			if got := podutils.IsPodCordon(pod); got != tt.wantCordon {
+				t.Errorf("IsPodCordon() = %v, want %v", got, tt.wantCordon)
+			}
+			if got := podutils.IsPodEviction(pod); got != tt.wantEviction {
+				t.Errorf("IsPodEviction() = %v, want %v", got, tt.wantEviction)
+			}
+			if evictionTime := pod.Annotations[slinkyv1beta1.AnnotationPodEviction]; evictionTime == past {
+				t.Errorf("eviction time was not refreshed, got %v", evictionTime)
+			}
+		})
+	}
+}
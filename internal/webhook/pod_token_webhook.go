@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+// PodTokenWebhook projects the Secret of a Token into the pods which request it
+// by annotations, and opt in by the LabelPodTokenInject label.
+type PodTokenWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var podtokenlog = logf.Log.WithName("pod-token-resource")
+
+func (r *PodTokenWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1.Pod{}).
+		WithDefaulter(r).
+		Complete()
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers;tokens,verbs=get;list;watch
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,matchPolicy=Equivalent,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=pods-v1.kb.io,admissionReviewVersions=v1
+
+var _ admission.Defaulter[*corev1.Pod] = &PodTokenWebhook{}
+
+// Default implements admission.CustomDefaulter.
+//
+// A pod which requests a Token that does not exist is denied, unless it also
+// requests it by username and Controller, in which case the Token is created
+// by the Token controller once the pod exists to own it. The username must be
+// allowed by the Controller.
+//
+// NOTE: the webhook configuration only selects pods with LabelPodTokenInject.
+func (r *PodTokenWebhook) Default(ctx context.Context, pod *corev1.Pod) error {
+	if !common.IsPodTokenInjected(pod) {
+		return nil
+	}
+	tokenName := pod.Annotations[slinkyv1beta1.AnnotationPodToken]
+	onDemand := pod.Annotations[slinkyv1beta1.AnnotationTokenUsername] != "" &&
+		pod.Annotations[slinkyv1beta1.AnnotationTokenController] != ""
+	if tokenName == "" && !onDemand {
+		return nil
+	}
+
+	// The namespace may be omitted from the pod, but not from the request.
+	if pod.Namespace == "" {
+		if req, err := admission.RequestFromContext(ctx); err == nil {
+			pod.Namespace = req.Namespace
+		}
+	}
+
+	if tokenName == "" {
+		base := "token-"
+		switch {
+		case pod.Name != "":
+			base = pod.Name + "-"
+		case pod.GenerateName != "":
+			base = pod.GenerateName
+		}
+		tokenName = names.SimpleNameGenerator.GenerateName(base)
+		pod.Annotations[slinkyv1beta1.AnnotationPodToken] = tokenName
+	}
+
+	token := &slinkyv1beta1.Token{}
+	tokenKey := types.NamespacedName{Namespace: pod.Namespace, Name: tokenName}
+	if err := r.Get(ctx, tokenKey, token); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if token = common.NewPodToken(pod); token == nil {
+			return fmt.Errorf("Token %s not found, annotate the pod with %s and %s to create it",
+				tokenKey, slinkyv1beta1.AnnotationTokenUsername, slinkyv1beta1.AnnotationTokenController)
+		}
+		if err := r.validateTokenUser(ctx, token); err != nil {
+			return err
+		}
+	}
+
+	common.ProjectPodToken(pod, token.SecretRef())
+
+	podtokenlog.V(1).Info("projected token into pod", "pod", klog.KObj(pod), "token", tokenName)
+
+	return nil
+}
+
+// validateTokenUser returns an error if the Controller of the Token does not
+// allow its username.
+func (r *PodTokenWebhook) validateTokenUser(ctx context.Context, token *slinkyv1beta1.Token) error {
+	controller, err := refresolver.New(r.Client).GetController(ctx, *token.Spec.ControllerRef, token.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("Controller %s/%s not found", token.Namespace, token.Spec.ControllerRef.Name)
+		}
+		return err
+	}
+	if !controller.IsTokenUserAllowed(token.Spec.Username) {
+		return fmt.Errorf("username %q is not allowed by Controller %s", token.Spec.Username, klog.KObj(controller))
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestPodTokenWebhook_Default(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	token := &slinkyv1beta1.Token{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.TokenSpec{
+			Username: "bob",
+			SecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "bob-jwt",
+				},
+				Key: "token",
+			},
+		},
+	}
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil)
+	controller.Spec.TokenUsers = []string{slinkyv1beta1.TokenUsersAll}
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "notebook",
+				Namespace: corev1.NamespaceDefault,
+				Labels: map[string]string{
+					slinkyv1beta1.LabelPodTokenInject: "true",
+				},
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "init"},
+				},
+				Containers: []corev1.Container{
+					{Name: "notebook"},
+				},
+			},
+		}
+	}
+	type fields struct {
+		Client client.Client
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		pod           *corev1.Pod
+		wantSecretRef *corev1.SecretKeySelector
+		wantTokenName string
+		wantErr       bool
+	}{
+		{
+			name: "Not annotated",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			pod: newPod(nil),
+		},
+		{
+			name: "Not labeled",
+			fields: fields{
+				Client: fake.NewFakeClient(token.DeepCopy()),
+			},
+			pod: func() *corev1.Pod {
+				pod := newPod(map[string]string{
+					slinkyv1beta1.AnnotationPodToken: "existing",
+				})
+				pod.Labels = nil
+				return pod
+			}(),
+		},
+		{
+			name: "Existing Token",
+			fields: fields{
+				Client: fake.NewFakeClient(token.DeepCopy()),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationPodToken: "existing",
+			}),
+			wantSecretRef: new(token.SecretRef()),
+			wantTokenName: "existing",
+		},
+		{
+			name: "Missing Token",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationPodToken: "missing",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Token on demand",
+			fields: fields{
+				Client: fake.NewFakeClient(controller.DeepCopy()),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationPodToken:        "alice",
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			wantSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "alice-jwt-alice",
+				},
+				Key: "SLURM_JWT",
+			},
+			wantTokenName: "alice",
+		},
+		{
+			name: "Token name generated",
+			fields: fields{
+				Client: fake.NewFakeClient(controller.DeepCopy()),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			wantTokenName: "notebook-",
+		},
+		{
+			name: "Privileged username",
+			fields: fields{
+				Client: fake.NewFakeClient(controller.DeepCopy()),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "root",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Username not allowed",
+			fields: fields{
+				Client: fake.NewFakeClient(func() *slinkyv1beta1.Controller {
+					controller := controller.DeepCopy()
+					controller.Spec.TokenUsers = []string{"bob"}
+					return controller
+				}()),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Controller not found",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			pod: newPod(map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PodTokenWebhook{
+				Client: tt.fields.Client,
+			}
+			pod := tt.pod.DeepCopy()
+			err := r.Default(context.TODO(), pod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PodTokenWebhook.Default() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantTokenName == "" {
+				if len(pod.Spec.Volumes) != 0 {
+					t.Errorf("PodTokenWebhook.Default() Volumes = %v, want none", pod.Spec.Volumes)
+				}
+				return
+			}
+			tokenName := pod.Annotations[slinkyv1beta1.AnnotationPodToken]
+			if !strings.HasPrefix(tokenName, tt.wantTokenName) {
+				t.Errorf("PodTokenWebhook.Default() Token = %v, want %v", tokenName, tt.wantTokenName)
+			}
+			if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].Name != common.PodTokenVolume {
+				t.Fatalf("PodTokenWebhook.Default() Volumes = %v", pod.Spec.Volumes)
+			}
+			if tt.wantSecretRef != nil && pod.Spec.Volumes[0].Secret.SecretName != tt.wantSecretRef.Name {
+				t.Errorf("PodTokenWebhook.Default() SecretName = %v, want %v",
+					pod.Spec.Volumes[0].Secret.SecretName, tt.wantSecretRef.Name)
+			}
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != common.PodTokenDir {
+					t.Errorf("PodTokenWebhook.Default() %s VolumeMounts = %v", container.Name, container.VolumeMounts)
+				}
+				if len(container.Env) != 1 || container.Env[0].Name != common.PodTokenEnv {
+					t.Fatalf("PodTokenWebhook.Default() %s Env = %v", container.Name, container.Env)
+				}
+				if tt.wantSecretRef != nil && *container.Env[0].ValueFrom.SecretKeyRef != *tt.wantSecretRef {
+					t.Errorf("PodTokenWebhook.Default() %s SecretKeyRef = %v, want %v",
+						container.Name, container.Env[0].ValueFrom.SecretKeyRef, tt.wantSecretRef)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts,verbs=delete;create;update
+
+type SlurmAccountWebhook struct{}
+
+// log is for logging in this package.
+var slurmaccountlog = logf.Log.WithName("slurmaccount-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmAccountWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.SlurmAccount{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmaccount,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmaccounts,verbs=create;update,versions=v1beta1,name=slurmaccount-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.SlurmAccount] = &SlurmAccountWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateCreate(ctx context.Context, account *slinkyv1beta1.SlurmAccount) (admission.Warnings, error) {
+	slurmaccountlog.Info("validate create", "slurmaccount", klog.KObj(account))
+
+	warns, errs := r.validateSlurmAccount(account)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateUpdate(ctx context.Context, oldAccount, newAccount *slinkyv1beta1.SlurmAccount) (admission.Warnings, error) {
+	slurmaccountlog.Info("validate update", "newSlurmAccount", klog.KObj(newAccount))
+
+	warns, errs := r.validateSlurmAccount(newAccount)
+
+	if !apiequality.Semantic.DeepEqual(newAccount.Spec.ControllerRef, oldAccount.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+	if newAccount.AccountName() != oldAccount.AccountName() {
+		errs = append(errs, errors.New("cannot change the account name after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateDelete(ctx context.Context, account *slinkyv1beta1.SlurmAccount) (admission.Warnings, error) {
+	slurmaccountlog.Info("validate delete", "slurmaccount", klog.KObj(account))
+
+	return nil, nil
+}
+
+func (r *SlurmAccountWebhook) validateSlurmAccount(account *slinkyv1beta1.SlurmAccount) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if account.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	errs = append(errs, validateSlurmdbNames("name", account.AccountName())...)
+	errs = append(errs, validateSlurmdbNames("parentAccount", account.Spec.ParentAccount)...)
+	errs = append(errs, validateSlurmdbNames("qos", account.Spec.QOS...)...)
+
+	return warns, errs
+}
+
+// validateSlurmdbNames checks that the names are valid in slurmdbd, which
+// stores account and QOS names in lowercase.
+func validateSlurmdbNames(field string, names ...string) []error {
+	var errs []error
+	for _, name := range names {
+		if name != strings.ToLower(name) {
+			errs = append(errs, fmt.Errorf("%s %q must be lowercase", field, name))
+		}
+		if strings.ContainsAny(name, " ,'\"") {
+			errs = append(errs, fmt.Errorf("%s %q must not contain spaces, commas, or quotes", field, name))
+		}
+	}
+	return errs
+}
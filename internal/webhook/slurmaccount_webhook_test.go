@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("SlurmAccount Webhook", func() {
+	Context("When creating SlurmAccount under Validating Webhook", func() {
+		It("Should admit a Create for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewSlurmAccount("science", controller)
+			account.Spec.ParentAccount = "root"
+			account.Spec.QOS = []string{"normal", "high"}
+
+			_, err := slurmAccountWebhook.ValidateCreate(ctx, account)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the account name is not lowercase", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewSlurmAccount("science", controller)
+			account.Spec.Name = "Science"
+
+			_, err := slurmAccountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if a QOS name contains a comma", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewSlurmAccount("science", controller)
+			account.Spec.QOS = []string{"normal,high"}
+
+			_, err := slurmAccountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating SlurmAccount under Validating Webhook", func() {
+		It("Should admit an Update for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldAccount := testutils.NewSlurmAccount("science", controller)
+			newAccount := testutils.NewSlurmAccount("science", controller)
+			newAccount.Spec.Fairshare = ptr.To(int32(10))
+
+			_, err := slurmAccountWebhook.ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the account name changes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldAccount := testutils.NewSlurmAccount("science", controller)
+			newAccount := testutils.NewSlurmAccount("science", controller)
+			newAccount.Spec.Name = "physics"
+
+			_, err := slurmAccountWebhook.ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the controllerRef changes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller2 := testutils.NewController("cluster2", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldAccount := testutils.NewSlurmAccount("science", controller)
+			newAccount := testutils.NewSlurmAccount("science", controller2)
+
+			_, err := slurmAccountWebhook.ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When deleting SlurmAccount under Validating Webhook", func() {
+		It("Should admit a Delete for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewSlurmAccount("science", controller)
+
+			_, err := slurmAccountWebhook.ValidateDelete(ctx, account)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
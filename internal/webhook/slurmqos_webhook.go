@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmqoses,verbs=delete;create;update
+
+type SlurmQOSWebhook struct{}
+
+// log is for logging in this package.
+var slurmqoslog = logf.Log.WithName("slurmqos-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmQOSWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.SlurmQOS{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmqos,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmqoses,verbs=create;update,versions=v1beta1,name=slurmqos-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.SlurmQOS] = &SlurmQOSWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateCreate(ctx context.Context, qos *slinkyv1beta1.SlurmQOS) (admission.Warnings, error) {
+	slurmqoslog.Info("validate create", "slurmqos", klog.KObj(qos))
+
+	warns, errs := r.validateSlurmQOS(qos)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateUpdate(ctx context.Context, oldQOS, newQOS *slinkyv1beta1.SlurmQOS) (admission.Warnings, error) {
+	slurmqoslog.Info("validate update", "newSlurmQOS", klog.KObj(newQOS))
+
+	warns, errs := r.validateSlurmQOS(newQOS)
+
+	if !apiequality.Semantic.DeepEqual(newQOS.Spec.ControllerRef, oldQOS.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+	if newQOS.QOSName() != oldQOS.QOSName() {
+		errs = append(errs, errors.New("cannot change the QOS name after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateDelete(ctx context.Context, qos *slinkyv1beta1.SlurmQOS) (admission.Warnings, error) {
+	slurmqoslog.Info("validate delete", "slurmqos", klog.KObj(qos))
+
+	return nil, nil
+}
+
+func (r *SlurmQOSWebhook) validateSlurmQOS(qos *slinkyv1beta1.SlurmQOS) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if qos.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	errs = append(errs, validateSlurmdbNames("name", qos.QOSName())...)
+
+	if d := qos.Spec.MaxWallDurationPerJob; d != nil {
+		if d.Duration < time.Minute {
+			errs = append(errs, fmt.Errorf("maxWallDurationPerJob must be at least 1m, got %v", d.Duration))
+		} else if d.Duration%time.Minute != 0 {
+			warns = append(warns, fmt.Sprintf("maxWallDurationPerJob %v is rounded down to whole minutes", d.Duration))
+		}
+	}
+
+	errs = append(errs, validateTRESLimits("maxTRESPerJob", qos.Spec.MaxTRESPerJob)...)
+	errs = append(errs, validateTRESLimits("maxTRESPerUser", qos.Spec.MaxTRESPerUser)...)
+
+	return warns, errs
+}
+
+func validateTRESLimits(field string, limits map[string]int64) []error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(limits)) {
+		if limits[name] < 0 {
+			errs = append(errs, fmt.Errorf("%s[%s] must be non-negative, got %d", field, name, limits[name]))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("SlurmQOS Webhook", func() {
+	Context("When creating SlurmQOS under Validating Webhook", func() {
+		It("Should admit a Create for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewSlurmQOS("high", controller)
+			qos.Spec.MaxWallDurationPerJob = &metav1.Duration{Duration: 2 * time.Hour}
+			qos.Spec.MaxTRESPerJob = map[string]int64{"cpu": 64, "gres/gpu": 8}
+
+			warnings, err := slurmQOSWebhook.ValidateCreate(ctx, qos)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn if maxWallDurationPerJob is not in whole minutes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewSlurmQOS("high", controller)
+			qos.Spec.MaxWallDurationPerJob = &metav1.Duration{Duration: 90 * time.Second}
+
+			warnings, err := slurmQOSWebhook.ValidateCreate(ctx, qos)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).NotTo(BeEmpty())
+		})
+
+		It("Should deny if maxWallDurationPerJob is less than a minute", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewSlurmQOS("high", controller)
+			qos.Spec.MaxWallDurationPerJob = &metav1.Duration{Duration: 30 * time.Second}
+
+			_, err := slurmQOSWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if a TRES limit is negative", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewSlurmQOS("high", controller)
+			qos.Spec.MaxTRESPerUser = map[string]int64{"cpu": -1}
+
+			_, err := slurmQOSWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating SlurmQOS under Validating Webhook", func() {
+		It("Should deny if the QOS name changes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldQOS := testutils.NewSlurmQOS("high", controller)
+			newQOS := testutils.NewSlurmQOS("high", controller)
+			newQOS.Spec.Name = "urgent"
+
+			_, err := slurmQOSWebhook.ValidateUpdate(ctx, oldQOS, newQOS)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When deleting SlurmQOS under Validating Webhook", func() {
+		It("Should admit a Delete for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewSlurmQOS("high", controller)
+
+			_, err := slurmQOSWebhook.ValidateDelete(ctx, qos)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
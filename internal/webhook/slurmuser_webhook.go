@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmusers,verbs=delete;create;update
+
+type SlurmUserWebhook struct{}
+
+// log is for logging in this package.
+var slurmuserlog = logf.Log.WithName("slurmuser-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmUserWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.SlurmUser{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmuser,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmusers,verbs=create;update,versions=v1beta1,name=slurmuser-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.SlurmUser] = &SlurmUserWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateCreate(ctx context.Context, user *slinkyv1beta1.SlurmUser) (admission.Warnings, error) {
+	slurmuserlog.Info("validate create", "slurmuser", klog.KObj(user))
+
+	warns, errs := r.validateSlurmUser(user)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateUpdate(ctx context.Context, oldUser, newUser *slinkyv1beta1.SlurmUser) (admission.Warnings, error) {
+	slurmuserlog.Info("validate update", "newSlurmUser", klog.KObj(newUser))
+
+	warns, errs := r.validateSlurmUser(newUser)
+
+	if !apiequality.Semantic.DeepEqual(newUser.Spec.ControllerRef, oldUser.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+	if newUser.UserName() != oldUser.UserName() {
+		errs = append(errs, errors.New("cannot change the user name after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateDelete(ctx context.Context, user *slinkyv1beta1.SlurmUser) (admission.Warnings, error) {
+	slurmuserlog.Info("validate delete", "slurmuser", klog.KObj(user))
+
+	return nil, nil
+}
+
+func (r *SlurmUserWebhook) validateSlurmUser(user *slinkyv1beta1.SlurmUser) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if user.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	if len(user.Spec.Accounts) == 0 {
+		errs = append(errs, errors.New("accounts must not be empty"))
+	}
+	errs = append(errs, validateSlurmdbNames("accounts", user.Spec.Accounts...)...)
+	errs = append(errs, validateSlurmdbNames("qos", user.Spec.QOS...)...)
+
+	if user.Spec.AdminLevel == slinkyv1beta1.SlurmUserAdminLevelAdministrator {
+		warns = append(warns, "adminLevel=Administrator grants the user full control of Slurm")
+	}
+
+	return warns, errs
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+var _ = Describe("SlurmUser Webhook", func() {
+	Context("When creating SlurmUser under Validating Webhook", func() {
+		It("Should admit a Create for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			user := testutils.NewSlurmUser("alice", controller, "science")
+
+			_, err := slurmUserWebhook.ValidateCreate(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if accounts is empty", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			user := testutils.NewSlurmUser("alice", controller)
+
+			_, err := slurmUserWebhook.ValidateCreate(ctx, user)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if the user is an administrator", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			user := testutils.NewSlurmUser("alice", controller, "science")
+			user.Spec.AdminLevel = slinkyv1beta1.SlurmUserAdminLevelAdministrator
+
+			warnings, err := slurmUserWebhook.ValidateCreate(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).NotTo(BeEmpty())
+		})
+	})
+
+	Context("When updating SlurmUser under Validating Webhook", func() {
+		It("Should admit an Update for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldUser := testutils.NewSlurmUser("alice", controller, "science")
+			newUser := testutils.NewSlurmUser("alice", controller, "science", "physics")
+
+			_, err := slurmUserWebhook.ValidateUpdate(ctx, oldUser, newUser)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if the user name changes", func() {
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldUser := testutils.NewSlurmUser("alice", controller, "science")
+			newUser := testutils.NewSlurmUser("alice", controller, "science")
+			newUser.Spec.Name = "bob"
+
+			_, err := slurmUserWebhook.ValidateUpdate(ctx, oldUser, newUser)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When deleting SlurmUser under Validating Webhook", func() {
+		It("Should admit a Delete for a CRD that passes Kube validation", func() {
+			By("Not returning an error")
+			controller := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			user := testutils.NewSlurmUser("alice", controller, "science")
+
+			_, err := slurmUserWebhook.ValidateDelete(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
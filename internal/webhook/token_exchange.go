@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
+)
+
+const (
+	// TokenExchangePath is the path of the token exchange on the webhook server.
+	TokenExchangePath = "/token-exchange"
+
+	// DefaultTokenExchangeLifetime is the lifetime of a JWT, if not requested.
+	DefaultTokenExchangeLifetime = 15 * time.Minute
+	// DefaultTokenExchangeMaxLifetime is the longest lifetime of a JWT.
+	DefaultTokenExchangeMaxLifetime = time.Hour
+	// DefaultTokenExchangeAudience is the audience which the ServiceAccount
+	// token must be valid for, so that tokens for other services (e.g. the
+	// auto-mounted token of the API server) are rejected.
+	DefaultTokenExchangeAudience = "slurm-token-exchange"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+
+// TokenExchangeHandler exchanges a Kubernetes ServiceAccount token for a
+// short-lived Slurm JWT. The ServiceAccount maps to its Slurm username and
+// Controller by its annotations, and the username must be allowed by the
+// TokenUsers of the Controller.
+type TokenExchangeHandler struct {
+	client.Client
+
+	// Reader reads the ServiceAccounts and signing keys, uncached.
+	Reader client.Reader
+	// Audiences are the audiences which the ServiceAccount token must be
+	// valid for. If empty, it is the DefaultTokenExchangeAudience.
+	Audiences []string
+	// MaxLifetime is the longest lifetime of a JWT.
+	MaxLifetime time.Duration
+}
+
+// log is for logging in this package.
+var tokenexchangelog = logf.Log.WithName("token-exchange")
+
+// SetupWebhookWithManager registers the token exchange on the webhook server.
+func (r *TokenExchangeHandler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Reader == nil {
+		r.Reader = mgr.GetAPIReader()
+	}
+	mgr.GetWebhookServer().Register(TokenExchangePath, r)
+	return nil
+}
+
+// TokenExchangeResponse is the Slurm JWT of the ServiceAccount.
+type TokenExchangeResponse struct {
+	// Username is the Slurm username of the JWT.
+	Username string `json:"username"`
+	// Token is the Slurm JWT.
+	Token string `json:"token"`
+	// ExpirationTimestamp is when the JWT expires.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
+
+// tokenExchangeError is an error, with its HTTP status code.
+type tokenExchangeError struct {
+	code int
+	err  error
+}
+
+func (e *tokenExchangeError) Error() string {
+	return e.err.Error()
+}
+
+func newTokenExchangeError(code int, format string, args ...any) error {
+	return &tokenExchangeError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// ServeHTTP implements http.Handler.
+//
+// The ServiceAccount token is given as the bearer token of a POST, and the
+// lifetime of the JWT by the optional `lifetime` query parameter (e.g. "30m").
+func (r *TokenExchangeHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method must be POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	saToken, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || saToken == "" {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	lifetime := DefaultTokenExchangeLifetime
+	if s := req.URL.Query().Get("lifetime"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			http.Error(w, fmt.Sprintf("invalid lifetime %q", s), http.StatusBadRequest)
+			return
+		}
+		lifetime = d
+	}
+
+	resp, err := r.Exchange(req.Context(), saToken, lifetime)
+	if err != nil {
+		code := http.StatusInternalServerError
+		var exchangeErr *tokenExchangeError
+		if errors.As(err, &exchangeErr) {
+			code = exchangeErr.code
+		}
+		tokenexchangelog.Error(err, "failed to exchange token", "code", code)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		tokenexchangelog.Error(err, "failed to write response")
+	}
+}
+
+// Exchange verifies the ServiceAccount token by TokenReview, and returns a
+// Slurm JWT for the Slurm username of the ServiceAccount, signed by its
+// Controller. The lifetime is capped by the MaxLifetime.
+func (r *TokenExchangeHandler) Exchange(ctx context.Context, saToken string, lifetime time.Duration) (*TokenExchangeResponse, error) {
+	audiences := r.Audiences
+	if len(audiences) == 0 {
+		audiences = []string{DefaultTokenExchangeAudience}
+	}
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     saToken,
+			Audiences: audiences,
+		},
+	}
+	if err := r.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, newTokenExchangeError(http.StatusUnauthorized, "token is not authenticated: %s", review.Status.Error)
+	}
+	// The authenticator must confirm an audience, or the token may be for
+	// another service.
+	if !slices.ContainsFunc(review.Status.Audiences, func(audience string) bool {
+		return slices.Contains(audiences, audience)
+	}) {
+		return nil, newTokenExchangeError(http.StatusUnauthorized, "token is not valid for audiences %v", audiences)
+	}
+
+	user := review.Status.User
+	namespace, name, err := serviceaccount.SplitUsername(user.Username)
+	if err != nil {
+		return nil, newTokenExchangeError(http.StatusForbidden, "user %q is not a ServiceAccount", user.Username)
+	}
+
+	sa := &corev1.ServiceAccount{}
+	saKey := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := r.Reader.Get(ctx, saKey, sa); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newTokenExchangeError(http.StatusForbidden, "ServiceAccount %s not found", saKey)
+		}
+		return nil, err
+	}
+	// A ServiceAccount recreated with the same name is not the same identity.
+	if user.UID != "" && user.UID != string(sa.UID) {
+		return nil, newTokenExchangeError(http.StatusForbidden, "ServiceAccount %s UID does not match", saKey)
+	}
+
+	username := sa.Annotations[slinkyv1beta1.AnnotationTokenUsername]
+	if username == "" {
+		return nil, newTokenExchangeError(http.StatusForbidden, "ServiceAccount %s has no %s annotation",
+			saKey, slinkyv1beta1.AnnotationTokenUsername)
+	}
+	controllerName := sa.Annotations[slinkyv1beta1.AnnotationTokenController]
+	if controllerName == "" {
+		return nil, newTokenExchangeError(http.StatusForbidden, "ServiceAccount %s has no %s annotation",
+			saKey, slinkyv1beta1.AnnotationTokenController)
+	}
+
+	refResolver := refresolver.New(r.Reader)
+	controller, err := refResolver.GetController(ctx, corev1.LocalObjectReference{Name: controllerName}, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newTokenExchangeError(http.StatusForbidden, "Controller %s/%s not found", namespace, controllerName)
+		}
+		return nil, err
+	}
+	if !controller.IsTokenUserAllowed(username) {
+		return nil, newTokenExchangeError(http.StatusForbidden, "username %q is not allowed by Controller %s",
+			username, klog.KObj(controller))
+	}
+	signingKey, err := refResolver.GetJwtSigningKey(ctx, controller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	maxLifetime := r.MaxLifetime
+	if maxLifetime <= 0 {
+		maxLifetime = DefaultTokenExchangeMaxLifetime
+	}
+	authToken, err := slurmjwt.NewTokenWithKey(signingKey).
+		WithUsername(username).
+		WithLifetime(min(lifetime, maxLifetime)).
+		NewSignedToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Slurm auth token: %w", err)
+	}
+
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKey(authToken, signingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Slurm auth token: %w", err)
+	}
+	exp, err := authTokenClaims.GetExpirationTime()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiration time: %w", err)
+	}
+	if exp == nil {
+		return nil, errors.New("Slurm auth token has no expiration time")
+	}
+
+	tokenexchangelog.Info("exchanged token", "serviceAccount", saKey, "username", username,
+		"controller", controllerName, "exp", exp.Time)
+
+	return &TokenExchangeResponse{
+		Username:            username,
+		Token:               authToken,
+		ExpirationTimestamp: metav1.NewTime(exp.Time),
+	}, nil
+}
@@ -0,0 +1,416 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+// newTokenReviewClient returns a client whose TokenReviews authenticate the
+// tokens as the users, for their audiences (by default, the
+// DefaultTokenExchangeAudience).
+func newTokenReviewClient(users map[string]authenticationv1.UserInfo, audiences map[string][]string, objs ...client.Object) client.Client {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	return fake.NewClientBuilder().
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authenticationv1.TokenReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				user, ok := users[review.Spec.Token]
+				if !ok {
+					review.Status.Error = "invalid bearer token"
+					return nil
+				}
+				tokenAudiences, ok := audiences[review.Spec.Token]
+				if !ok {
+					tokenAudiences = []string{DefaultTokenExchangeAudience}
+				}
+				for _, audience := range review.Spec.Audiences {
+					if slices.Contains(tokenAudiences, audience) {
+						review.Status.Audiences = append(review.Status.Audiences, audience)
+					}
+				}
+				if len(review.Status.Audiences) == 0 {
+					review.Status.Error = "token audiences is invalid for the target audiences"
+					return nil
+				}
+				review.Status.Authenticated = true
+				review.Status.User = user
+				return nil
+			},
+		}).
+		Build()
+}
+
+func TestTokenExchangeHandler_Exchange(t *testing.T) {
+	jwtKeyRef := testutils.NewJwtKeyRef("slurm")
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), jwtKeyRef, nil)
+	controller.Spec.TokenUsers = []string{slinkyv1beta1.TokenUsersAll}
+	strictController := testutils.NewController("strict", testutils.NewSlurmKeyRef("slurm"), jwtKeyRef, nil)
+	strictController.Spec.TokenUsers = []string{"bob", "root"}
+	jwtKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtKeyRef.Name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			jwtKeyRef.Key: []byte("password1"),
+		},
+	}
+	newServiceAccount := func(name string, annotations map[string]string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   corev1.NamespaceDefault,
+				UID:         types.UID("uid-" + name),
+				Annotations: annotations,
+			},
+		}
+	}
+	mapped := newServiceAccount("pipeline", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "alice",
+		slinkyv1beta1.AnnotationTokenController: "slurm",
+	})
+	unmapped := newServiceAccount("unmapped", nil)
+	noController := newServiceAccount("no-controller", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername: "alice",
+	})
+	missingController := newServiceAccount("missing-controller", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "alice",
+		slinkyv1beta1.AnnotationTokenController: "missing",
+	})
+	root := newServiceAccount("root", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "root",
+		slinkyv1beta1.AnnotationTokenController: "slurm",
+	})
+	slurmUser := newServiceAccount("slurm-user", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "slurm",
+		slinkyv1beta1.AnnotationTokenController: "slurm",
+	})
+	notAllowed := newServiceAccount("not-allowed", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "alice",
+		slinkyv1beta1.AnnotationTokenController: "strict",
+	})
+	explicitRoot := newServiceAccount("explicit-root", map[string]string{
+		slinkyv1beta1.AnnotationTokenUsername:   "root",
+		slinkyv1beta1.AnnotationTokenController: "strict",
+	})
+	users := map[string]authenticationv1.UserInfo{
+		"pipeline":           {Username: "system:serviceaccount:default:pipeline", UID: "uid-pipeline"},
+		"recreated":          {Username: "system:serviceaccount:default:pipeline", UID: "uid-old"},
+		"unmapped":           {Username: "system:serviceaccount:default:unmapped", UID: "uid-unmapped"},
+		"no-controller":      {Username: "system:serviceaccount:default:no-controller"},
+		"missing-controller": {Username: "system:serviceaccount:default:missing-controller"},
+		"deleted":            {Username: "system:serviceaccount:default:deleted"},
+		"user":               {Username: "alice"},
+		"apiserver":          {Username: "system:serviceaccount:default:pipeline", UID: "uid-pipeline"},
+		"root":               {Username: "system:serviceaccount:default:root"},
+		"slurm-user":         {Username: "system:serviceaccount:default:slurm-user"},
+		"not-allowed":        {Username: "system:serviceaccount:default:not-allowed"},
+		"explicit-root":      {Username: "system:serviceaccount:default:explicit-root"},
+	}
+	audiences := map[string][]string{
+		"apiserver": {"https://kubernetes.default.svc"},
+	}
+	c := newTokenReviewClient(users, audiences, controller, strictController, jwtKeySecret,
+		mapped, unmapped, noController, missingController, root, slurmUser, notAllowed, explicitRoot)
+	type args struct {
+		saToken  string
+		lifetime time.Duration
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantUsername string
+		wantLifetime time.Duration
+		wantCode     int
+	}{
+		{
+			name: "Mapped ServiceAccount",
+			args: args{
+				saToken:  "pipeline",
+				lifetime: 10 * time.Minute,
+			},
+			wantUsername: "alice",
+			wantLifetime: 10 * time.Minute,
+		},
+		{
+			name: "Lifetime is capped",
+			args: args{
+				saToken:  "pipeline",
+				lifetime: 24 * time.Hour,
+			},
+			wantUsername: "alice",
+			wantLifetime: DefaultTokenExchangeMaxLifetime,
+		},
+		{
+			name: "Not authenticated",
+			args: args{
+				saToken:  "invalid",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Token for another audience",
+			args: args{
+				saToken:  "apiserver",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Not a ServiceAccount",
+			args: args{
+				saToken:  "user",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "ServiceAccount not found",
+			args: args{
+				saToken:  "deleted",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "ServiceAccount UID does not match",
+			args: args{
+				saToken:  "recreated",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "No username",
+			args: args{
+				saToken:  "unmapped",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "No controller",
+			args: args{
+				saToken:  "no-controller",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "Controller not found",
+			args: args{
+				saToken:  "missing-controller",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "Root is rejected",
+			args: args{
+				saToken:  "root",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "SlurmUser is rejected",
+			args: args{
+				saToken:  "slurm-user",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "Username not allowed",
+			args: args{
+				saToken:  "not-allowed",
+				lifetime: time.Minute,
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "Root allowed explicitly",
+			args: args{
+				saToken:  "explicit-root",
+				lifetime: time.Minute,
+			},
+			wantUsername: "root",
+			wantLifetime: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &TokenExchangeHandler{
+				Client: c,
+				Reader: c,
+			}
+			got, err := r.Exchange(context.TODO(), tt.args.saToken, tt.args.lifetime)
+			if (err != nil) != (tt.wantCode != 0) {
+				t.Fatalf("TokenExchangeHandler.Exchange() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err != nil {
+				exchangeErr, ok := err.(*tokenExchangeError)
+				if !ok || exchangeErr.code != tt.wantCode {
+					t.Errorf("TokenExchangeHandler.Exchange() error = %v, wantCode %v", err, tt.wantCode)
+				}
+				return
+			}
+			if got.Username != tt.wantUsername {
+				t.Errorf("TokenExchangeHandler.Exchange() Username = %v, want %v", got.Username, tt.wantUsername)
+			}
+			claims, err := slurmjwt.ParseTokenClaims(got.Token, jwtKeySecret.Data[jwtKeyRef.Key])
+			if err != nil {
+				t.Fatalf("ParseTokenClaims() error = %v", err)
+			}
+			if claims["sun"] != tt.wantUsername {
+				t.Errorf("ParseTokenClaims() sun = %v, want %v", claims["sun"], tt.wantUsername)
+			}
+			exp, err := claims.GetExpirationTime()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exp.Equal(got.ExpirationTimestamp.Time) {
+				t.Errorf("TokenExchangeHandler.Exchange() ExpirationTimestamp = %v, want %v", got.ExpirationTimestamp, exp.Time)
+			}
+			iat, err := claims.GetIssuedAt()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lifetime := exp.Sub(iat.Time); lifetime != tt.wantLifetime {
+				t.Errorf("TokenExchangeHandler.Exchange() lifetime = %v, want %v", lifetime, tt.wantLifetime)
+			}
+		})
+	}
+}
+
+func TestTokenExchangeHandler_ServeHTTP(t *testing.T) {
+	jwtKeyRef := testutils.NewJwtKeyRef("slurm")
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), jwtKeyRef, nil)
+	controller.Spec.TokenUsers = []string{"alice"}
+	jwtKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtKeyRef.Name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			jwtKeyRef.Key: []byte("password1"),
+		},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: corev1.NamespaceDefault,
+			Annotations: map[string]string{
+				slinkyv1beta1.AnnotationTokenUsername:   "alice",
+				slinkyv1beta1.AnnotationTokenController: "slurm",
+			},
+		},
+	}
+	users := map[string]authenticationv1.UserInfo{
+		"pipeline": {Username: "system:serviceaccount:default:pipeline"},
+	}
+	c := newTokenReviewClient(users, nil, controller, jwtKeySecret, sa)
+	tests := []struct {
+		name          string
+		method        string
+		target        string
+		authorization string
+		wantCode      int
+	}{
+		{
+			name:          "Exchange",
+			method:        http.MethodPost,
+			target:        TokenExchangePath,
+			authorization: "Bearer pipeline",
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:          "With lifetime",
+			method:        http.MethodPost,
+			target:        TokenExchangePath + "?lifetime=5m",
+			authorization: "Bearer pipeline",
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:          "Invalid lifetime",
+			method:        http.MethodPost,
+			target:        TokenExchangePath + "?lifetime=-5m",
+			authorization: "Bearer pipeline",
+			wantCode:      http.StatusBadRequest,
+		},
+		{
+			name:          "Not POST",
+			method:        http.MethodGet,
+			target:        TokenExchangePath,
+			authorization: "Bearer pipeline",
+			wantCode:      http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "No bearer token",
+			method:   http.MethodPost,
+			target:   TokenExchangePath,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "Invalid bearer token",
+			method:        http.MethodPost,
+			target:        TokenExchangePath,
+			authorization: "Bearer invalid",
+			wantCode:      http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &TokenExchangeHandler{
+				Client: c,
+				Reader: c,
+			}
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("TokenExchangeHandler.ServeHTTP() code = %v, want %v: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			got := &TokenExchangeResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Username != "alice" || got.Token == "" {
+				t.Errorf("TokenExchangeHandler.ServeHTTP() = %v", got)
+			}
+		})
+	}
+}
@@ -52,6 +52,9 @@ func (r *TokenWebhook) ValidateUpdate(ctx context.Context, oldToken, newToken *s
 	if !apiequality.Semantic.DeepEqual(newToken.JwtRef(), oldToken.JwtRef()) {
 		errs = append(errs, errors.New("the value of JwtKeyRef or JwtHs256KeyRef cannot be modified after deployment"))
 	}
+	if !apiequality.Semantic.DeepEqual(newToken.Spec.ControllerRef, oldToken.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change ControllerRef after deployment"))
+	}
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -61,6 +61,17 @@ var _ = Describe("Token Webhook", func() {
 			_, err := tokenWebhook.ValidateUpdate(ctx, oldToken, newToken)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should deny if the controllerRef has changed", func() {
+			oldToken := testutils.NewToken("token", &corev1.Secret{})
+			oldToken.Spec.ControllerRef = &corev1.LocalObjectReference{Name: "slurm"}
+
+			newToken := oldToken.DeepCopy()
+			newToken.Spec.ControllerRef = &corev1.LocalObjectReference{Name: "slurm2"}
+
+			_, err := tokenWebhook.ValidateUpdate(ctx, oldToken, newToken)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("When deleting Token under Validating Webhook", func() {
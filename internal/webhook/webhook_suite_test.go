@@ -45,7 +45,11 @@ var accountingWebhook AccountingWebhook
 var controllerWebhook ControllerWebhook
 var loginSetWebhook LoginSetWebhook
 var nodeSetWebhook NodeSetWebhook
+var partitionWebhook PartitionWebhook
 var restapiWebhook RestapiWebhook
+var slurmAccountWebhook SlurmAccountWebhook
+var slurmQOSWebhook SlurmQOSWebhook
+var slurmUserWebhook SlurmUserWebhook
 var tokenWebhook TokenWebhook
 
 func TestAPIs(t *testing.T) {
@@ -121,6 +125,12 @@ var _ = BeforeSuite(func() {
 	err = (&nodeSetWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	partitionWebhook = PartitionWebhook{
+		Client: mgr.GetClient(),
+	}
+	err = (&partitionWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&PodBindingWebhook{
 		Client: mgr.GetClient(),
 	}).SetupWebhookWithManager(mgr)
@@ -129,6 +139,15 @@ var _ = BeforeSuite(func() {
 	err = (&restapiWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&slurmAccountWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&slurmQOSWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&slurmUserWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&tokenWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -4,6 +4,8 @@
 package conditions
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
@@ -32,9 +34,34 @@ const (
 	PodConditionUndrain       corev1.PodConditionType = PodStatePrefix + "Undrain"
 )
 
+const (
+	// Workload Condition Type (e.g. Controller, Accounting, RestApi, LoginSet)
+	ConditionReady       = "Ready"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+)
+
 const (
 	// NodeSet Condition Type
 	NodeSetConditionReservationCreated = "ReservationCreated"
+	NodeSetConditionRolledBack         = "RolledBack"
+)
+
+const (
+	// Controller Condition Type
+	ControllerConditionConfigInvalid       = "ConfigInvalid"
+	ControllerConditionFailover            = "Failover"
+	ControllerConditionSlurmctldResponding = "SlurmctldResponding"
+)
+
+const (
+	// Accounting Condition Type
+	AccountingConditionSlurmdbdReachable = "SlurmdbdReachable"
+)
+
+const (
+	// Slurm accounting entity (e.g. SlurmAccount) Condition Type
+	SlurmdbConditionSynced = "Synced"
 )
 
 func IsConditionTrue(status *corev1.PodStatus, condType corev1.PodConditionType) bool {
@@ -63,3 +90,25 @@ func IsNodeDrain(status *corev1.PodStatus) bool {
 	return IsConditionTrue(status, PodConditionDrain) &&
 		!IsConditionTrue(status, PodConditionUndrain)
 }
+
+// Failed is a conceptual state that means the node is unusable due to a fault.
+func IsNodeFailed(status *corev1.PodStatus) bool {
+	return IsConditionTrue(status, PodConditionDown) ||
+		IsConditionTrue(status, PodConditionError) ||
+		IsConditionTrue(status, PodConditionFail) ||
+		IsConditionTrue(status, PodConditionNotResponding)
+}
+
+// Registered is a conceptual state that means the node is known to slurmctld
+// and has been started.
+func IsNodeRegistered(status *corev1.PodStatus) bool {
+	for _, cond := range status.Conditions {
+		if !strings.HasPrefix(string(cond.Type), PodStatePrefix) || cond.Type == PodConditionFuture {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
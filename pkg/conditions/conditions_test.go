@@ -355,3 +355,161 @@ func TestAreJobsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNodeFailed(t *testing.T) {
+	type args struct {
+		status *corev1.PodStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Node is idle",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is down",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionDown,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is not responding",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionNotResponding,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is failing",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionAllocated,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionFail,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNodeFailed(tt.args.status); got != tt.want {
+				t.Errorf("IsNodeFailed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNodeRegistered(t *testing.T) {
+	type args struct {
+		status *corev1.PodStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "No Slurm node state",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is future",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionFuture,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is idle",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is down",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionDown,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNodeRegistered(tt.args.status); got != tt.want {
+				t.Errorf("IsNodeRegistered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}